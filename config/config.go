@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -36,6 +37,79 @@ type Config struct {
 	StripeKey                string `mapstructure:"STRIPE_KEY"`
 	GeminiAPIKey             string `mapstructure:"GEMINI_KEY"`
 	ExchangeRateAPIKey       string `mapstructure:"EXCHANGE_RATE_API_KEY"`
+
+	// Exchange rates. EXCHANGE_RATE_PROVIDER is "api" (exchangerate-api.com) or "static",
+	// which reads EXCHANGE_RATE_FILE and never touches the network.
+	ExchangeRateProvider       string `mapstructure:"EXCHANGE_RATE_PROVIDER"`
+	ExchangeRateFile           string `mapstructure:"EXCHANGE_RATE_FILE"`
+	ExchangeRateTTLMinutes     int    `mapstructure:"EXCHANGE_RATE_TTL_MINUTES"`
+	ExchangeRateRefreshMinutes int    `mapstructure:"EXCHANGE_RATE_REFRESH_MINUTES"`
+
+	// Price quotes. The signing secret falls back to JWT_SECRET when unset.
+	QuoteSigningSecret string `mapstructure:"QUOTE_SIGNING_SECRET"`
+	QuoteTTLMinutes    int    `mapstructure:"QUOTE_TTL_MINUTES"`
+
+	// Discounts. A zero rate or amount disables the discount.
+	FirstBookingDiscountRate float64 `mapstructure:"FIRST_BOOKING_DISCOUNT_RATE"` // e.g. 0.1 for 10% off
+	FirstBookingDiscountMax  float64 `mapstructure:"FIRST_BOOKING_DISCOUNT_MAX"`  // cap in the booking currency; 0 means uncapped
+	ReferralRewardAmount     float64 `mapstructure:"REFERRAL_REWARD_AMOUNT"`      // paid to both the referrer and the new user
+	ReferralRewardCurrency   string  `mapstructure:"REFERRAL_REWARD_CURRENCY"`
+
+	// Deposit plans. A failed balance charge is retried BALANCE_CHARGE_RETRIES times,
+	// BALANCE_RETRY_MINUTES apart; BALANCE_DUE_HOURS applies when a provider sets none.
+	BalanceDueHours      int `mapstructure:"BALANCE_DUE_HOURS"`
+	BalanceChargeRetries int `mapstructure:"BALANCE_CHARGE_RETRIES"`
+	BalanceRetryMinutes  int `mapstructure:"BALANCE_RETRY_MINUTES"`
+
+	// No-shows. A provider who has not checked in NO_SHOW_THRESHOLD_MINUTES after a
+	// booking starts is a no-show, unless the user set their own threshold. The user
+	// is refunded automatically when they don't choose within NO_SHOW_REFUND_HOURS.
+	NoShowThresholdMinutes int `mapstructure:"NO_SHOW_THRESHOLD_MINUTES"`
+	NoShowRefundHours      int `mapstructure:"NO_SHOW_REFUND_HOURS"`
+
+	// Live tracking of in-home bookings. Providers can set off at most
+	// TRACKING_LEAD_MINUTES before a booking starts and report a location at most
+	// every TRACKING_MIN_INTERVAL_SECONDS. ETAs assume TRACKING_SPEED_KMH in a
+	// straight line.
+	TrackingLeadMinutes        int     `mapstructure:"TRACKING_LEAD_MINUTES"`
+	TrackingMinIntervalSeconds int     `mapstructure:"TRACKING_MIN_INTERVAL_SECONDS"`
+	TrackingSpeedKmh           float64 `mapstructure:"TRACKING_SPEED_KMH"`
+
+	// Booking chat. A booking's chat stops accepting messages CHAT_CLOSE_HOURS
+	// after the job is completed, or after the booking ends when it never was.
+	// Images are limited to CHAT_MAX_IMAGE_MB.
+	ChatCloseHours int `mapstructure:"CHAT_CLOSE_HOURS"`
+	ChatMaxImageMB int `mapstructure:"CHAT_MAX_IMAGE_MB"`
+
+	// Provider dashboards stream domain events in real time. The newest
+	// PROVIDER_EVENT_BACKLOG events of each provider are kept for
+	// PROVIDER_EVENT_RETENTION_HOURS so reconnecting clients can catch up.
+	ProviderEventBacklog        int `mapstructure:"PROVIDER_EVENT_BACKLOG"`
+	ProviderEventRetentionHours int `mapstructure:"PROVIDER_EVENT_RETENTION_HOURS"`
+
+	// Masked calls. Users and providers reach each other through proxy numbers
+	// leased from the relay at CALL_RELAY_API_URL; CALL_RELAY_TRANSPORT=fake
+	// hands out made-up numbers instead. Without either, calls are unavailable.
+	CallRelayTransport string `mapstructure:"CALL_RELAY_TRANSPORT"`
+	CallRelayAPIURL    string `mapstructure:"CALL_RELAY_API_URL"`
+	CallRelayAPIKey    string `mapstructure:"CALL_RELAY_API_KEY"`
+
+	// Notification channels. NOTIFICATION_TRANSPORT is "live" or "memory", which
+	// keeps every message in process and sends nothing. SMS and WhatsApp go through
+	// the HTTP gateway at MESSAGING_API_URL; email through SMTP.
+	NotificationTransport string `mapstructure:"NOTIFICATION_TRANSPORT"`
+	MessagingAPIURL       string `mapstructure:"MESSAGING_API_URL"`
+	MessagingAPIKey       string `mapstructure:"MESSAGING_API_KEY"`
+	SMSSenderID           string `mapstructure:"SMS_SENDER_ID"`
+	WhatsAppSender        string `mapstructure:"WHATSAPP_SENDER"`
+	SMTPHost              string `mapstructure:"SMTP_HOST"`
+	SMTPPort              int    `mapstructure:"SMTP_PORT"`
+	SMTPUsername          string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword          string `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom              string `mapstructure:"SMTP_FROM"`
+
+	// Inbox notifications expire NOTIFICATION_RETENTION_DAYS after they are created.
+	NotificationRetentionDays int `mapstructure:"NOTIFICATION_RETENTION_DAYS"`
 }
 
 var AppConfig Config
@@ -73,6 +147,29 @@ func LoadConfig() {
 	viper.SetDefault("REDIS_OTP_DB", 2)
 	viper.SetDefault("DATABASE_URL", "mongodb://localhost:27017")
 	viper.SetDefault("GOOGLE_API_KEY", "")
+	viper.SetDefault("EXCHANGE_RATE_PROVIDER", "api")
+	viper.SetDefault("EXCHANGE_RATE_FILE", "config/exchangeRates.json")
+	viper.SetDefault("EXCHANGE_RATE_TTL_MINUTES", 360)
+	viper.SetDefault("EXCHANGE_RATE_REFRESH_MINUTES", 60)
+	viper.SetDefault("QUOTE_TTL_MINUTES", 15)
+	viper.SetDefault("REFERRAL_REWARD_CURRENCY", "KES")
+	viper.SetDefault("BALANCE_DUE_HOURS", 48)
+	viper.SetDefault("BALANCE_CHARGE_RETRIES", 3)
+	viper.SetDefault("BALANCE_RETRY_MINUTES", 120)
+	viper.SetDefault("NO_SHOW_THRESHOLD_MINUTES", 15)
+	viper.SetDefault("NO_SHOW_REFUND_HOURS", 24)
+	viper.SetDefault("TRACKING_LEAD_MINUTES", 120)
+	viper.SetDefault("TRACKING_MIN_INTERVAL_SECONDS", 5)
+	viper.SetDefault("TRACKING_SPEED_KMH", 25)
+	viper.SetDefault("CHAT_CLOSE_HOURS", 48)
+	viper.SetDefault("CHAT_MAX_IMAGE_MB", 5)
+	viper.SetDefault("PROVIDER_EVENT_BACKLOG", 500)
+	viper.SetDefault("PROVIDER_EVENT_RETENTION_HOURS", 72)
+	viper.SetDefault("CALL_RELAY_TRANSPORT", "live")
+	viper.SetDefault("NOTIFICATION_TRANSPORT", "live")
+	viper.SetDefault("SMTP_PORT", 587)
+	viper.SetDefault("SMS_SENDER_ID", "Bloomify")
+	viper.SetDefault("NOTIFICATION_RETENTION_DAYS", 90)
 
 	if err := viper.ReadInConfig(); err != nil {
 		log.Println("No config file found, using environment variables only")
@@ -84,6 +181,11 @@ func LoadConfig() {
 
 	// country bias map from json
 	LoadCountryBiasMap("config/countryBias.json")
+
+	// optional tax table override; the compiled-in table is used otherwise
+	if err := LoadTaxTable("config/taxTable.json"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Using built-in tax table: %v", err)
+	}
 }
 
 func GetEnv() string {
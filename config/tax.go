@@ -0,0 +1,67 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+)
+
+// TaxRule is the tax and platform fee applied to bookings served in a country.
+type TaxRule struct {
+	TaxName         string   `json:"taxName"`                   // e.g. "VAT", "GST"
+	TaxRate         float64  `json:"taxRate"`                   // e.g. 0.16
+	PlatformFeeRate *float64 `json:"platformFeeRate,omitempty"` // overrides the default fee when set
+}
+
+// DefaultTaxCountry is the TaxTable key used for countries without their own rule.
+const DefaultTaxCountry = "default"
+
+func feeRate(rate float64) *float64 { return &rate }
+
+// TaxTable maps ISO country codes to their rule. It can be replaced from
+// config/taxTable.json without a rebuild.
+var TaxTable = map[string]TaxRule{
+	DefaultTaxCountry: {PlatformFeeRate: feeRate(0.05)},
+	"KE":              {TaxName: "VAT", TaxRate: 0.16},
+	"UG":              {TaxName: "VAT", TaxRate: 0.18},
+	"TZ":              {TaxName: "VAT", TaxRate: 0.18},
+	"RW":              {TaxName: "VAT", TaxRate: 0.18},
+	"NG":              {TaxName: "VAT", TaxRate: 0.075},
+	"ZA":              {TaxName: "VAT", TaxRate: 0.15},
+	"GB":              {TaxName: "VAT", TaxRate: 0.20},
+	"IN":              {TaxName: "GST", TaxRate: 0.18},
+	"AU":              {TaxName: "GST", TaxRate: 0.10},
+}
+
+func LoadTaxTable(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read tax table file: %w", err)
+	}
+	table := map[string]TaxRule{}
+	if err := json.Unmarshal(data, &table); err != nil {
+		return fmt.Errorf("failed to parse tax table JSON: %w", err)
+	}
+	TaxTable = table
+	log.Println("Successfully loaded tax table")
+	return nil
+}
+
+// TaxRuleFor returns the tax name, tax rate and platform fee rate for a country.
+func TaxRuleFor(countryCode string) (string, float64, float64) {
+	def := TaxTable[DefaultTaxCountry]
+	rule, ok := TaxTable[countryCode]
+	if !ok {
+		rule = def
+	}
+
+	var fee float64
+	switch {
+	case rule.PlatformFeeRate != nil:
+		fee = *rule.PlatformFeeRate
+	case def.PlatformFeeRate != nil:
+		fee = *def.PlatformFeeRate
+	}
+	return rule.TaxName, rule.TaxRate, fee
+}
@@ -3,12 +3,14 @@ package cron
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"time"
 
 	"bloomify/config"
 	"bloomify/models"
 	"bloomify/services/notification"
+	"bloomify/services/tasks"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/hibiken/asynq"
@@ -16,8 +18,23 @@ import (
 
 const TypeReminderSend = "reminder:send"
 
-// InitReminderWorker runs the async worker in background.
-func InitReminderWorker(notifSvc notification.NotificationService) {
+// BalanceCharger charges the balance of a deposit booking. final is set on the
+// last attempt.
+type BalanceCharger interface {
+	ChargeBookingBalance(ctx context.Context, bookingID string, final bool) error
+}
+
+// NoShowHandler flags bookings the provider did not check in for, and refunds
+// those the user left unresolved.
+type NoShowHandler interface {
+	CheckProviderNoShow(ctx context.Context, bookingID string) error
+	RefundNoShow(ctx context.Context, bookingID string) error
+}
+
+// InitReminderWorker runs the async worker in background. It also runs the
+// balance charges of deposit bookings and the no-show checks, which share the
+// reminder queue.
+func InitReminderWorker(notifSvc notification.NotificationService, balances BalanceCharger, noShows NoShowHandler) {
 	redisOpts := asynq.RedisClientOpt{
 		Addr:     config.AppConfig.RedisAddr,
 		Password: config.AppConfig.RedisPassword,
@@ -31,11 +48,15 @@ func InitReminderWorker(notifSvc notification.NotificationService) {
 			Queues: map[string]int{
 				"default": 1,
 			},
+			RetryDelayFunc: retryDelay,
 		},
 	)
 
 	mux := asynq.NewServeMux()
 	mux.HandleFunc(TypeReminderSend, handleReminderTask(notifSvc))
+	mux.HandleFunc(tasks.TypeChargeBalance, handleBalanceTask(balances))
+	mux.HandleFunc(tasks.TypeNoShowCheck, handleNoShowTask(noShows.CheckProviderNoShow))
+	mux.HandleFunc(tasks.TypeNoShowRefund, handleNoShowTask(noShows.RefundNoShow))
 
 	// Start Redis health monitor
 	go monitorRedisConnection()
@@ -76,13 +97,19 @@ func handleReminderTask(notifSvc notification.NotificationService) asynq.Handler
 			"title":      p.Title,
 			"body":       p.Body,
 		}
+		if p.Type != "" {
+			data["type"] = p.Type
+		}
+		if p.BookingID != "" {
+			data["bookingId"] = p.BookingID
+		}
 
 		var err error
 		switch p.Target {
 		case "user":
-			err = notifSvc.SendUserPushNotification(ctx, p.ID, p.Title, p.Body, data)
+			err = notifSvc.NotifyUser(ctx, p.ID, p.Title, p.Body, data)
 		case "provider":
-			err = notifSvc.SendProviderPushNotification(ctx, p.ID, p.Title, p.Body, data)
+			err = notifSvc.NotifyProvider(ctx, p.ID, p.Title, p.Body, data)
 		default:
 			log.Printf("[ReminderHandler] ⚠️ Unknown target type: %s", p.Target)
 			return nil
@@ -95,6 +122,49 @@ func handleReminderTask(notifSvc notification.NotificationService) asynq.Handler
 	}
 }
 
+func handleBalanceTask(balances BalanceCharger) asynq.HandlerFunc {
+	return func(ctx context.Context, task *asynq.Task) error {
+		var p tasks.BalanceChargePayload
+		if err := json.Unmarshal(task.Payload(), &p); err != nil {
+			log.Printf("[BalanceHandler] 🔴 Invalid payload: %v", err)
+			return fmt.Errorf("invalid balance payload: %v: %w", err, asynq.SkipRetry)
+		}
+
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+		err := balances.ChargeBookingBalance(ctx, p.BookingID, retried >= maxRetry)
+		if err != nil {
+			log.Printf("[BalanceHandler] ❌ Attempt %d/%d failed: %v", retried+1, maxRetry+1, err)
+		}
+		return err
+	}
+}
+
+func handleNoShowTask(run func(ctx context.Context, bookingID string) error) asynq.HandlerFunc {
+	return func(ctx context.Context, task *asynq.Task) error {
+		var p tasks.NoShowPayload
+		if err := json.Unmarshal(task.Payload(), &p); err != nil {
+			log.Printf("[NoShowHandler] 🔴 Invalid payload: %v", err)
+			return fmt.Errorf("invalid no-show payload: %v: %w", err, asynq.SkipRetry)
+		}
+
+		err := run(ctx, p.BookingID)
+		if err != nil {
+			log.Printf("[NoShowHandler] ❌ %s for booking %s failed: %v", task.Type(), p.BookingID, err)
+		}
+		return err
+	}
+}
+
+// retryDelay spaces balance charge retries BALANCE_RETRY_MINUTES apart, giving
+// the user time to fix their card; other tasks use the default backoff.
+func retryDelay(n int, err error, task *asynq.Task) time.Duration {
+	if task.Type() == tasks.TypeChargeBalance && config.AppConfig.BalanceRetryMinutes > 0 {
+		return time.Duration(config.AppConfig.BalanceRetryMinutes) * time.Minute
+	}
+	return asynq.DefaultRetryDelayFunc(n, err, task)
+}
+
 // monitorRedisConnection pings Redis periodically to detect failures at runtime.
 func monitorRedisConnection() {
 	client := redis.NewClient(&redis.Options{
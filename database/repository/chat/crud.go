@@ -0,0 +1,246 @@
+package chatRepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"bloomify/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// maxPageSize caps how many threads or messages one page returns.
+const maxPageSize = 100
+
+// GetThread returns the chat thread of a booking.
+func (r *mongoChatRepo) GetThread(ctx context.Context, bookingID string) (*models.ChatThread, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var thread models.ChatThread
+	if err := r.threads.FindOne(ctx, bson.M{"bookingId": bookingID}).Decode(&thread); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrThreadNotFound
+		}
+		return nil, fmt.Errorf("failed to load chat thread: %w", err)
+	}
+	return &thread, nil
+}
+
+// ListThreads returns a page of threads, most recently active first. Pages
+// start at 1.
+func (r *mongoChatRepo) ListThreads(ctx context.Context, filter models.ChatThreadFilter, page, limit int64) (*models.ChatThreadPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > maxPageSize {
+		limit = 20
+	}
+
+	query := bson.M{}
+	if filter.UserID != "" {
+		query["userId"] = filter.UserID
+	}
+	if filter.ProviderID != "" {
+		query["providerId"] = filter.ProviderID
+	}
+	if filter.FlaggedOnly {
+		query["flagged"] = true
+	}
+	total, err := r.threads.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count chat threads: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "lastMessageAt", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.threads.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat threads: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	threads := []models.ChatThread{}
+	if err := cursor.All(ctx, &threads); err != nil {
+		return nil, fmt.Errorf("failed to decode chat threads: %w", err)
+	}
+	return &models.ChatThreadPage{Threads: threads, Total: total, Page: page, Limit: limit}, nil
+}
+
+// AddMessage stores a message and updates its thread's preview and the
+// recipient's unread count.
+func (r *mongoChatRepo) AddMessage(ctx context.Context, thread models.ChatThread, msg models.ChatMessage) error {
+	if msg.ID == "" || msg.BookingID == "" {
+		return fmt.Errorf("message id and booking are required")
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := r.messages.InsertOne(ctx, msg); err != nil {
+		return fmt.Errorf("failed to store chat message: %w", err)
+	}
+
+	recipient := models.ChatRoleProvider
+	if msg.SenderRole == models.ChatRoleProvider {
+		recipient = models.ChatRoleUser
+	}
+	_, err := r.threads.UpdateOne(ctx,
+		bson.M{"bookingId": msg.BookingID},
+		bson.M{
+			"$set": bson.M{
+				"lastMessage":   messagePreview(msg),
+				"lastMessageAt": msg.CreatedAt,
+			},
+			"$inc": bson.M{"unread." + recipient: 1},
+			"$setOnInsert": bson.M{
+				"userId":     thread.UserID,
+				"providerId": thread.ProviderID,
+				"flagged":    false,
+				"createdAt":  msg.CreatedAt,
+			},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update chat thread: %w", err)
+	}
+	return nil
+}
+
+// messagePreview is the start of a message's text, or a placeholder for an
+// image.
+func messagePreview(msg models.ChatMessage) string {
+	const maxPreview = 80
+	text := []rune(msg.Text)
+	switch {
+	case len(text) > maxPreview:
+		return string(text[:maxPreview]) + "…"
+	case len(text) > 0:
+		return msg.Text
+	case len(msg.Attachments) > 0:
+		return "[image]"
+	}
+	return ""
+}
+
+// ListMessages returns a page of a thread's messages, newest first.
+func (r *mongoChatRepo) ListMessages(ctx context.Context, bookingID string, before time.Time, limit int64, includeHidden bool) ([]models.ChatMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if limit < 1 || limit > maxPageSize {
+		limit = 50
+	}
+	query := bson.M{"bookingId": bookingID}
+	if !before.IsZero() {
+		query["createdAt"] = bson.M{"$lt": before}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}).SetLimit(limit)
+	cursor, err := r.messages.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat messages: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	messages := []models.ChatMessage{}
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, fmt.Errorf("failed to decode chat messages: %w", err)
+	}
+	if !includeHidden {
+		for i := range messages {
+			if messages[i].Hidden {
+				messages[i].Text = ""
+				messages[i].Attachments = nil
+			}
+			messages[i].Reports = nil
+			messages[i].Moderation = nil
+			messages[i].Flagged = false
+		}
+	}
+	return messages, nil
+}
+
+// MarkRead sets the read time of unread messages sent to readerRole and
+// resets the reader's unread count.
+func (r *mongoChatRepo) MarkRead(ctx context.Context, bookingID, readerRole string, at time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := r.messages.UpdateMany(ctx,
+		bson.M{
+			"bookingId":  bookingID,
+			"senderRole": bson.M{"$ne": readerRole},
+			"readAt":     nil,
+			"createdAt":  bson.M{"$lte": at},
+		},
+		bson.M{"$set": bson.M{"readAt": at}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark chat messages read: %w", err)
+	}
+	if _, err := r.threads.UpdateOne(ctx,
+		bson.M{"bookingId": bookingID},
+		bson.M{"$set": bson.M{"unread." + readerRole: 0, "readAt." + readerRole: at}},
+	); err != nil {
+		return 0, fmt.Errorf("failed to update chat thread: %w", err)
+	}
+	return res.ModifiedCount, nil
+}
+
+// ReportMessage adds a report to a message and flags it and its thread.
+func (r *mongoChatRepo) ReportMessage(ctx context.Context, bookingID, messageID string, report models.ChatReport) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := r.messages.UpdateOne(ctx,
+		bson.M{"bookingId": bookingID, "id": messageID},
+		bson.M{"$set": bson.M{"flagged": true}, "$push": bson.M{"reports": report}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to report chat message: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrMessageNotFound
+	}
+	if _, err := r.threads.UpdateOne(ctx, bson.M{"bookingId": bookingID}, bson.M{"$set": bson.M{"flagged": true}}); err != nil {
+		return fmt.Errorf("failed to flag chat thread: %w", err)
+	}
+	return nil
+}
+
+// ModerateMessage records a moderator's decision on a message.
+func (r *mongoChatRepo) ModerateMessage(ctx context.Context, bookingID, messageID string, moderation models.ChatModeration) (*models.ChatMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var msg models.ChatMessage
+	err := r.messages.FindOneAndUpdate(ctx,
+		bson.M{"bookingId": bookingID, "id": messageID},
+		bson.M{"$set": bson.M{"hidden": moderation.Hidden, "flagged": false, "moderation": moderation}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&msg)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to moderate chat message: %w", err)
+	}
+
+	pending, err := r.messages.CountDocuments(ctx, bson.M{"bookingId": bookingID, "flagged": true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count flagged chat messages: %w", err)
+	}
+	if _, err := r.threads.UpdateOne(ctx, bson.M{"bookingId": bookingID}, bson.M{"$set": bson.M{"flagged": pending > 0}}); err != nil {
+		return nil, fmt.Errorf("failed to update chat thread: %w", err)
+	}
+	return &msg, nil
+}
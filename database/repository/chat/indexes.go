@@ -0,0 +1,33 @@
+package chatRepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (r *mongoChatRepo) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.threads.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "bookingId", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "lastMessageAt", Value: -1}}},
+		{Keys: bson.D{{Key: "providerId", Value: 1}, {Key: "lastMessageAt", Value: -1}}},
+		{Keys: bson.D{{Key: "flagged", Value: 1}, {Key: "lastMessageAt", Value: -1}}},
+	}); err != nil {
+		return fmt.Errorf("failed to create chat thread indexes: %w", err)
+	}
+
+	if _, err := r.messages.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "bookingId", Value: 1}, {Key: "createdAt", Value: -1}}},
+	}); err != nil {
+		return fmt.Errorf("failed to create chat message indexes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package chatRepo
+
+import (
+	"bloomify/database"
+	"bloomify/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrThreadNotFound is returned when a booking has no chat yet.
+var ErrThreadNotFound = errors.New("chat thread not found")
+
+// ErrMessageNotFound is returned when a message is not part of the thread.
+var ErrMessageNotFound = errors.New("chat message not found")
+
+// ChatRepository persists booking chats: one thread per booking and its
+// messages.
+type ChatRepository interface {
+	GetThread(ctx context.Context, bookingID string) (*models.ChatThread, error)
+	ListThreads(ctx context.Context, filter models.ChatThreadFilter, page, limit int64) (*models.ChatThreadPage, error)
+	// AddMessage stores a message, creating the thread on the first one, and
+	// counts it as unread for the other side.
+	AddMessage(ctx context.Context, thread models.ChatThread, msg models.ChatMessage) error
+	// ListMessages returns up to limit messages sent before before (all when it
+	// is zero), newest first. Hidden messages keep only their metadata unless
+	// includeHidden is set.
+	ListMessages(ctx context.Context, bookingID string, before time.Time, limit int64, includeHidden bool) ([]models.ChatMessage, error)
+	// MarkRead marks the messages sent to readerRole up to at as read and returns
+	// how many changed.
+	MarkRead(ctx context.Context, bookingID, readerRole string, at time.Time) (int64, error)
+	// ReportMessage flags a message and its thread for moderation.
+	ReportMessage(ctx context.Context, bookingID, messageID string, report models.ChatReport) error
+	// ModerateMessage hides or restores a message and clears its flag; the thread
+	// stays flagged while other messages await moderation.
+	ModerateMessage(ctx context.Context, bookingID, messageID string, moderation models.ChatModeration) (*models.ChatMessage, error)
+}
+
+type mongoChatRepo struct {
+	threads  *mongo.Collection
+	messages *mongo.Collection
+}
+
+// NewMongoChatRepo returns a ChatRepository backed by MongoDB.
+func NewMongoChatRepo() ChatRepository {
+	db := database.MongoClient.Database("bloomify")
+	repo := &mongoChatRepo{
+		threads:  db.Collection("chat_threads"),
+		messages: db.Collection("chat_messages"),
+	}
+
+	if err := repo.ensureIndexes(); err != nil {
+		fmt.Printf("failed to create chat indexes: %v\n", err)
+	}
+	return repo
+}
@@ -0,0 +1,57 @@
+package ledgerRepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bloomify/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Record inserts a ledger entry; an entry with the same ID is left untouched.
+func (r *mongoLedgerRepo) Record(ctx context.Context, entry *models.LedgerEntry) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	if _, err := r.coll.InsertOne(ctx, entry); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to record ledger entry: %w", err)
+	}
+	return nil
+}
+
+// ListByProvider returns a provider's ledger, newest first.
+func (r *mongoLedgerRepo) ListByProvider(ctx context.Context, providerID string) ([]models.LedgerEntry, error) {
+	return r.list(ctx, bson.M{"providerId": providerID})
+}
+
+// ListByBooking returns every ledger entry of a booking, newest first.
+func (r *mongoLedgerRepo) ListByBooking(ctx context.Context, bookingID string) ([]models.LedgerEntry, error) {
+	return r.list(ctx, bson.M{"bookingId": bookingID})
+}
+
+func (r *mongoLedgerRepo) list(ctx context.Context, filter bson.M) ([]models.LedgerEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.coll.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ledger entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := []models.LedgerEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode ledger entries: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,36 @@
+package ledgerRepo
+
+import (
+	"bloomify/database"
+	"bloomify/models"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// LedgerRepository stores the commission ledger: one entry per payment a
+// provider earns from. Entries are keyed by a deterministic ID, so recording
+// the same payment twice is a no-op.
+type LedgerRepository interface {
+	Record(ctx context.Context, entry *models.LedgerEntry) error
+	ListByProvider(ctx context.Context, providerID string) ([]models.LedgerEntry, error)
+	ListByBooking(ctx context.Context, bookingID string) ([]models.LedgerEntry, error)
+}
+
+type mongoLedgerRepo struct {
+	coll *mongo.Collection
+}
+
+// NewMongoLedgerRepo returns a LedgerRepository backed by MongoDB.
+func NewMongoLedgerRepo() LedgerRepository {
+	db := database.MongoClient.Database("bloomify")
+	repo := &mongoLedgerRepo{
+		coll: db.Collection("provider_ledger"),
+	}
+
+	if err := repo.ensureIndexes(); err != nil {
+		fmt.Printf("failed to create ledger indexes: %v\n", err)
+	}
+	return repo
+}
@@ -0,0 +1,52 @@
+package notificationRepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bloomify/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RecordDeliveries stores the delivery results of one dispatch.
+func (r *mongoNotificationRepo) RecordDeliveries(ctx context.Context, deliveries []models.NotificationDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	docs := make([]any, len(deliveries))
+	for i, d := range deliveries {
+		docs[i] = d
+	}
+	if _, err := r.deliveries.InsertMany(ctx, docs); err != nil {
+		return fmt.Errorf("failed to record notification deliveries: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries returns a recipient's most recent delivery results.
+func (r *mongoNotificationRepo) ListDeliveries(ctx context.Context, recipientID string, limit int64) ([]models.NotificationDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+	cursor, err := r.deliveries.Find(ctx, bson.M{"recipientId": recipientID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification deliveries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []models.NotificationDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, fmt.Errorf("failed to decode notification deliveries: %w", err)
+	}
+	return deliveries, nil
+}
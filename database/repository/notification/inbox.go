@@ -0,0 +1,189 @@
+package notificationRepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bloomify/config"
+	"bloomify/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// maxPageSize caps how many notifications one page returns.
+const maxPageSize = 100
+
+// expiry returns when a notification created at createdAt leaves the inbox.
+func expiry(createdAt time.Time) time.Time {
+	days := config.AppConfig.NotificationRetentionDays
+	if days <= 0 {
+		days = 90
+	}
+	return createdAt.AddDate(0, 0, days)
+}
+
+// AddNotification stores a notification in its recipient's inbox.
+func (r *mongoNotificationRepo) AddNotification(ctx context.Context, n models.Notification) error {
+	if n.ID == "" || n.RecipientID == "" {
+		return fmt.Errorf("notification id and recipient are required")
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now()
+	}
+	if n.UpdatedAt.IsZero() {
+		n.UpdatedAt = n.CreatedAt
+	}
+	if n.ExpiresAt.IsZero() {
+		n.ExpiresAt = expiry(n.CreatedAt)
+	}
+	if _, err := r.inbox.InsertOne(ctx, n); err != nil {
+		return fmt.Errorf("failed to store notification: %w", err)
+	}
+	return nil
+}
+
+// ListNotifications returns a page of a recipient's inbox, newest first, with
+// the total and unread counts. Pages start at 1.
+func (r *mongoNotificationRepo) ListNotifications(ctx context.Context, recipientID string, unreadOnly bool, page, limit int64) (*models.NotificationPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > maxPageSize {
+		limit = 20
+	}
+
+	filter := bson.M{"recipientId": recipientID}
+	if unreadOnly {
+		filter["read"] = false
+	}
+	total, err := r.inbox.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	unread, err := r.inbox.CountDocuments(ctx, bson.M{"recipientId": recipientID, "read": false})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.inbox.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	notifications := []models.Notification{}
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, fmt.Errorf("failed to decode notifications: %w", err)
+	}
+	return &models.NotificationPage{
+		Notifications: notifications,
+		Total:         total,
+		Unread:        unread,
+		Page:          page,
+		Limit:         limit,
+	}, nil
+}
+
+// UnreadCount returns how many unread notifications a recipient has.
+func (r *mongoNotificationRepo) UnreadCount(ctx context.Context, recipientID string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	n, err := r.inbox.CountDocuments(ctx, bson.M{"recipientId": recipientID, "read": false})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return n, nil
+}
+
+// MarkNotificationsAsRead marks the given notifications of a recipient read and
+// returns how many changed.
+func (r *mongoNotificationRepo) MarkNotificationsAsRead(ctx context.Context, recipientID string, notificationIDs []string) (int64, error) {
+	if len(notificationIDs) == 0 {
+		return 0, nil
+	}
+	return r.markRead(ctx, bson.M{"recipientId": recipientID, "id": bson.M{"$in": notificationIDs}, "read": false})
+}
+
+// MarkAllAsRead marks every notification of a recipient read.
+func (r *mongoNotificationRepo) MarkAllAsRead(ctx context.Context, recipientID string) (int64, error) {
+	return r.markRead(ctx, bson.M{"recipientId": recipientID, "read": false})
+}
+
+func (r *mongoNotificationRepo) markRead(ctx context.Context, filter bson.M) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := r.inbox.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"read": true, "updatedAt": time.Now()}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return res.ModifiedCount, nil
+}
+
+// RemoveNotifications deletes the given notifications from a recipient's inbox.
+func (r *mongoNotificationRepo) RemoveNotifications(ctx context.Context, recipientID string, notificationIDs []string) (int64, error) {
+	if len(notificationIDs) == 0 {
+		return 0, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := r.inbox.DeleteMany(ctx, bson.M{"recipientId": recipientID, "id": bson.M{"$in": notificationIDs}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove notifications: %w", err)
+	}
+	return res.DeletedCount, nil
+}
+
+// GetPreferences returns a recipient's notification preferences; recipients
+// who never changed them get empty preferences.
+func (r *mongoNotificationRepo) GetPreferences(ctx context.Context, recipientID string) (*models.NotificationPreferences, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	prefs := models.NotificationPreferences{RecipientID: recipientID, MutedTypes: []string{}}
+	err := r.preferences.FindOne(ctx, bson.M{"recipientId": recipientID}).Decode(&prefs)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("failed to load notification preferences: %w", err)
+	}
+	return &prefs, nil
+}
+
+// SetMutedTypes replaces the notification types a recipient muted.
+func (r *mongoNotificationRepo) SetMutedTypes(ctx context.Context, role, recipientID string, types []string) (*models.NotificationPreferences, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if types == nil {
+		types = []string{}
+	}
+	prefs := models.NotificationPreferences{
+		RecipientID: recipientID,
+		Role:        role,
+		MutedTypes:  types,
+		UpdatedAt:   time.Now(),
+	}
+	_, err := r.preferences.UpdateOne(ctx,
+		bson.M{"recipientId": recipientID},
+		bson.M{"$set": prefs},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	return &prefs, nil
+}
@@ -0,0 +1,54 @@
+package notificationRepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// deliveryRetention is how long delivery results are kept.
+const deliveryRetention = 90 * 24 * time.Hour
+
+// reminderRetention is how long reminders are kept after they fire.
+const reminderRetention = 30 * 24 * time.Hour
+
+func (r *mongoNotificationRepo) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.inbox.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "recipientId", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "recipientId", Value: 1}, {Key: "read", Value: 1}}},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	}); err != nil {
+		return fmt.Errorf("failed to create inbox indexes: %w", err)
+	}
+
+	if _, err := r.reminders.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "recipientId", Value: 1}, {Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "fireDate", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(reminderRetention.Seconds()))},
+	}); err != nil {
+		return fmt.Errorf("failed to create reminder indexes: %w", err)
+	}
+
+	if _, err := r.preferences.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "recipientId", Value: 1}}, Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return fmt.Errorf("failed to create notification preference indexes: %w", err)
+	}
+
+	if _, err := r.deliveries.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "recipientId", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "dispatchId", Value: 1}}},
+		{Keys: bson.D{{Key: "createdAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(deliveryRetention.Seconds()))},
+	}); err != nil {
+		return fmt.Errorf("failed to create delivery indexes: %w", err)
+	}
+	return nil
+}
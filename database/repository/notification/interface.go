@@ -0,0 +1,64 @@
+package notificationRepo
+
+import (
+	"bloomify/database"
+	"bloomify/models"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// NotificationRepository persists the notification inbox, scheduled reminders,
+// per-type muting and per-channel delivery results.
+type NotificationRepository interface {
+	// Inbox
+	AddNotification(ctx context.Context, n models.Notification) error
+	ListNotifications(ctx context.Context, recipientID string, unreadOnly bool, page, limit int64) (*models.NotificationPage, error)
+	UnreadCount(ctx context.Context, recipientID string) (int64, error)
+	MarkNotificationsAsRead(ctx context.Context, recipientID string, notificationIDs []string) (int64, error)
+	MarkAllAsRead(ctx context.Context, recipientID string) (int64, error)
+	RemoveNotifications(ctx context.Context, recipientID string, notificationIDs []string) (int64, error)
+
+	// Reminders
+	SaveReminders(ctx context.Context, role, recipientID string, reminders []models.Reminder) error
+	ListReminders(ctx context.Context, recipientID string) ([]models.Reminder, error)
+	MarkReminderSent(ctx context.Context, recipientID, reminderID string) error
+
+	// Muting
+	GetPreferences(ctx context.Context, recipientID string) (*models.NotificationPreferences, error)
+	SetMutedTypes(ctx context.Context, role, recipientID string, types []string) (*models.NotificationPreferences, error)
+
+	// Deliveries
+	RecordDeliveries(ctx context.Context, deliveries []models.NotificationDelivery) error
+	ListDeliveries(ctx context.Context, recipientID string, limit int64) ([]models.NotificationDelivery, error)
+
+	// DrainEmbeddedNotifications moves notifications and reminders still embedded
+	// in user and provider documents into their collections.
+	DrainEmbeddedNotifications(ctx context.Context) (int64, error)
+}
+
+type mongoNotificationRepo struct {
+	db          *mongo.Database
+	inbox       *mongo.Collection
+	reminders   *mongo.Collection
+	preferences *mongo.Collection
+	deliveries  *mongo.Collection
+}
+
+// NewMongoNotificationRepo returns a NotificationRepository backed by MongoDB.
+func NewMongoNotificationRepo() NotificationRepository {
+	db := database.MongoClient.Database("bloomify")
+	repo := &mongoNotificationRepo{
+		db:          db,
+		inbox:       db.Collection("notifications"),
+		reminders:   db.Collection("reminders"),
+		preferences: db.Collection("notification_preferences"),
+		deliveries:  db.Collection("notification_deliveries"),
+	}
+
+	if err := repo.ensureIndexes(); err != nil {
+		fmt.Printf("failed to create notification indexes: %v\n", err)
+	}
+	return repo
+}
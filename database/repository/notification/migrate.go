@@ -0,0 +1,139 @@
+package notificationRepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bloomify/models"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// legacyNotification is a notification as it was embedded in account
+// documents, which stored it under the driver's default lowercase keys.
+type legacyNotification struct {
+	ID           string         `bson:"id"`
+	UserID       string         `bson:"userid"`
+	Type         string         `bson:"type"`
+	Title        string         `bson:"title"`
+	Body         string         `bson:"body"`
+	Data         map[string]any `bson:"data"`
+	Sent         bool           `bson:"sent"`
+	CreatedAt    time.Time      `bson:"createdat"`
+	UpdatedAt    time.Time      `bson:"updatedat"`
+	MarkedReadAt time.Time      `bson:"updatedAt"` // written by the old mark-as-read
+	Read         bool           `bson:"read"`
+	Message      string         `bson:"message"`
+}
+
+type embeddedInbox struct {
+	ID            string               `bson:"id"`
+	Notifications []legacyNotification `bson:"notifications"`
+	Reminders     []models.Reminder    `bson:"reminders"`
+}
+
+// DrainEmbeddedNotifications copies the notifications and reminders embedded
+// in user and provider documents into their collections and unsets the
+// arrays. Notifications already copied are skipped, so an interrupted run can
+// be repeated; notifications embedded without an id are given a new one. It returns how many accounts were drained.
+func (r *mongoNotificationRepo) DrainEmbeddedNotifications(ctx context.Context) (int64, error) {
+	var drained int64
+	for role, coll := range map[string]*mongo.Collection{
+		"user":     r.db.Collection("users"),
+		"provider": r.db.Collection("providers"),
+	} {
+		n, err := r.drainCollection(ctx, role, coll)
+		drained += n
+		if err != nil {
+			return drained, err
+		}
+	}
+	return drained, nil
+}
+
+func (r *mongoNotificationRepo) drainCollection(ctx context.Context, role string, coll *mongo.Collection) (int64, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"notifications": bson.M{"$exists": true}},
+		bson.M{"reminders": bson.M{"$exists": true}},
+	}}
+	opts := options.Find().SetProjection(bson.M{"id": 1, "notifications": 1, "reminders": 1})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find %s inboxes: %w", role, err)
+	}
+	defer cursor.Close(ctx)
+
+	var drained int64
+	for cursor.Next(ctx) {
+		var doc embeddedInbox
+		if err := cursor.Decode(&doc); err != nil {
+			return drained, fmt.Errorf("failed to decode %s inbox: %w", role, err)
+		}
+		if doc.ID == "" {
+			continue
+		}
+		if err := r.copyInbox(ctx, role, doc); err != nil {
+			return drained, err
+		}
+		if _, err := coll.UpdateOne(ctx, bson.M{"id": doc.ID}, bson.M{"$unset": bson.M{"notifications": "", "reminders": ""}}); err != nil {
+			return drained, fmt.Errorf("failed to clear inbox of %s %s: %w", role, doc.ID, err)
+		}
+		drained++
+	}
+	return drained, cursor.Err()
+}
+
+func (r *mongoNotificationRepo) copyInbox(ctx context.Context, role string, doc embeddedInbox) error {
+	if len(doc.Notifications) > 0 {
+		writes := make([]mongo.WriteModel, 0, len(doc.Notifications))
+		now := time.Now()
+		for _, old := range doc.Notifications {
+			// The array is unset once copied, so a notification without an id
+			// gets one rather than being dropped.
+			if old.ID == "" {
+				old.ID = uuid.New().String()
+			}
+			n := models.Notification{
+				ID:          old.ID,
+				RecipientID: doc.ID,
+				Role:        role,
+				UserID:      old.UserID,
+				Type:        old.Type,
+				Title:       old.Title,
+				Body:        old.Body,
+				Data:        old.Data,
+				Sent:        old.Sent,
+				CreatedAt:   old.CreatedAt,
+				UpdatedAt:   old.UpdatedAt,
+				Read:        old.Read,
+				Message:     old.Message,
+			}
+			if n.CreatedAt.IsZero() {
+				n.CreatedAt = now
+			}
+			if old.MarkedReadAt.After(n.UpdatedAt) {
+				n.UpdatedAt = old.MarkedReadAt
+			}
+			n.ExpiresAt = expiry(n.CreatedAt)
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"id": n.ID}).
+				SetUpdate(bson.M{"$setOnInsert": n}).
+				SetUpsert(true))
+		}
+		if len(writes) > 0 {
+			if _, err := r.inbox.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+				return fmt.Errorf("failed to copy notifications of %s %s: %w", role, doc.ID, err)
+			}
+		}
+	}
+	if len(doc.Reminders) > 0 {
+		if err := r.SaveReminders(ctx, role, doc.ID, doc.Reminders); err != nil {
+			return fmt.Errorf("failed to copy reminders of %s %s: %w", role, doc.ID, err)
+		}
+	}
+	return nil
+}
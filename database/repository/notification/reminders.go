@@ -0,0 +1,70 @@
+package notificationRepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bloomify/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveReminders upserts reminders by their ID, so resending a reminder
+// reschedules it rather than duplicating it.
+func (r *mongoNotificationRepo) SaveReminders(ctx context.Context, role, recipientID string, reminders []models.Reminder) error {
+	if len(reminders) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	writes := make([]mongo.WriteModel, 0, len(reminders))
+	for _, rem := range reminders {
+		rem.RecipientID = recipientID
+		rem.Role = role
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"recipientId": recipientID, "id": rem.ID}).
+			SetReplacement(rem).
+			SetUpsert(true))
+	}
+	if _, err := r.reminders.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to save reminders: %w", err)
+	}
+	return nil
+}
+
+// ListReminders returns a recipient's reminders by fire date.
+func (r *mongoNotificationRepo) ListReminders(ctx context.Context, recipientID string) ([]models.Reminder, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "fireDate", Value: 1}})
+	cursor, err := r.reminders.Find(ctx, bson.M{"recipientId": recipientID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminders: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	reminders := []models.Reminder{}
+	if err := cursor.All(ctx, &reminders); err != nil {
+		return nil, fmt.Errorf("failed to decode reminders: %w", err)
+	}
+	return reminders, nil
+}
+
+// MarkReminderSent flags a reminder as delivered.
+func (r *mongoNotificationRepo) MarkReminderSent(ctx context.Context, recipientID, reminderID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := r.reminders.UpdateOne(ctx,
+		bson.M{"recipientId": recipientID, "id": reminderID},
+		bson.M{"$set": bson.M{"sent": true}},
+	); err != nil {
+		return fmt.Errorf("failed to mark reminder sent: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package promotionRepo
+
+import (
+	"bloomify/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetPromo returns the promo code with the given (upper-case) code.
+func (r *mongoPromotionRepo) GetPromo(ctx context.Context, code string) (*models.PromoCode, error) {
+	var promo models.PromoCode
+	if err := r.promos.FindOne(ctx, bson.M{"code": code}).Decode(&promo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrPromoNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch promo code %s: %w", code, err)
+	}
+	return &promo, nil
+}
+
+// GetReferralCodeByOwner returns the referral code belonging to a user.
+func (r *mongoPromotionRepo) GetReferralCodeByOwner(ctx context.Context, ownerID string) (*models.PromoCode, error) {
+	var promo models.PromoCode
+	filter := bson.M{"ownerId": ownerID, "kind": models.PromoReferral}
+	if err := r.promos.FindOne(ctx, filter).Decode(&promo); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrPromoNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch referral code for user %s: %w", ownerID, err)
+	}
+	return &promo, nil
+}
+
+// ListPromos returns marketing promo codes, optionally including inactive ones.
+// Personal referral codes are not listed.
+func (r *mongoPromotionRepo) ListPromos(ctx context.Context, includeInactive bool) ([]models.PromoCode, error) {
+	filter := bson.M{"kind": bson.M{"$ne": models.PromoReferral}}
+	if !includeInactive {
+		filter["active"] = true
+	}
+
+	cursor, err := r.promos.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch promo codes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var promos []models.PromoCode
+	if err := cursor.All(ctx, &promos); err != nil {
+		return nil, fmt.Errorf("failed to decode promo codes: %w", err)
+	}
+	return promos, nil
+}
+
+// CreatePromo inserts a new promo code.
+func (r *mongoPromotionRepo) CreatePromo(ctx context.Context, promo *models.PromoCode) error {
+	now := time.Now()
+	promo.CreatedAt = now
+	promo.UpdatedAt = now
+
+	if _, err := r.promos.InsertOne(ctx, promo); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("promo code %s already exists", promo.Code)
+		}
+		return fmt.Errorf("failed to create promo code %s: %w", promo.Code, err)
+	}
+	return nil
+}
+
+// UpdatePromo replaces the editable fields of a promo code. The redemption
+// counter is left untouched so in-flight checkouts are not lost.
+func (r *mongoPromotionRepo) UpdatePromo(ctx context.Context, promo *models.PromoCode) error {
+	promo.UpdatedAt = time.Now()
+	update := bson.M{"$set": bson.M{
+		"description": promo.Description,
+		"kind":        promo.Kind,
+		"value":       promo.Value,
+		"maxDiscount": promo.MaxDiscount,
+		"currency":    promo.Currency,
+		"serviceIds":  promo.ServiceIDs,
+		"regions":     promo.Regions,
+		"usageLimit":  promo.UsageLimit,
+		"startsAt":    promo.StartsAt,
+		"expiresAt":   promo.ExpiresAt,
+		"active":      promo.Active,
+		"updatedAt":   promo.UpdatedAt,
+	}}
+
+	res, err := r.promos.UpdateOne(ctx, bson.M{"code": promo.Code}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update promo code %s: %w", promo.Code, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrPromoNotFound
+	}
+	return nil
+}
+
+// RedeemPromo inserts the redemption, then consumes a use of the code. If the
+// code is exhausted the redemption is removed again.
+func (r *mongoPromotionRepo) RedeemPromo(ctx context.Context, redemption *models.DiscountRedemption, usageLimit int) error {
+	if err := r.RecordRedemption(ctx, redemption); err != nil {
+		return err
+	}
+
+	filter := bson.M{"code": redemption.Code, "active": true}
+	if usageLimit > 0 {
+		filter["redemptions"] = bson.M{"$lt": usageLimit}
+	}
+	res, err := r.promos.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"redemptions": 1}})
+	if err == nil && res.MatchedCount == 0 {
+		err = ErrPromoExhausted
+	}
+	if err != nil {
+		if _, delErr := r.redemptions.DeleteOne(ctx, bson.M{"id": redemption.ID}); delErr != nil {
+			return fmt.Errorf("failed to roll back redemption %s: %w", redemption.ID, delErr)
+		}
+		if errors.Is(err, ErrPromoExhausted) {
+			return err
+		}
+		return fmt.Errorf("failed to redeem promo code %s: %w", redemption.Code, err)
+	}
+	return nil
+}
+
+// RecordRedemption inserts a redemption; the unique ID makes a second insert fail.
+func (r *mongoPromotionRepo) RecordRedemption(ctx context.Context, redemption *models.DiscountRedemption) error {
+	redemption.RedeemedAt = time.Now()
+	if _, err := r.redemptions.InsertOne(ctx, redemption); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrAlreadyRedeemed
+		}
+		return fmt.Errorf("failed to record redemption %s: %w", redemption.ID, err)
+	}
+	return nil
+}
+
+// ReleaseRedemption deletes a redemption and returns its use to the code, if any.
+func (r *mongoPromotionRepo) ReleaseRedemption(ctx context.Context, redemptionID string) error {
+	var redemption models.DiscountRedemption
+	if err := r.redemptions.FindOneAndDelete(ctx, bson.M{"id": redemptionID}).Decode(&redemption); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		return fmt.Errorf("failed to release redemption %s: %w", redemptionID, err)
+	}
+
+	if redemption.Code == "" {
+		return nil
+	}
+	filter := bson.M{"code": redemption.Code, "redemptions": bson.M{"$gt": 0}}
+	if _, err := r.promos.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"redemptions": -1}}); err != nil {
+		return fmt.Errorf("failed to return use of promo code %s: %w", redemption.Code, err)
+	}
+	return nil
+}
+
+// CreateReferralCredit stores a new, unspent credit.
+func (r *mongoPromotionRepo) CreateReferralCredit(ctx context.Context, credit *models.ReferralCredit) error {
+	credit.CreatedAt = time.Now()
+	if _, err := r.credits.InsertOne(ctx, credit); err != nil {
+		return fmt.Errorf("failed to create referral credit: %w", err)
+	}
+	return nil
+}
+
+// ListReferralCredits returns every credit of a user, oldest first.
+func (r *mongoPromotionRepo) ListReferralCredits(ctx context.Context, userID string) ([]models.ReferralCredit, error) {
+	cursor, err := r.credits.Find(ctx, bson.M{"userId": userID}, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch referral credits: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var credits []models.ReferralCredit
+	if err := cursor.All(ctx, &credits); err != nil {
+		return nil, fmt.Errorf("failed to decode referral credits: %w", err)
+	}
+	return credits, nil
+}
+
+// ClaimReferralCredit atomically assigns the oldest unspent credit to a booking.
+func (r *mongoPromotionRepo) ClaimReferralCredit(ctx context.Context, userID, currency, bookingID string) (*models.ReferralCredit, error) {
+	filter := bson.M{
+		"userId":    userID,
+		"currency":  currency,
+		"bookingId": bson.M{"$in": bson.A{nil, ""}},
+	}
+	update := bson.M{"$set": bson.M{"bookingId": bookingID, "redeemedAt": time.Now()}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var credit models.ReferralCredit
+	if err := r.credits.FindOneAndUpdate(ctx, filter, update, opts).Decode(&credit); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim referral credit: %w", err)
+	}
+	return &credit, nil
+}
+
+// SplitReferralCredit lowers a claimed credit to amount and stores the rest as
+// an unspent credit. The rest keeps the original creation time so it is spent
+// before newer credits.
+func (r *mongoPromotionRepo) SplitReferralCredit(ctx context.Context, creditID string, amount float64, rest *models.ReferralCredit) error {
+	filter := bson.M{"id": creditID, "bookingId": bson.M{"$nin": bson.A{nil, ""}}}
+	res, err := r.credits.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"amount": amount}})
+	if err != nil {
+		return fmt.Errorf("failed to split referral credit %s: %w", creditID, err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("referral credit %s is not claimed", creditID)
+	}
+	if _, err := r.credits.InsertOne(ctx, rest); err != nil {
+		// Put the whole amount back on the claimed credit rather than lose the rest.
+		if _, uerr := r.credits.UpdateOne(ctx, bson.M{"id": creditID}, bson.M{"$inc": bson.M{"amount": rest.Amount}}); uerr != nil {
+			return fmt.Errorf("failed to restore referral credit %s: %w", creditID, uerr)
+		}
+		return fmt.Errorf("failed to store the rest of referral credit %s: %w", creditID, err)
+	}
+	return nil
+}
+
+// ReleaseReferralCredit makes a claimed credit spendable again.
+func (r *mongoPromotionRepo) ReleaseReferralCredit(ctx context.Context, creditID string) error {
+	update := bson.M{"$unset": bson.M{"bookingId": "", "redeemedAt": ""}}
+	if _, err := r.credits.UpdateOne(ctx, bson.M{"id": creditID}, update); err != nil {
+		return fmt.Errorf("failed to release referral credit %s: %w", creditID, err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package promotionRepo
+
+import (
+	"bloomify/database"
+	"bloomify/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrPromoNotFound is returned when no promo code matches.
+	ErrPromoNotFound = errors.New("promo code not found")
+	// ErrPromoExhausted is returned when a code has reached its usage limit or was deactivated.
+	ErrPromoExhausted = errors.New("promo code is no longer available")
+	// ErrAlreadyRedeemed is returned when the user already redeemed the discount.
+	ErrAlreadyRedeemed = errors.New("discount already redeemed")
+)
+
+// PromotionRepository persists promo codes, their redemptions and referral credits.
+// Redemptions are keyed by a deterministic ID, so concurrent checkouts cannot
+// redeem the same discount twice.
+type PromotionRepository interface {
+	GetPromo(ctx context.Context, code string) (*models.PromoCode, error)
+	GetReferralCodeByOwner(ctx context.Context, ownerID string) (*models.PromoCode, error)
+	ListPromos(ctx context.Context, includeInactive bool) ([]models.PromoCode, error)
+	CreatePromo(ctx context.Context, promo *models.PromoCode) error
+	UpdatePromo(ctx context.Context, promo *models.PromoCode) error
+
+	// RedeemPromo records the redemption and consumes one use of its code,
+	// failing with ErrAlreadyRedeemed or ErrPromoExhausted.
+	RedeemPromo(ctx context.Context, redemption *models.DiscountRedemption, usageLimit int) error
+	// RecordRedemption records a redemption that is not tied to a usage-limited code.
+	RecordRedemption(ctx context.Context, redemption *models.DiscountRedemption) error
+	// ReleaseRedemption undoes a redemption whose checkout failed.
+	ReleaseRedemption(ctx context.Context, redemptionID string) error
+
+	CreateReferralCredit(ctx context.Context, credit *models.ReferralCredit) error
+	ListReferralCredits(ctx context.Context, userID string) ([]models.ReferralCredit, error)
+	// ClaimReferralCredit marks the user's oldest unspent credit in currency as spent on
+	// bookingID and returns it, or returns nil if there is none.
+	ClaimReferralCredit(ctx context.Context, userID, currency, bookingID string) (*models.ReferralCredit, error)
+	// SplitReferralCredit lowers a claimed credit to amount and stores rest as
+	// a new, unspent credit, so only what a booking used is spent.
+	SplitReferralCredit(ctx context.Context, creditID string, amount float64, rest *models.ReferralCredit) error
+	ReleaseReferralCredit(ctx context.Context, creditID string) error
+}
+
+type mongoPromotionRepo struct {
+	promos      *mongo.Collection
+	redemptions *mongo.Collection
+	credits     *mongo.Collection
+}
+
+// NewMongoPromotionRepo returns a PromotionRepository backed by MongoDB.
+func NewMongoPromotionRepo() PromotionRepository {
+	db := database.MongoClient.Database("bloomify")
+	repo := &mongoPromotionRepo{
+		promos:      db.Collection("promo_codes"),
+		redemptions: db.Collection("discount_redemptions"),
+		credits:     db.Collection("referral_credits"),
+	}
+
+	if err := repo.ensureIndexes(); err != nil {
+		fmt.Printf("failed to create promotion indexes: %v\n", err)
+	}
+	return repo
+}
@@ -20,6 +20,7 @@ func (r *MongoProviderRepo) ensureIndexes() error {
 		{Keys: bson.D{{Key: "serviceCatalogue.service.id", Value: 1}}},
 		{Keys: bson.D{{Key: "serviceCatalogue.customOptions.option", Value: 1}}},
 		{Keys: bson.D{{Key: "serviceCatalogue.mode", Value: 1}}},
+		{Keys: bson.D{{Key: "serviceCatalogues.service.id", Value: 1}, {Key: "serviceCatalogues.mode", Value: 1}}},
 		{Keys: bson.D{{Key: "profile.status", Value: 1}}},
 		// Single 2dsphere index – required for geo queries
 		{Keys: bson.D{{Key: "profile.locationGeo", Value: "2dsphere"}}},
@@ -91,49 +91,56 @@ func (r *MongoProviderRepo) UpdatePushDocument(id string, updateDoc bson.M) erro
 	return nil
 }
 
-func (r *MongoProviderRepo) MarkNotificationsAsRead(id string, notificationIDs []string) error {
+// ClearFCMTokens removes the given push tokens from the provider's devices, and
+// the account-wide token if it is one of them.
+func (r *MongoProviderRepo) ClearFCMTokens(id string, tokens []string) error {
 	ctx, cancel := newContext(5 * time.Second)
 	defer cancel()
 
-	// Create array filters for the specific notifications to update
-	arrayFilters := options.ArrayFilters{
-		Filters: []any{
-			bson.M{
-				"elem.id": bson.M{"$in": notificationIDs},
-			},
-		},
+	filter := bson.M{"id": id}
+	update := bson.M{"$unset": bson.M{"devices.$[d].fcmToken": ""}}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"d.fcmToken": bson.M{"$in": tokens}}},
+	})
+	if _, err := r.coll.UpdateOne(ctx, filter, update, opts); err != nil {
+		return fmt.Errorf("failed to clear FCM tokens of provider %s: %w", id, err)
+	}
+
+	legacy := bson.M{"id": id, "security.fcmToken": bson.M{"$in": tokens}}
+	if _, err := r.coll.UpdateOne(ctx, legacy, bson.M{"$set": bson.M{"security.fcmToken": ""}}); err != nil {
+		return fmt.Errorf("failed to clear FCM token of provider %s: %w", id, err)
 	}
+	return nil
+}
+
+// SetDeviceFCMToken stores the push token of one of the provider's devices in a
+// single update: the token is removed from any other device it moved from, and
+// the account-wide token is cleared. It fails when the device is not found.
+func (r *MongoProviderRepo) SetDeviceFCMToken(id, deviceID, token string) error {
+	ctx, cancel := newContext(5 * time.Second)
+	defer cancel()
 
-	// Update operation to:
-	// 1. Set read=true for matching notifications
-	// 2. Update their updatedAt timestamp
-	// 3. Update the provider's updatedAt field
+	filter := bson.M{"id": id, "devices.deviceId": deviceID}
 	update := bson.M{
 		"$set": bson.M{
-			"notifications.$[elem].read":      true,
-			"notifications.$[elem].updatedAt": time.Now(),
-			"updatedAt":                       time.Now(),
+			"devices.$[d].fcmToken": token,
+			"security.fcmToken":     "",
+			"updatedAt":             time.Now(),
 		},
+		"$unset": bson.M{"devices.$[o].fcmToken": ""},
 	}
-
-	opts := options.Update().SetArrayFilters(arrayFilters)
-	filter := bson.M{"id": id}
-
-	result, err := r.coll.UpdateOne(
-		ctx,
-		filter,
-		update,
-		opts,
-	)
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{
+			bson.M{"d.deviceId": deviceID},
+			bson.M{"o.fcmToken": token, "o.deviceId": bson.M{"$ne": deviceID}},
+		},
+	})
+	result, err := r.coll.UpdateOne(ctx, filter, update, opts)
 	if err != nil {
-		return fmt.Errorf("failed to mark notifications as read for provider %s: %w", id, err)
+		return fmt.Errorf("failed to set FCM token of provider %s: %w", id, err)
 	}
 	if result.MatchedCount == 0 {
-		return fmt.Errorf("provider with id %s not found", id)
+		return fmt.Errorf("device %s of provider %s not found", deviceID, id)
 	}
-	if result.ModifiedCount == 0 {
-		return fmt.Errorf("no notifications were updated (possibly already read or IDs not found)")
-	}
-
 	return nil
 }
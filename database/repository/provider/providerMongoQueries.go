@@ -125,8 +125,12 @@ func (r *MongoProviderRepo) GetByServiceTypeWithProjection(service string, proje
 	ctx, cancel := newContext(5 * time.Second)
 	defer cancel()
 
-	// Adjust filter to refer to serviceCatalogue.serviceType.
-	filter := bson.M{"serviceCatalogue.serviceType": bson.M{"$regex": service, "$options": "i"}}
+	// Match the service against the primary entry or any additional catalogue entry.
+	serviceFilter := bson.M{"$regex": service, "$options": "i"}
+	filter := bson.M{"$or": bson.A{
+		bson.M{"serviceCatalogue.service.id": serviceFilter},
+		bson.M{"serviceCatalogues.service.id": serviceFilter},
+	}}
 	var proj bson.M
 	if projection == nil {
 		proj = bson.M{
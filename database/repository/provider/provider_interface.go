@@ -54,7 +54,11 @@ type ProviderRepository interface {
 	// IsProviderAvailable checks if a provider with the given basic registration details already exists.
 	IsProviderAvailable(basicReq models.ProviderBasicRegistrationData) (bool, error)
 	FetchTopProviders(ctx context.Context, page, limit int) ([]models.Provider, error)
-	MarkNotificationsAsRead(id string, notificationIDs []string) error
+	// ClearFCMTokens removes push tokens from the provider's devices.
+	ClearFCMTokens(id string, tokens []string) error
+	// SetDeviceFCMToken stores the push token of one of the provider's devices
+	// and removes it from any other.
+	SetDeviceFCMToken(id, deviceID, token string) error
 }
 
 // MongoProviderRepo implements ProviderRepository using MongoDB.
@@ -46,21 +46,35 @@ func (r *MongoProviderRepo) AdvancedSearch(criteria ProviderSearchCriteria) ([]m
 			0,
 		}},
 	}
+	// Catalogue conditions must hold on a single entry, either the legacy
+	// serviceCatalogue or one element of serviceCatalogues.
+	entry := bson.M{}
 	if criteria.ServiceType != "" {
-		match["serviceCatalogue.service.id"] = bson.M{"$regex": criteria.ServiceType, "$options": "i"}
+		entry["service.id"] = bson.M{"$regex": criteria.ServiceType, "$options": "i"}
 	}
 	if criteria.CustomOption != "" {
-		match["serviceCatalogue.customOptions"] = bson.M{
+		entry["customOptions"] = bson.M{
 			"$elemMatch": bson.M{"option": bson.M{"$regex": criteria.CustomOption, "$options": "i"}},
 		}
 	}
 
 	if len(criteria.Modes) > 0 {
-		match["serviceCatalogue.mode"] = bson.M{
+		entry["mode"] = bson.M{
 			"$in": criteria.Modes,
 		}
 	}
 
+	if len(entry) > 0 {
+		legacy := bson.M{}
+		for k, v := range entry {
+			legacy["serviceCatalogue."+k] = v
+		}
+		match["$or"] = bson.A{
+			legacy,
+			bson.M{"serviceCatalogues": bson.M{"$elemMatch": entry}},
+		}
+	}
+
 	pipeline = append(pipeline, bson.D{{Key: "$match", Value: match}})
 
 	// 3. Add computed fields, safely handling missing arrays
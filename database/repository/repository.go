@@ -1,8 +1,12 @@
 package repository
 
 import (
+	ledgerRepo "bloomify/database/repository/ledger"
+	notificationRepo "bloomify/database/repository/notification"
+	promotionRepo "bloomify/database/repository/promotion"
 	providerRepo "bloomify/database/repository/provider"
 	schedulerRepo "bloomify/database/repository/scheduler"
+	serviceRepo "bloomify/database/repository/service"
 	timeslotRepo "bloomify/database/repository/timeslot"
 	userRepo "bloomify/database/repository/user"
 )
@@ -28,3 +32,23 @@ var NewMongoSchedulerRepo = schedulerRepo.NewMongoSchedulerRepo
 type TimeslotsRepository = timeslotRepo.TimeSlotRepository
 
 var NewMongoTimeSlotRepo = timeslotRepo.NewMongoTimeSlotRepo
+
+// Re-export the ServiceRepository interface and constructor.
+type ServiceRepository = serviceRepo.ServiceRepository
+
+var NewMongoServiceRepo = serviceRepo.NewMongoServiceRepo
+
+// Re-export the PromotionRepository interface and constructor.
+type PromotionRepository = promotionRepo.PromotionRepository
+
+var NewMongoPromotionRepo = promotionRepo.NewMongoPromotionRepo
+
+// Re-export the LedgerRepository interface and constructor.
+type LedgerRepository = ledgerRepo.LedgerRepository
+
+var NewMongoLedgerRepo = ledgerRepo.NewMongoLedgerRepo
+
+// Re-export the NotificationRepository interface and constructor.
+type NotificationRepository = notificationRepo.NotificationRepository
+
+var NewMongoNotificationRepo = notificationRepo.NewMongoNotificationRepo
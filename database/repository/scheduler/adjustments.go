@@ -0,0 +1,75 @@
+package schedulerRepo
+
+import (
+	"bloomify/models"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AddBookingAdjustment appends an adjustment to a booking.
+func (repo *MongoSchedulerRepo) AddBookingAdjustment(ctx context.Context, bookingID string, adj models.BookingAdjustment) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := repo.bookingColl.UpdateOne(ctxWithTimeout,
+		bson.M{"id": bookingID},
+		bson.M{"$push": bson.M{"adjustments": adj}},
+	)
+	if err != nil {
+		return fmt.Errorf("error adding adjustment to booking %s: %w", bookingID, err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("booking %s not found", bookingID)
+	}
+	return nil
+}
+
+// UpdateBookingAdjustment replaces an adjustment while it is still in fromStatus.
+// The status check and the write are one operation, so two concurrent approvals
+// cannot both charge the user.
+func (repo *MongoSchedulerRepo) UpdateBookingAdjustment(ctx context.Context, bookingID string, adj models.BookingAdjustment, fromStatus string) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"id": bookingID,
+		"adjustments": bson.M{"$elemMatch": bson.M{
+			"adjustmentId": adj.AdjustmentID,
+			"status":       fromStatus,
+		}},
+	}
+	res, err := repo.bookingColl.UpdateOne(ctxWithTimeout, filter, bson.M{"$set": bson.M{"adjustments.$": adj}})
+	if err != nil {
+		return fmt.Errorf("error updating adjustment %s: %w", adj.AdjustmentID, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrAdjustmentConflict
+	}
+	return nil
+}
+
+// AddInvoiceCharge appends lines to a booking's invoice and adds their amounts
+// to the invoice total, except those already included in another line.
+func (repo *MongoSchedulerRepo) AddInvoiceCharge(ctx context.Context, bookingID string, lines ...models.InvoiceLine) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var amount float64
+	for _, line := range lines {
+		if !line.Included {
+			amount += line.Amount
+		}
+	}
+	update := bson.M{
+		"$push": bson.M{"invoice.lines": bson.M{"$each": lines}},
+		"$inc":  bson.M{"invoice.amount": amount},
+		"$set":  bson.M{"invoice.updatedat": time.Now()},
+	}
+	if _, err := repo.bookingColl.UpdateOne(ctxWithTimeout, bson.M{"id": bookingID}, update); err != nil {
+		return fmt.Errorf("error adding invoice charge to booking %s: %w", bookingID, err)
+	}
+	return nil
+}
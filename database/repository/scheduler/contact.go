@@ -0,0 +1,53 @@
+package schedulerRepo
+
+import (
+	"bloomify/models"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SetCallRelay stores a booking's call relay. The expiry check and the write are
+// one operation, so two callers cannot both lease numbers for the booking.
+func (repo *MongoSchedulerRepo) SetCallRelay(ctx context.Context, bookingID string, relay models.CallRelay, now time.Time) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"id": bookingID,
+		"$or": bson.A{
+			bson.M{"callRelay": bson.M{"$exists": false}},
+			bson.M{"callRelay.expiresAt": bson.M{"$lte": now}},
+		},
+	}
+	res, err := repo.bookingColl.UpdateOne(ctxWithTimeout, filter, bson.M{"$set": bson.M{"callRelay": relay}})
+	if err != nil {
+		return fmt.Errorf("error setting call relay of booking %s: %w", bookingID, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrCallRelayConflict
+	}
+	return nil
+}
+
+// SetContactSharing records whether the user or the provider of a booking shares
+// their phone number with the other.
+func (repo *MongoSchedulerRepo) SetContactSharing(ctx context.Context, bookingID, role string, share bool) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	field := "contactSharing.user"
+	if role == models.ChatRoleProvider {
+		field = "contactSharing.provider"
+	}
+	res, err := repo.bookingColl.UpdateOne(ctxWithTimeout, bson.M{"id": bookingID}, bson.M{"$set": bson.M{field: share}})
+	if err != nil {
+		return fmt.Errorf("error updating contact sharing of booking %s: %w", bookingID, err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("booking %s not found", bookingID)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package schedulerRepo
+
+import (
+	"bloomify/models"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AddCheckIn appends a check-in to a confirmed booking. The status check and the
+// write are one operation, so a check-in cannot race a no-show being flagged.
+func (repo *MongoSchedulerRepo) AddCheckIn(ctx context.Context, bookingID string, checkIn models.BookingCheckIn) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"id":            bookingID,
+		"status":        "confirmed",
+		"checkIns.kind": bson.M{"$ne": checkIn.Kind},
+	}
+	res, err := repo.bookingColl.UpdateOne(ctxWithTimeout, filter, bson.M{"$push": bson.M{"checkIns": checkIn}})
+	if err != nil {
+		return fmt.Errorf("error adding check-in to booking %s: %w", bookingID, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrNoShowConflict
+	}
+	return nil
+}
+
+// MarkNoShow flags a confirmed booking as a no-show, then counts it against the
+// provider or the user depending on record.Party.
+func (repo *MongoSchedulerRepo) MarkNoShow(ctx context.Context, b *models.Booking, record models.NoShowRecord) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"id":     b.ID,
+		"status": "confirmed",
+		"noShow": bson.M{"$exists": false},
+	}
+	res, err := repo.bookingColl.UpdateOne(ctxWithTimeout, filter, bson.M{"$set": bson.M{
+		"status": "no_show",
+		"noShow": record,
+	}})
+	if err != nil {
+		return fmt.Errorf("error flagging no-show on booking %s: %w", b.ID, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrNoShowConflict
+	}
+
+	coll, id := repo.providerColl, b.ProviderID
+	if record.Party == models.NoShowByUser {
+		coll, id = repo.userColl, b.UserID
+	}
+	update := bson.M{
+		"$inc": bson.M{"reliability.noShows": 1},
+		"$set": bson.M{"reliability.lastNoShowAt": record.DetectedAt},
+	}
+	if _, err := coll.UpdateOne(ctxWithTimeout, bson.M{"id": id}, update); err != nil {
+		return fmt.Errorf("error recording no-show of %s %s: %w", record.Party, id, err)
+	}
+	return nil
+}
+
+// UpdateNoShow replaces a booking's no-show record while it is still in
+// fromResolution, so a refund is only issued once.
+func (repo *MongoSchedulerRepo) UpdateNoShow(ctx context.Context, bookingID string, record models.NoShowRecord, fromResolution string) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"id": bookingID, "noShow.resolution": fromResolution}
+	res, err := repo.bookingColl.UpdateOne(ctxWithTimeout, filter, bson.M{"$set": bson.M{"noShow": record}})
+	if err != nil {
+		return fmt.Errorf("error updating no-show of booking %s: %w", bookingID, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrNoShowConflict
+	}
+	return nil
+}
+
+// AddInvoiceRefund appends a refund to a booking's invoice.
+func (repo *MongoSchedulerRepo) AddInvoiceRefund(ctx context.Context, bookingID string, refund models.Refund) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$push": bson.M{"invoice.refunds": refund},
+		"$set":  bson.M{"invoice.updatedat": time.Now()},
+	}
+	if _, err := repo.bookingColl.UpdateOne(ctxWithTimeout, bson.M{"id": bookingID}, update); err != nil {
+		return fmt.Errorf("error adding refund to booking %s: %w", bookingID, err)
+	}
+	return nil
+}
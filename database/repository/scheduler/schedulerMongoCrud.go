@@ -60,3 +60,63 @@ func (repo *MongoSchedulerRepo) CancelBooking(bookingID string) error {
 	}
 	return nil
 }
+
+// CountBookingsByUser returns how many bookings a user has made.
+func (repo *MongoSchedulerRepo) CountBookingsByUser(ctx context.Context, userID string) (int64, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	count, err := repo.bookingColl.CountDocuments(ctxWithTimeout, bson.M{"userId": userID})
+	if err != nil {
+		return 0, fmt.Errorf("error counting bookings for user %s: %w", userID, err)
+	}
+	return count, nil
+}
+
+// UpdatePaymentPlan replaces a booking's payment plan, removing it when plan is
+// nil, and sets the booking status when status is not empty.
+func (repo *MongoSchedulerRepo) UpdatePaymentPlan(ctx context.Context, bookingID string, plan *models.PaymentPlan, status string) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	set := bson.M{}
+	update := bson.M{}
+	if plan != nil {
+		set["paymentPlan"] = plan
+	} else {
+		update["$unset"] = bson.M{"paymentPlan": ""}
+	}
+	if status != "" {
+		set["status"] = status
+	}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+
+	if _, err := repo.bookingColl.UpdateOne(ctxWithTimeout, bson.M{"id": bookingID}, update); err != nil {
+		return fmt.Errorf("error updating payment plan of booking %s: %w", bookingID, err)
+	}
+	return nil
+}
+
+// ClaimPaymentPlan replaces a booking's payment plan while its balance is still
+// in fromStatus after fromAttempts attempts. The check and the write are one
+// operation, so two concurrent charges cannot both claim the balance.
+func (repo *MongoSchedulerRepo) ClaimPaymentPlan(ctx context.Context, bookingID string, plan *models.PaymentPlan, fromStatus string, fromAttempts int) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"id":                        bookingID,
+		"paymentPlan.balanceStatus": fromStatus,
+		"paymentPlan.attempts":      fromAttempts,
+	}
+	res, err := repo.bookingColl.UpdateOne(ctxWithTimeout, filter, bson.M{"$set": bson.M{"paymentPlan": plan}})
+	if err != nil {
+		return fmt.Errorf("error claiming payment plan of booking %s: %w", bookingID, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrPaymentPlanConflict
+	}
+	return nil
+}
@@ -5,16 +5,36 @@ import (
 	timeslotRepo "bloomify/database/repository/timeslot"
 	"bloomify/models"
 	"context"
+	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// ErrAdjustmentConflict is returned when a booking adjustment is not in the
+// status an update expects, e.g. it was already approved or declined.
+var ErrAdjustmentConflict = errors.New("adjustment is not in the expected state")
+
+// ErrNoShowConflict is returned when a booking is not in the state a check-in
+// or no-show update expects, e.g. it is no longer confirmed or was already
+// flagged as a no-show.
+var ErrNoShowConflict = errors.New("booking is not in the expected state")
+
+// ErrPaymentPlanConflict is returned when a booking's balance is not in the
+// status a claim expects, e.g. another charge claimed it first.
+var ErrPaymentPlanConflict = errors.New("payment plan is not in the expected state")
+
+// ErrCallRelayConflict is returned when a booking already has a call relay that
+// has not expired.
+var ErrCallRelayConflict = errors.New("booking already has an open call relay")
+
 type SchedulerRepository interface {
 	SumOverlappingBookings(providerID, date string, start, end int, priorityFilter *bool) (int, error)
 	CreateBooking(booking *models.Booking) error
 	GetBookingByID(ctx context.Context, bookingID string) (*models.Booking, error)
 	UpdateBooking(bookingID string, updatedBooking *models.Booking) error
 	CancelBooking(bookingID string) error
+	CountBookingsByUser(ctx context.Context, userID string) (int64, error)
 	BookSingleSlotTransactionally(
 		ctx context.Context,
 		providerID string,
@@ -22,11 +42,60 @@ type SchedulerRepository interface {
 		slot models.TimeSlot,
 		booking *models.Booking,
 	) error
+	// BookSlotsTransactionally inserts every booking and embeds it into its slot in a
+	// single transaction, so either all legs are booked or none are.
+	BookSlotsTransactionally(ctx context.Context, legs []BookingLeg) error
+	// UnbookSlotsTransactionally deletes the bookings of legs committed by
+	// BookSlotsTransactionally and gives their slots' units back, all in one
+	// transaction.
+	UnbookSlotsTransactionally(ctx context.Context, legs []BookingLeg) error
+	// AddBookingAdjustment appends an adjustment to a booking.
+	AddBookingAdjustment(ctx context.Context, bookingID string, adj models.BookingAdjustment) error
+	// UpdateBookingAdjustment replaces an adjustment only while it is still in
+	// fromStatus, and returns ErrAdjustmentConflict otherwise.
+	UpdateBookingAdjustment(ctx context.Context, bookingID string, adj models.BookingAdjustment, fromStatus string) error
+	// AddInvoiceCharge appends lines to a booking's invoice and adds their
+	// amounts to the invoice total, except those already included in another line.
+	AddInvoiceCharge(ctx context.Context, bookingID string, lines ...models.InvoiceLine) error
+	// UpdatePaymentPlan replaces a booking's payment plan, removing it when plan is
+	// nil, and sets the booking status when status is not empty.
+	UpdatePaymentPlan(ctx context.Context, bookingID string, plan *models.PaymentPlan, status string) error
+	// ClaimPaymentPlan replaces a booking's payment plan only while its balance
+	// is in fromStatus after fromAttempts attempts, and returns
+	// ErrPaymentPlanConflict otherwise.
+	ClaimPaymentPlan(ctx context.Context, bookingID string, plan *models.PaymentPlan, fromStatus string, fromAttempts int) error
+	// AddCheckIn appends a check-in to a confirmed booking that has none of its
+	// kind yet, and returns ErrNoShowConflict otherwise.
+	AddCheckIn(ctx context.Context, bookingID string, checkIn models.BookingCheckIn) error
+	// MarkNoShow flags a confirmed booking as a no-show and counts it against the
+	// absent party's reliability. It returns ErrNoShowConflict when the booking is
+	// not confirmed or was already flagged.
+	MarkNoShow(ctx context.Context, b *models.Booking, record models.NoShowRecord) error
+	// UpdateNoShow replaces a booking's no-show record only while it is still in
+	// fromResolution, and returns ErrNoShowConflict otherwise.
+	UpdateNoShow(ctx context.Context, bookingID string, record models.NoShowRecord, fromResolution string) error
+	// AddInvoiceRefund appends a refund to a booking's invoice.
+	AddInvoiceRefund(ctx context.Context, bookingID string, refund models.Refund) error
+	// SetCallRelay stores a booking's call relay unless it has one that expires
+	// after now, and returns ErrCallRelayConflict then.
+	SetCallRelay(ctx context.Context, bookingID string, relay models.CallRelay, now time.Time) error
+	// SetContactSharing records whether the booking's user or provider, by role,
+	// shares their phone number.
+	SetContactSharing(ctx context.Context, bookingID, role string, share bool) error
+}
+
+// BookingLeg is one booking of a multi-slot transaction.
+type BookingLeg struct {
+	ProviderID string
+	Date       string
+	Slot       models.TimeSlot
+	Booking    *models.Booking
 }
 
 type MongoSchedulerRepo struct {
 	providerColl *mongo.Collection
 	bookingColl  *mongo.Collection
+	userColl     *mongo.Collection
 	timeSlotRepo timeslotRepo.TimeSlotRepository
 }
 
@@ -36,6 +105,7 @@ func NewMongoSchedulerRepo(tsRepo timeslotRepo.TimeSlotRepository) SchedulerRepo
 	return &MongoSchedulerRepo{
 		providerColl: db.Collection("providers"),
 		bookingColl:  db.Collection("bookings"),
+		userColl:     db.Collection("users"),
 		timeSlotRepo: tsRepo,
 	}
 }
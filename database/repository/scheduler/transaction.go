@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -39,7 +40,7 @@ func (repo *MongoSchedulerRepo) BookSingleSlotTransactionally(
 		}
 
 		// Embed booking into timeslot using its parts
-		if err := repo.timeSlotRepo.TryEmbedBooking(sc, providerID, slot.ID, date, booking.ID, booking.Units, booking.Priority); err != nil {
+		if err := repo.timeSlotRepo.TryEmbedBooking(sc, providerID, slot.ID, date, booking.ID, booking.Units-booking.PriorityUnits, booking.PriorityUnits); err != nil {
 			return fmt.Errorf("failed to embed booking into time slot: %w", err)
 		}
 
@@ -62,6 +63,86 @@ func (repo *MongoSchedulerRepo) BookSingleSlotTransactionally(
 	return nil
 }
 
+func (repo *MongoSchedulerRepo) BookSlotsTransactionally(ctx context.Context, legs []BookingLeg) error {
+	if len(legs) == 0 {
+		return fmt.Errorf("no bookings to create")
+	}
+
+	client := repo.bookingColl.Database().Client()
+	sess, err := client.StartSession()
+	if err != nil {
+		return fmt.Errorf("could not start mongo session: %w", err)
+	}
+	defer sess.EndSession(ctx)
+
+	txnFn := func(sc mongo.SessionContext) error {
+		for _, leg := range legs {
+			if _, err := repo.bookingColl.InsertOne(sc, leg.Booking); err != nil {
+				return fmt.Errorf("insert booking %s failed: %w", leg.Booking.ID, err)
+			}
+			if err := repo.timeSlotRepo.TryEmbedBooking(sc, leg.ProviderID, leg.Slot.ID, leg.Date, leg.Booking.ID, leg.Booking.Units-leg.Booking.PriorityUnits, leg.Booking.PriorityUnits); err != nil {
+				return fmt.Errorf("failed to embed booking into time slot %s: %w", leg.Slot.ID, err)
+			}
+		}
+		return nil
+	}
+
+	if err := mongo.WithSession(ctx, sess, func(sc mongo.SessionContext) error {
+		if err := sc.StartTransaction(); err != nil {
+			return err
+		}
+		if err := txnFn(sc); err != nil {
+			_ = sc.AbortTransaction(sc)
+			return err
+		}
+		return sc.CommitTransaction(sc)
+	}); err != nil {
+		return fmt.Errorf("booking transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *MongoSchedulerRepo) UnbookSlotsTransactionally(ctx context.Context, legs []BookingLeg) error {
+	if len(legs) == 0 {
+		return nil
+	}
+
+	client := repo.bookingColl.Database().Client()
+	sess, err := client.StartSession()
+	if err != nil {
+		return fmt.Errorf("could not start mongo session: %w", err)
+	}
+	defer sess.EndSession(ctx)
+
+	txnFn := func(sc mongo.SessionContext) error {
+		for _, leg := range legs {
+			if _, err := repo.bookingColl.DeleteOne(sc, bson.M{"id": leg.Booking.ID}); err != nil {
+				return fmt.Errorf("delete booking %s failed: %w", leg.Booking.ID, err)
+			}
+			if err := repo.timeSlotRepo.ReleaseBooking(sc, leg.ProviderID, leg.Slot.ID, leg.Date, leg.Booking.ID, leg.Booking.Units-leg.Booking.PriorityUnits, leg.Booking.PriorityUnits); err != nil {
+				return fmt.Errorf("failed to release time slot %s: %w", leg.Slot.ID, err)
+			}
+		}
+		return nil
+	}
+
+	if err := mongo.WithSession(ctx, sess, func(sc mongo.SessionContext) error {
+		if err := sc.StartTransaction(); err != nil {
+			return err
+		}
+		if err := txnFn(sc); err != nil {
+			_ = sc.AbortTransaction(sc)
+			return err
+		}
+		return sc.CommitTransaction(sc)
+	}); err != nil {
+		return fmt.Errorf("unbooking transaction failed: %w", err)
+	}
+
+	return nil
+}
+
 func (repo *MongoSchedulerRepo) SetTimeSlotBlocked(
 	providerID, slotID, date string,
 	blocked bool, reason string,
@@ -0,0 +1,113 @@
+package serviceRepo
+
+import (
+	"bloomify/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetAll returns every service definition, optionally including deactivated ones.
+func (r *mongoServiceRepo) GetAll(ctx context.Context, includeInactive bool) ([]models.ServiceDefinition, error) {
+	filter := bson.M{}
+	if !includeInactive {
+		filter["active"] = true
+	}
+
+	cursor, err := r.coll.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch services: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var defs []models.ServiceDefinition
+	if err := cursor.All(ctx, &defs); err != nil {
+		return nil, fmt.Errorf("failed to decode services: %w", err)
+	}
+	return defs, nil
+}
+
+// GetByID returns a single service definition.
+func (r *mongoServiceRepo) GetByID(ctx context.Context, id string) (*models.ServiceDefinition, error) {
+	var def models.ServiceDefinition
+	if err := r.coll.FindOne(ctx, bson.M{"id": id}).Decode(&def); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrServiceNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch service %s: %w", id, err)
+	}
+	return &def, nil
+}
+
+// Create inserts a new service definition at version 1.
+func (r *mongoServiceRepo) Create(ctx context.Context, def *models.ServiceDefinition) error {
+	now := time.Now()
+	def.Version = 1
+	def.CreatedAt = now
+	def.UpdatedAt = now
+
+	if _, err := r.coll.InsertOne(ctx, def); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("service %s already exists", def.ID)
+		}
+		return fmt.Errorf("failed to create service %s: %w", def.ID, err)
+	}
+	return nil
+}
+
+// Update archives the current revision and replaces it with def.
+func (r *mongoServiceRepo) Update(ctx context.Context, def *models.ServiceDefinition, expectedVersion int) error {
+	current, err := r.GetByID(ctx, def.ID)
+	if err != nil {
+		return err
+	}
+	if current.Version != expectedVersion {
+		return ErrVersionConflict
+	}
+
+	if _, err := r.history.InsertOne(ctx, current); err != nil && !mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("failed to archive service %s v%d: %w", def.ID, current.Version, err)
+	}
+
+	def.Version = expectedVersion + 1
+	def.CreatedAt = current.CreatedAt
+	def.UpdatedAt = time.Now()
+
+	res, err := r.coll.ReplaceOne(ctx, bson.M{"id": def.ID, "version": expectedVersion}, def)
+	if err != nil {
+		return fmt.Errorf("failed to update service %s: %w", def.ID, err)
+	}
+	if res.MatchedCount == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// GetHistory returns archived revisions of a service, newest first.
+func (r *mongoServiceRepo) GetHistory(ctx context.Context, id string) ([]models.ServiceDefinition, error) {
+	cursor, err := r.history.Find(ctx, bson.M{"id": id}, options.Find().SetSort(bson.D{{Key: "version", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch history for service %s: %w", id, err)
+	}
+	defer cursor.Close(ctx)
+
+	var defs []models.ServiceDefinition
+	if err := cursor.All(ctx, &defs); err != nil {
+		return nil, fmt.Errorf("failed to decode history for service %s: %w", id, err)
+	}
+	return defs, nil
+}
+
+// Count returns the number of stored service definitions, active or not.
+func (r *mongoServiceRepo) Count(ctx context.Context) (int64, error) {
+	n, err := r.coll.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count services: %w", err)
+	}
+	return n, nil
+}
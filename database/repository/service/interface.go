@@ -0,0 +1,49 @@
+package serviceRepo
+
+import (
+	"bloomify/database"
+	"bloomify/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrServiceNotFound is returned when no service definition has the requested ID.
+	ErrServiceNotFound = errors.New("service definition not found")
+	// ErrVersionConflict is returned when an update was based on a stale version.
+	ErrVersionConflict = errors.New("service definition was modified concurrently")
+)
+
+// ServiceRepository persists the platform service taxonomy and its revision history.
+type ServiceRepository interface {
+	GetAll(ctx context.Context, includeInactive bool) ([]models.ServiceDefinition, error)
+	GetByID(ctx context.Context, id string) (*models.ServiceDefinition, error)
+	Create(ctx context.Context, def *models.ServiceDefinition) error
+	// Update replaces the definition if its stored version equals expectedVersion,
+	// archiving the previous revision and bumping the version.
+	Update(ctx context.Context, def *models.ServiceDefinition, expectedVersion int) error
+	GetHistory(ctx context.Context, id string) ([]models.ServiceDefinition, error)
+	Count(ctx context.Context) (int64, error)
+}
+
+type mongoServiceRepo struct {
+	coll    *mongo.Collection
+	history *mongo.Collection
+}
+
+// NewMongoServiceRepo returns a ServiceRepository backed by MongoDB.
+func NewMongoServiceRepo() ServiceRepository {
+	db := database.MongoClient.Database("bloomify")
+	repo := &mongoServiceRepo{
+		coll:    db.Collection("services"),
+		history: db.Collection("service_versions"),
+	}
+
+	if err := repo.ensureIndexes(); err != nil {
+		fmt.Printf("failed to create service indexes: %v\n", err)
+	}
+	return repo
+}
@@ -100,15 +100,17 @@ func (repo *mongoTimeSlotRepo) RollbackTimeSlotAggregates(slotID string, date st
 func (r *mongoTimeSlotRepo) TryEmbedBooking(
 	ctx context.Context,
 	providerID, slotID, date, bookingID string,
-	units int,
-	priority bool,
+	standardUnits, priorityUnits int,
 ) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	incrementField := "bookedUnitsStandard"
-	if priority {
-		incrementField = "bookedUnitsPriority"
+	increments := bson.M{}
+	if standardUnits > 0 {
+		increments["bookedUnitsStandard"] = standardUnits
+	}
+	if priorityUnits > 0 {
+		increments["bookedUnitsPriority"] = priorityUnits
 	}
 
 	// Step 1: Verify the timeslot with that date exists first (pre-check)
@@ -131,7 +133,9 @@ func (r *mongoTimeSlotRepo) TryEmbedBooking(
 
 	update := bson.M{
 		"$addToSet": bson.M{"bookingIds": bookingID},
-		"$inc":      bson.M{incrementField: units},
+	}
+	if len(increments) > 0 {
+		update["$inc"] = increments
 	}
 
 	res, err := r.coll.UpdateOne(ctx, filter, update)
@@ -148,3 +152,39 @@ func (r *mongoTimeSlotRepo) TryEmbedBooking(
 
 	return nil
 }
+
+func (r *mongoTimeSlotRepo) ReleaseBooking(
+	ctx context.Context,
+	providerID, slotID, date, bookingID string,
+	standardUnits, priorityUnits int,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	decrements := bson.M{}
+	if standardUnits > 0 {
+		decrements["bookedUnitsStandard"] = -standardUnits
+	}
+	if priorityUnits > 0 {
+		decrements["bookedUnitsPriority"] = -priorityUnits
+	}
+
+	// Matching on bookingIds keeps a repeated release from giving units back twice.
+	filter := bson.M{
+		"providerId": providerID,
+		"id":         slotID,
+		"date":       date,
+		"bookingIds": bookingID,
+	}
+	update := bson.M{
+		"$pull": bson.M{"bookingIds": bookingID},
+	}
+	if len(decrements) > 0 {
+		update["$inc"] = decrements
+	}
+
+	if _, err := r.coll.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to release booking %s from slot %s: %w", bookingID, slotID, err)
+	}
+	return nil
+}
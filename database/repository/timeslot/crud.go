@@ -4,6 +4,7 @@ package timeslotRepo
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -94,3 +95,47 @@ func (r *mongoTimeSlotRepo) GetByIDWithDate(ctx context.Context, providerID, slo
 }
 
 func boolPtr(b bool) *bool { return &b }
+
+// ListEarlyBirdSlotsWithoutTiers returns earlybird slots still priced by the legacy fixed thresholds.
+func (r *mongoTimeSlotRepo) ListEarlyBirdSlotsWithoutTiers(ctx context.Context) ([]models.TimeSlot, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"slotModel":       "earlybird",
+		"earlyBird":       bson.M{"$ne": nil},
+		"earlyBird.tiers": bson.M{"$exists": false},
+	}
+	cursor, err := r.coll.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list earlybird slots: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var slots []models.TimeSlot
+	if err := cursor.All(ctx, &slots); err != nil {
+		return nil, fmt.Errorf("failed to decode earlybird slots: %w", err)
+	}
+	return slots, nil
+}
+
+// SetEarlyBirdTiers stores tier schedules on slots that do not have one yet.
+func (r *mongoTimeSlotRepo) SetEarlyBirdTiers(ctx context.Context, tiersBySlotID map[string][]models.EarlyBirdTier) (int64, error) {
+	if len(tiersBySlotID) == 0 {
+		return 0, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	writes := make([]mongo.WriteModel, 0, len(tiersBySlotID))
+	for slotID, tiers := range tiersBySlotID {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"id": slotID, "earlyBird.tiers": bson.M{"$exists": false}}).
+			SetUpdate(bson.M{"$set": bson.M{"earlyBird.tiers": tiers}}))
+	}
+	res, err := r.coll.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, fmt.Errorf("failed to set earlybird tiers: %w", err)
+	}
+	return res.ModifiedCount, nil
+}
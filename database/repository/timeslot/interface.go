@@ -20,7 +20,14 @@ type TimeSlotRepository interface {
 	UpdateTimeSlotAggregates(slotID string, date string, units int, priority bool, currentVersion int) error
 	SetTimeSlotBlockReason(ctx context.Context, providerID, slotID, date string, blocked bool, blockReason string) error
 	RollbackTimeSlotAggregates(slotID string, date string, units int, isPriority bool, minVersion int) error
-	TryEmbedBooking(ctx context.Context, providerID, slotID, date, bookingID string, units int, priority bool) error
+	TryEmbedBooking(ctx context.Context, providerID, slotID, date, bookingID string, standardUnits, priorityUnits int) error
+	// ReleaseBooking undoes TryEmbedBooking: it removes the booking from the slot
+	// and gives its units back. It does nothing when the booking is not embedded.
+	ReleaseBooking(ctx context.Context, providerID, slotID, date, bookingID string, standardUnits, priorityUnits int) error
+
+	// Earlybird tier migration
+	ListEarlyBirdSlotsWithoutTiers(ctx context.Context) ([]models.TimeSlot, error)
+	SetEarlyBirdTiers(ctx context.Context, tiersBySlotID map[string][]models.EarlyBirdTier) (int64, error)
 }
 
 type mongoTimeSlotRepo struct {
@@ -127,49 +127,56 @@ func (r *MongoUserRepo) PullFromArray(id, field string, value interface{}) error
 	return nil
 }
 
-func (r *MongoUserRepo) MarkNotificationsAsRead(id string, notificationIDs []string) error {
+// ClearFCMTokens removes the given push tokens from the user's devices, and
+// the account-wide token if it is one of them.
+func (r *MongoUserRepo) ClearFCMTokens(id string, tokens []string) error {
 	ctx, cancel := newContext(5 * time.Second)
 	defer cancel()
 
-	// Create array filters for the specific notifications to update
-	arrayFilters := options.ArrayFilters{
-		Filters: []any{
-			bson.M{
-				"elem.id": bson.M{"$in": notificationIDs},
-			},
-		},
+	filter := bson.M{"id": id}
+	update := bson.M{"$unset": bson.M{"devices.$[d].fcmToken": ""}}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"d.fcmToken": bson.M{"$in": tokens}}},
+	})
+	if _, err := r.coll.UpdateOne(ctx, filter, update, opts); err != nil {
+		return fmt.Errorf("failed to clear FCM tokens of user %s: %w", id, err)
+	}
+
+	legacy := bson.M{"id": id, "fcmToken": bson.M{"$in": tokens}}
+	if _, err := r.coll.UpdateOne(ctx, legacy, bson.M{"$set": bson.M{"fcmToken": ""}}); err != nil {
+		return fmt.Errorf("failed to clear FCM token of user %s: %w", id, err)
 	}
+	return nil
+}
+
+// SetDeviceFCMToken stores the push token of one of the user's devices in a
+// single update: the token is removed from any other device it moved from, and
+// the account-wide token is cleared. It fails when the device is not found.
+func (r *MongoUserRepo) SetDeviceFCMToken(id, deviceID, token string) error {
+	ctx, cancel := newContext(5 * time.Second)
+	defer cancel()
 
-	// Update operation to:
-	// 1. Set read=true for matching notifications
-	// 2. Update their updatedAt timestamp
-	// 3. Update the user's updatedAt field
+	filter := bson.M{"id": id, "devices.deviceId": deviceID}
 	update := bson.M{
 		"$set": bson.M{
-			"notifications.$[elem].read":      true,
-			"notifications.$[elem].updatedAt": time.Now(),
-			"updatedAt":                       time.Now(),
+			"devices.$[d].fcmToken": token,
+			"fcmToken":              "",
+			"updatedAt":             time.Now(),
 		},
+		"$unset": bson.M{"devices.$[o].fcmToken": ""},
 	}
-
-	opts := options.Update().SetArrayFilters(arrayFilters)
-	filter := bson.M{"id": id}
-
-	result, err := r.coll.UpdateOne(
-		ctx,
-		filter,
-		update,
-		opts,
-	)
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{
+			bson.M{"d.deviceId": deviceID},
+			bson.M{"o.fcmToken": token, "o.deviceId": bson.M{"$ne": deviceID}},
+		},
+	})
+	result, err := r.coll.UpdateOne(ctx, filter, update, opts)
 	if err != nil {
-		return fmt.Errorf("failed to mark notifications as read for user %s: %w", id, err)
+		return fmt.Errorf("failed to set FCM token of user %s: %w", id, err)
 	}
 	if result.MatchedCount == 0 {
-		return fmt.Errorf("user with id %s not found", id)
+		return fmt.Errorf("device %s of user %s not found", deviceID, id)
 	}
-	if result.ModifiedCount == 0 {
-		return fmt.Errorf("no notifications were updated (possibly already read or IDs not found)")
-	}
-
 	return nil
 }
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"bloomify/models"
+	"bloomify/services/booking"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProposeAdjustment handles POST /api/providers/bookings/:bookingID/adjustments.
+// The provider asks the user to pay for extra units or a one-off charge.
+func (h *BookingHandler) ProposeAdjustment(c *gin.Context) {
+	providerID := c.GetString("providerID")
+	if providerID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "provider not authenticated"})
+		return
+	}
+
+	var req models.AdjustmentProposal
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "message": err.Error()})
+		return
+	}
+
+	adj, err := h.BookingSvc.ProposeAdjustment(providerID, c.Param("bookingID"), req)
+	if err != nil {
+		respondAdjustmentError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"adjustment": adj})
+}
+
+// RespondToAdjustment handles POST /api/booking/bookings/:bookingID/adjustments/:adjustmentID.
+// Body: {"approve": true, "paymentIntentId": "..."}; the intent is required for card bookings.
+func (h *BookingHandler) RespondToAdjustment(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var decision models.AdjustmentDecision
+	if err := c.ShouldBindJSON(&decision); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "message": err.Error()})
+		return
+	}
+
+	adj, err := h.BookingSvc.RespondToAdjustment(userID, c.Param("bookingID"), c.Param("adjustmentID"), decision)
+	if err != nil {
+		respondAdjustmentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"adjustment": adj})
+}
+
+// AddTip handles POST /api/booking/bookings/:bookingID/tip.
+func (h *BookingHandler) AddTip(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var req models.TipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "message": err.Error()})
+		return
+	}
+
+	adj, err := h.BookingSvc.AddTip(userID, c.Param("bookingID"), req)
+	if err != nil {
+		respondAdjustmentError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"adjustment": adj})
+}
+
+// PayBalance handles POST /api/booking/bookings/:bookingID/balance.
+// Body: {"paymentIntentId": "..."} authorized for the outstanding balance.
+func (h *BookingHandler) PayBalance(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var req struct {
+		PaymentIntentID string `json:"paymentIntentId" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "message": err.Error()})
+		return
+	}
+
+	plan, err := h.BookingSvc.PayBalance(userID, c.Param("bookingID"), req.PaymentIntentID)
+	if err != nil {
+		respondAdjustmentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"paymentPlan": plan})
+}
+
+func respondAdjustmentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, booking.ErrBookingNotFound), errors.Is(err, booking.ErrAdjustmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found", "message": err.Error()})
+	case errors.Is(err, booking.ErrAdjustmentForbidden), errors.Is(err, booking.ErrNotBookingParticipant):
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden", "message": err.Error()})
+	case errors.Is(err, booking.ErrAdjustmentClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": "adjustment closed", "message": err.Error()})
+	case errors.Is(err, booking.ErrBalanceSettled), errors.Is(err, booking.ErrBalanceInProgress):
+		c.JSON(http.StatusConflict, gin.H{"error": "nothing to pay", "message": err.Error()})
+	default:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "adjustment failed", "message": err.Error()})
+	}
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	chatRepo "bloomify/database/repository/chat"
+	"bloomify/models"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func chatErrorStatus(err error) int {
+	if errors.Is(err, chatRepo.ErrThreadNotFound) || errors.Is(err, chatRepo.ErrMessageNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// ListChatsHandler handles GET /api/admin/chats?flagged=true&page=1&limit=20.
+func (ah *AdminHandler) ListChatsHandler(c *gin.Context) {
+	flaggedOnly := c.Query("flagged") == "true"
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
+
+	result, err := ah.AdminService.ListChatThreads(c.Request.Context(), flaggedOnly, page, limit)
+	if err != nil {
+		zap.L().Error("Failed to list chats", zap.Error(err))
+		c.JSON(chatErrorStatus(err), gin.H{"error": "Failed to list chats", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetChatHandler handles GET /api/admin/chats/:bookingID?before=<RFC 3339>&limit=50
+// and returns the chat including hidden messages and reports.
+func (ah *AdminHandler) GetChatHandler(c *gin.Context) {
+	var before time.Time
+	if raw := c.Query("before"); raw != "" {
+		t, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid before", "message": err.Error()})
+			return
+		}
+		before = t
+	}
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "50"), 10, 64)
+
+	bookingID := c.Param("bookingID")
+	result, err := ah.AdminService.GetChatThread(c.Request.Context(), bookingID, before, limit)
+	if err != nil {
+		zap.L().Error("Failed to load chat", zap.String("bookingID", bookingID), zap.Error(err))
+		c.JSON(chatErrorStatus(err), gin.H{"error": "Failed to load chat", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ModerateChatMessageHandler handles PUT /api/admin/chats/:bookingID/messages/:messageID
+// with {"hidden": true, "reason": ...}. Hidden messages stay stored but their
+// content is withheld from the participants.
+func (ah *AdminHandler) ModerateChatMessageHandler(c *gin.Context) {
+	var req models.ChatModerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "message": err.Error()})
+		return
+	}
+
+	bookingID, messageID := c.Param("bookingID"), c.Param("messageID")
+	msg, err := ah.AdminService.ModerateChatMessage(c.Request.Context(), bookingID, messageID, req, adminActor)
+	if err != nil {
+		zap.L().Error("Failed to moderate chat message", zap.String("bookingID", bookingID), zap.String("messageID", messageID), zap.Error(err))
+		c.JSON(chatErrorStatus(err), gin.H{"error": "Failed to moderate message", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, msg)
+}
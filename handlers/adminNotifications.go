@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+
+	"bloomify/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationPreviewRequest is the body of a template preview.
+type NotificationPreviewRequest struct {
+	Type   string               `json:"type" binding:"required"`
+	Locale string               `json:"locale"`
+	Params utils.TemplateParams `json:"params"`
+}
+
+// ListNotificationTemplatesHandler handles GET /api/admin/notification-templates.
+func (ah *AdminHandler) ListNotificationTemplatesHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"templates":     utils.TemplateLocales(),
+		"defaultLocale": utils.DefaultLocale,
+	})
+}
+
+// PreviewNotificationTemplateHandler handles POST /api/admin/notification-templates/preview
+// and returns the title and body a recipient with the given locale would get.
+func (ah *AdminHandler) PreviewNotificationTemplateHandler(c *gin.Context) {
+	var req NotificationPreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "message": err.Error()})
+		return
+	}
+
+	title, body, err := utils.RenderNotification(req.Type, req.Locale, req.Params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to render template", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"type":   req.Type,
+		"locale": utils.NormalizeLocale(req.Locale),
+		"title":  title,
+		"body":   body,
+	})
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	promotionRepo "bloomify/database/repository/promotion"
+	"bloomify/models"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func promotionErrorStatus(err error) int {
+	if errors.Is(err, promotionRepo.ErrPromoNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
+// ListPromotionsHandler handles GET /api/admin/promotions?includeInactive=true.
+func (ah *AdminHandler) ListPromotionsHandler(c *gin.Context) {
+	includeInactive := c.Query("includeInactive") == "true"
+	promos, err := ah.AdminService.ListPromotions(c.Request.Context(), includeInactive)
+	if err != nil {
+		zap.L().Error("Failed to list promotions", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list promotions", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, promos)
+}
+
+// CreatePromotionHandler handles POST /api/admin/promotions.
+func (ah *AdminHandler) CreatePromotionHandler(c *gin.Context) {
+	var promo models.PromoCode
+	if err := c.ShouldBindJSON(&promo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "message": err.Error()})
+		return
+	}
+
+	created, err := ah.AdminService.CreatePromotion(c.Request.Context(), promo, adminActor)
+	if err != nil {
+		zap.L().Error("Failed to create promotion", zap.String("code", promo.Code), zap.Error(err))
+		c.JSON(promotionErrorStatus(err), gin.H{"error": "Failed to create promotion", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdatePromotionHandler handles PUT /api/admin/promotions/:code.
+func (ah *AdminHandler) UpdatePromotionHandler(c *gin.Context) {
+	var promo models.PromoCode
+	if err := c.ShouldBindJSON(&promo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "message": err.Error()})
+		return
+	}
+	promo.Code = c.Param("code")
+
+	updated, err := ah.AdminService.UpdatePromotion(c.Request.Context(), promo)
+	if err != nil {
+		zap.L().Error("Failed to update promotion", zap.String("code", promo.Code), zap.Error(err))
+		c.JSON(promotionErrorStatus(err), gin.H{"error": "Failed to update promotion", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeletePromotionHandler handles DELETE /api/admin/promotions/:code.
+// Codes are deactivated rather than removed so redemptions stay traceable.
+func (ah *AdminHandler) DeletePromotionHandler(c *gin.Context) {
+	code := c.Param("code")
+	promo, err := ah.AdminService.DeactivatePromotion(c.Request.Context(), code)
+	if err != nil {
+		zap.L().Error("Failed to deactivate promotion", zap.String("code", code), zap.Error(err))
+		c.JSON(promotionErrorStatus(err), gin.H{"error": "Failed to deactivate promotion", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, promo)
+}
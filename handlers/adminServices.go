@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	serviceRepo "bloomify/database/repository/service"
+	"bloomify/models"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// adminActor identifies the editor recorded on taxonomy revisions. Admin auth is a
+// shared static token, so there is no per-admin identity to record yet.
+const adminActor = "admin"
+
+func taxonomyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, serviceRepo.ErrServiceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, serviceRepo.ErrVersionConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// ListServicesHandler handles GET /api/admin/services?includeInactive=true.
+func (ah *AdminHandler) ListServicesHandler(c *gin.Context) {
+	includeInactive := c.Query("includeInactive") == "true"
+	services, err := ah.AdminService.ListServices(c.Request.Context(), includeInactive)
+	if err != nil {
+		zap.L().Error("Failed to list services", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list services", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, services)
+}
+
+// GetServiceHandler handles GET /api/admin/services/:serviceID.
+func (ah *AdminHandler) GetServiceHandler(c *gin.Context) {
+	def, err := ah.AdminService.GetService(c.Request.Context(), c.Param("serviceID"))
+	if err != nil {
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": "Failed to fetch service", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, def)
+}
+
+// CreateServiceHandler handles POST /api/admin/services.
+func (ah *AdminHandler) CreateServiceHandler(c *gin.Context) {
+	var def models.ServiceDefinition
+	if err := c.ShouldBindJSON(&def); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "message": err.Error()})
+		return
+	}
+
+	created, err := ah.AdminService.CreateService(c.Request.Context(), def, adminActor)
+	if err != nil {
+		zap.L().Error("Failed to create service", zap.String("serviceID", def.ID), zap.Error(err))
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": "Failed to create service", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateServiceHandler handles PUT /api/admin/services/:serviceID.
+// The body's version must match the stored version, otherwise 409 is returned.
+func (ah *AdminHandler) UpdateServiceHandler(c *gin.Context) {
+	var def models.ServiceDefinition
+	if err := c.ShouldBindJSON(&def); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "message": err.Error()})
+		return
+	}
+	def.ID = c.Param("serviceID")
+
+	updated, err := ah.AdminService.UpdateService(c.Request.Context(), def, def.Version, adminActor)
+	if err != nil {
+		zap.L().Error("Failed to update service", zap.String("serviceID", def.ID), zap.Error(err))
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": "Failed to update service", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteServiceHandler handles DELETE /api/admin/services/:serviceID?version=N.
+// Services are deactivated rather than removed so their history is preserved.
+func (ah *AdminHandler) DeleteServiceHandler(c *gin.Context) {
+	version, err := strconv.Atoi(c.Query("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "version query parameter is required"})
+		return
+	}
+
+	serviceID := c.Param("serviceID")
+	def, err := ah.AdminService.DeactivateService(c.Request.Context(), serviceID, version, adminActor)
+	if err != nil {
+		zap.L().Error("Failed to deactivate service", zap.String("serviceID", serviceID), zap.Error(err))
+		c.JSON(taxonomyErrorStatus(err), gin.H{"error": "Failed to deactivate service", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, def)
+}
+
+// GetServiceHistoryHandler handles GET /api/admin/services/:serviceID/history.
+func (ah *AdminHandler) GetServiceHistoryHandler(c *gin.Context) {
+	history, err := ah.AdminService.GetServiceHistory(c.Request.Context(), c.Param("serviceID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch service history", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"bloomify/models"
+	"bloomify/services/booking"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// InitiateBasket handles POST /api/booking/basket.
+func (h *BookingHandler) InitiateBasket(c *gin.Context) {
+	var req struct {
+		Plans []models.ServicePlan `json:"plans" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload", "message": err.Error()})
+		return
+	}
+
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	deviceID, deviceName, err := GetDeviceDetails(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	basket, err := h.BookingSvc.InitiateBasket(req.Plans, userID, deviceID, deviceName)
+	if err != nil {
+		var matchErr *booking.MatchError
+		if errors.As(err, &matchErr) {
+			c.JSON(http.StatusOK, gin.H{"error": matchErr.Code, "message": matchErr.Message})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "message": "failed to initiate basket"})
+		return
+	}
+
+	c.JSON(http.StatusOK, basket)
+}
+
+// UpdateBasketItem handles PUT /api/booking/basket/:basketID/items/:itemID.
+func (h *BookingHandler) UpdateBasketItem(c *gin.Context) {
+	var req struct {
+		SelectedProviderID string `json:"selectedProviderID" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload", "message": err.Error()})
+		return
+	}
+
+	weekIndex := 0
+	if weekIndexStr := c.Query("weekIndex"); weekIndexStr != "" {
+		wi, err := strconv.Atoi(weekIndexStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid weekIndex parameter", "message": err.Error()})
+			return
+		}
+		weekIndex = wi
+	}
+
+	basket, err := h.BookingSvc.UpdateBasketItem(c.Param("basketID"), c.Param("itemID"), req.SelectedProviderID, weekIndex)
+	if err != nil {
+		h.Logger.Error("UpdateBasketItem: failed to update basket", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, basket)
+}
+
+// ConfirmBasket handles POST /api/booking/basket/:basketID/confirm.
+func (h *BookingHandler) ConfirmBasket(c *gin.Context) {
+	var req models.BasketCheckoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request payload", "message": err.Error()})
+		return
+	}
+
+	result, err := h.BookingSvc.ConfirmBasket(c.Param("basketID"), req)
+	if err != nil {
+		h.Logger.Error("ConfirmBasket: failed to confirm basket", zap.Error(err))
+		if respondCurrencyMismatch(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CancelBasket handles DELETE /api/booking/basket/:basketID.
+func (h *BookingHandler) CancelBasket(c *gin.Context) {
+	if err := h.BookingSvc.CancelBasket(c.Param("basketID")); err != nil {
+		h.Logger.Error("CancelBasket: failed to cancel basket", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to cancel basket", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "basket cancelled"})
+}
@@ -20,6 +20,8 @@ type BookingHandler struct {
 	BookingSvc  booking.BookingSessionService
 	MatchingSvc booking.MatchingService
 	Logger      *zap.Logger
+	// Customers saves cards for deposit bookings; optional.
+	Customers booking.StripeCustomers
 }
 
 func NewBookingHandler(bs booking.BookingSessionService, ms booking.MatchingService, logger *zap.Logger) *BookingHandler {
@@ -182,6 +184,22 @@ func (h *BookingHandler) GetPaymentIntent(c *gin.Context) {
 		},
 	}
 
+	// Deposit bookings charge the balance to this card later, so it is saved.
+	if req.SavePaymentMethod {
+		if h.Customers == nil {
+			c.JSON(501, gin.H{"error": "Saving cards is not available"})
+			return
+		}
+		customerID, err := h.Customers.StripeCustomerID(c.Request.Context(), userID)
+		if err != nil {
+			h.Logger.Error("Stripe customer error", zap.Error(err))
+			c.JSON(500, gin.H{"error": "Failed to create payment intent"})
+			return
+		}
+		params.Customer = stripe.String(customerID)
+		params.SetupFutureUsage = stripe.String(string(stripe.PaymentIntentSetupFutureUsageOffSession))
+	}
+
 	intent, err := paymentintent.New(params)
 	if err != nil {
 		h.Logger.Error("Stripe PaymentIntent error", zap.Error(err))
@@ -209,6 +227,9 @@ func (h *BookingHandler) ConfirmBooking(c *gin.Context) {
 	bookingResult, err := h.BookingSvc.ConfirmBooking(req.SessionID, req.ConfirmedSlot)
 	if err != nil {
 		h.Logger.Error("ConfirmBooking: failed to confirm booking", zap.Error(err))
+		if respondCurrencyMismatch(c, err) {
+			return
+		}
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
@@ -216,6 +237,22 @@ func (h *BookingHandler) ConfirmBooking(c *gin.Context) {
 	c.JSON(200, bookingResult)
 }
 
+// respondCurrencyMismatch answers 409 with the expected currency when err is a
+// currency mismatch, so the client can re-create the payment in that currency.
+func respondCurrencyMismatch(c *gin.Context, err error) bool {
+	var mismatch *booking.CurrencyMismatchError
+	if !errors.As(err, &mismatch) {
+		return false
+	}
+	c.JSON(http.StatusConflict, gin.H{
+		"error":            "currencyMismatch",
+		"message":          mismatch.Error(),
+		"expectedCurrency": mismatch.Expected,
+		"currency":         mismatch.Got,
+	})
+	return true
+}
+
 // CancelSession handles DELETE /api/booking/session/:sessionID.
 func (h *BookingHandler) CancelSession(c *gin.Context) {
 	sessionID := c.Param("sessionID")
@@ -26,6 +26,9 @@ type HandlerBundle struct {
 	DeleteTimeslotHandler          gin.HandlerFunc
 	ProviderLegalDocumentation     gin.HandlerFunc
 	VerifyBooking                  gin.HandlerFunc
+	AddCatalogueEntryHandler       gin.HandlerFunc
+	UpdateCatalogueEntryHandler    gin.HandlerFunc
+	RemoveCatalogueEntryHandler    gin.HandlerFunc
 
 	// Provider device endpoints
 	GetProviderDevicesHandler          gin.HandlerFunc
@@ -44,6 +47,21 @@ type HandlerBundle struct {
 	MatchNearbyProviders gin.HandlerFunc
 	GeocodeAddress       gin.HandlerFunc
 	ReverseGeocode       gin.HandlerFunc
+	InitiateBasket       gin.HandlerFunc
+	UpdateBasketItem     gin.HandlerFunc
+	ConfirmBasket        gin.HandlerFunc
+	CancelBasket         gin.HandlerFunc
+	GetReceipt           gin.HandlerFunc
+	ProposeAdjustment    gin.HandlerFunc
+	RespondToAdjustment  gin.HandlerFunc
+	AddTip               gin.HandlerFunc
+	PayBalance           gin.HandlerFunc
+	CheckIn              gin.HandlerFunc
+	ResolveNoShow        gin.HandlerFunc
+	ShareLocation        gin.HandlerFunc
+	GetLiveLocation      gin.HandlerFunc
+	StreamLiveLocation   gin.HandlerFunc
+	StreamProviderEvents gin.HandlerFunc
 
 	// AI endpoints
 	AIChatHandler gin.HandlerFunc
@@ -62,6 +80,27 @@ type HandlerBundle struct {
 	UserLegalDocumentation     gin.HandlerFunc
 	UpdateSafetyPreferences    gin.HandlerFunc
 	UpdateTrustedProviders     gin.HandlerFunc
+	GetHouseholdHandler        gin.HandlerFunc
+	AddHouseholdMemberHandler  gin.HandlerFunc
+	UpdateHouseholdMember      gin.HandlerFunc
+	RemoveHouseholdMember      gin.HandlerFunc
+	GetAddressesHandler        gin.HandlerFunc
+	AddAddressHandler          gin.HandlerFunc
+	UpdateAddressHandler       gin.HandlerFunc
+	RemoveAddressHandler       gin.HandlerFunc
+	GetReferralHandler         gin.HandlerFunc
+
+	// Notification inboxes
+	UserNotifications     *NotificationHandler
+	ProviderNotifications *NotificationHandler
+
+	// Booking chats
+	UserChat     *ChatHandler
+	ProviderChat *ChatHandler
+
+	// Masked calls
+	UserContact     *ContactHandler
+	ProviderContact *ContactHandler
 
 	// User device endpoints
 	GetUserDevicesHandler          gin.HandlerFunc
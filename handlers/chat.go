@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"bloomify/models"
+	"bloomify/services/booking"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// chatImageExtensions names uploaded chat images by their detected type.
+var chatImageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// ChatHandler serves booking chats to either users or providers, depending on
+// Role.
+type ChatHandler struct {
+	BookingSvc booking.BookingSessionService
+	Role       string // "user" or "provider"
+}
+
+func NewChatHandler(svc booking.BookingSessionService, role string) *ChatHandler {
+	return &ChatHandler{BookingSvc: svc, Role: role}
+}
+
+// actorID returns the authenticated user or provider.
+func (h *ChatHandler) actorID(c *gin.Context) (string, bool) {
+	key := "userID"
+	if h.Role == models.ChatRoleProvider {
+		key = "providerID"
+	}
+	id := c.GetString(key)
+	if id == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return "", false
+	}
+	return id, true
+}
+
+// ListChats handles GET /chats?page=1&limit=20.
+func (h *ChatHandler) ListChats(c *gin.Context) {
+	id, ok := h.actorID(c)
+	if !ok {
+		return
+	}
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
+
+	result, err := h.BookingSvc.ListChats(h.Role, id, page, limit)
+	if err != nil {
+		respondChatError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetChat handles GET /bookings/:bookingID/chat?before=<RFC 3339>&limit=50 and
+// returns the thread with its messages, newest first.
+func (h *ChatHandler) GetChat(c *gin.Context) {
+	id, ok := h.actorID(c)
+	if !ok {
+		return
+	}
+	var before time.Time
+	if raw := c.Query("before"); raw != "" {
+		t, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid before", "message": err.Error()})
+			return
+		}
+		before = t
+	}
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "50"), 10, 64)
+
+	result, err := h.BookingSvc.GetChat(h.Role, id, c.Param("bookingID"), before, limit)
+	if err != nil {
+		respondChatError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// SendMessage handles POST /bookings/:bookingID/chat with {"text": ...}, or a
+// multipart form with "text" and an "image" file.
+func (h *ChatHandler) SendMessage(c *gin.Context) {
+	id, ok := h.actorID(c)
+	if !ok {
+		return
+	}
+	var req models.ChatMessageRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "message": err.Error()})
+		return
+	}
+
+	var upload *booking.ChatUpload
+	if fileHeader, err := c.FormFile("image"); err == nil {
+		upload, err = saveChatImage(fileHeader)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid image", "message": err.Error()})
+			return
+		}
+		defer os.Remove(upload.Path)
+	}
+
+	msg, err := h.BookingSvc.SendChatMessage(h.Role, id, c.Param("bookingID"), req.Text, upload)
+	if err != nil {
+		respondChatError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, msg)
+}
+
+// saveChatImage copies an uploaded image to a temporary file named after its
+// detected type, which the client cannot choose.
+func saveChatImage(fileHeader *multipart.FileHeader) (*booking.ChatUpload, error) {
+	src, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	contentType := http.DetectContentType(head[:n])
+	ext, ok := chatImageExtensions[contentType]
+	if !ok {
+		return nil, booking.ErrChatImageInvalid
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(os.TempDir(), uuid.New().String()+ext)
+	dst, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+	return &booking.ChatUpload{Path: path, ContentType: contentType, Size: fileHeader.Size}, nil
+}
+
+// MarkRead handles POST /bookings/:bookingID/chat/read and marks the messages
+// the caller received as read.
+func (h *ChatHandler) MarkRead(c *gin.Context) {
+	id, ok := h.actorID(c)
+	if !ok {
+		return
+	}
+	n, err := h.BookingSvc.MarkChatRead(h.Role, id, c.Param("bookingID"))
+	if err != nil {
+		respondChatError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"updated": n})
+}
+
+// ReportMessage handles POST /bookings/:bookingID/chat/messages/:messageID/report
+// with {"reason": ...}.
+func (h *ChatHandler) ReportMessage(c *gin.Context) {
+	id, ok := h.actorID(c)
+	if !ok {
+		return
+	}
+	var req models.ChatReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "message": err.Error()})
+		return
+	}
+	if err := h.BookingSvc.ReportChatMessage(h.Role, id, c.Param("bookingID"), c.Param("messageID"), req.Reason); err != nil {
+		respondChatError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"reported": true})
+}
+
+// StreamChat handles GET /bookings/:bookingID/chat/stream, a server-sent event
+// stream of "message" and "read" events.
+func (h *ChatHandler) StreamChat(c *gin.Context) {
+	id, ok := h.actorID(c)
+	if !ok {
+		return
+	}
+	events, err := h.BookingSvc.SubscribeChat(c.Request.Context(), h.Role, id, c.Param("bookingID"))
+	if err != nil {
+		respondChatError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	heartbeat := time.NewTicker(trackingHeartbeat)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, event)
+			return true
+		case <-heartbeat.C:
+			c.SSEvent("ping", time.Now().Unix())
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func respondChatError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, booking.ErrBookingNotFound), errors.Is(err, booking.ErrChatMessageNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found", "message": err.Error()})
+	case errors.Is(err, booking.ErrNotBookingParticipant):
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden", "message": err.Error()})
+	case errors.Is(err, booking.ErrChatClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": "chat closed", "message": err.Error()})
+	case errors.Is(err, booking.ErrChatMessageInvalid), errors.Is(err, booking.ErrChatImageInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid message", "message": err.Error()})
+	case errors.Is(err, booking.ErrChatUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "chat unavailable", "message": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "chat failed", "message": err.Error()})
+	}
+}
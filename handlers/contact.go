@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"bloomify/models"
+	"bloomify/services/booking"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContactHandler tells users or providers, depending on Role, how to phone the
+// other side of a booking without exposing either number.
+type ContactHandler struct {
+	BookingSvc booking.BookingSessionService
+	Role       string // "user" or "provider"
+}
+
+func NewContactHandler(svc booking.BookingSessionService, role string) *ContactHandler {
+	return &ContactHandler{BookingSvc: svc, Role: role}
+}
+
+// actorID returns the authenticated user or provider.
+func (h *ContactHandler) actorID(c *gin.Context) (string, bool) {
+	key := "userID"
+	if h.Role == models.ChatRoleProvider {
+		key = "providerID"
+	}
+	id := c.GetString(key)
+	if id == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return "", false
+	}
+	return id, true
+}
+
+// GetContact handles GET /bookings/:bookingID/contact and returns the relay
+// number to call, plus the other side's own number if both chose to share.
+func (h *ContactHandler) GetContact(c *gin.Context) {
+	id, ok := h.actorID(c)
+	if !ok {
+		return
+	}
+	contact, err := h.BookingSvc.GetContact(h.Role, id, c.Param("bookingID"))
+	if err != nil {
+		respondContactError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, contact)
+}
+
+// SetSharing handles PUT /bookings/:bookingID/contact with {"share": true|false}
+// and opts the caller in or out of sharing their own number.
+func (h *ContactHandler) SetSharing(c *gin.Context) {
+	id, ok := h.actorID(c)
+	if !ok {
+		return
+	}
+	var req models.ContactSharingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "message": err.Error()})
+		return
+	}
+	contact, err := h.BookingSvc.SetContactSharing(h.Role, id, c.Param("bookingID"), *req.Share)
+	if err != nil {
+		respondContactError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, contact)
+}
+
+func respondContactError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, booking.ErrBookingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found", "message": err.Error()})
+	case errors.Is(err, booking.ErrNotBookingParticipant):
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden", "message": err.Error()})
+	case errors.Is(err, booking.ErrContactClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": "contact closed", "message": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "contact failed", "message": err.Error()})
+	}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"bloomify/models"
+	"bloomify/services/booking"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CheckIn handles POST /api/providers/bookings/:bookingID/check-in.
+// Body: {"kind": "on_the_way"|"arrived"|"started"|"completed"|"customer_absent",
+// "location": {...}}.
+func (h *BookingHandler) CheckIn(c *gin.Context) {
+	providerID := c.GetString("providerID")
+	if providerID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "provider not authenticated"})
+		return
+	}
+
+	var req models.CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "message": err.Error()})
+		return
+	}
+
+	b, err := h.BookingSvc.CheckIn(providerID, c.Param("bookingID"), req)
+	if err != nil {
+		respondNoShowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": b.Status, "checkIns": b.CheckIns, "noShow": b.NoShow})
+}
+
+// ResolveNoShow handles POST /api/booking/bookings/:bookingID/no-show.
+// Body: {"action": "refund"|"rebook"} after the provider did not turn up.
+func (h *BookingHandler) ResolveNoShow(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var decision models.NoShowDecision
+	if err := c.ShouldBindJSON(&decision); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "message": err.Error()})
+		return
+	}
+
+	result, err := h.BookingSvc.ResolveNoShow(userID, c.Param("bookingID"), decision)
+	if err != nil {
+		respondNoShowError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func respondNoShowError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, booking.ErrBookingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found", "message": err.Error()})
+	case errors.Is(err, booking.ErrNotBookingParticipant):
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden", "message": err.Error()})
+	case errors.Is(err, booking.ErrCheckInClosed), errors.Is(err, booking.ErrNoShowClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": "booking closed", "message": err.Error()})
+	case errors.Is(err, booking.ErrCustomerAbsentTooEarly), errors.Is(err, booking.ErrTripTooEarly):
+		c.JSON(http.StatusConflict, gin.H{"error": "too early", "message": err.Error()})
+	case errors.Is(err, booking.ErrCheckInOutOfOrder), errors.Is(err, booking.ErrTrackingUnavailable):
+		c.JSON(http.StatusConflict, gin.H{"error": "invalid check-in", "message": err.Error()})
+	default:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "no-show handling failed", "message": err.Error()})
+	}
+}
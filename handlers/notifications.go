@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	notificationRepo "bloomify/database/repository/notification"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// NotificationHandler serves the notification inbox of either users or
+// providers, depending on Role.
+type NotificationHandler struct {
+	Inbox notificationRepo.NotificationRepository
+	Role  string // "user" or "provider"
+}
+
+func NewNotificationHandler(inbox notificationRepo.NotificationRepository, role string) *NotificationHandler {
+	return &NotificationHandler{Inbox: inbox, Role: role}
+}
+
+// NotificationIDsRequest lists the inbox entries to act on.
+type NotificationIDsRequest struct {
+	IDs []string `json:"ids" binding:"required,min=1"`
+}
+
+// MutedTypesRequest replaces the muted notification types.
+type MutedTypesRequest struct {
+	MutedTypes []string `json:"mutedTypes"`
+}
+
+// recipientID returns the authenticated user or provider.
+func (h *NotificationHandler) recipientID(c *gin.Context) (string, bool) {
+	key := "userID"
+	if h.Role == "provider" {
+		key = "providerID"
+	}
+	id := c.GetString(key)
+	if id == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return "", false
+	}
+	return id, true
+}
+
+// ListNotifications handles GET /notifications?page=1&limit=20&unread=true.
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	id, ok := h.recipientID(c)
+	if !ok {
+		return
+	}
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "20"), 10, 64)
+	unreadOnly := c.Query("unread") == "true"
+
+	result, err := h.Inbox.ListNotifications(c.Request.Context(), id, unreadOnly, page, limit)
+	if err != nil {
+		zap.L().Error("Failed to list notifications", zap.String("recipientID", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list notifications", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// UnreadCount handles GET /notifications/unread-count.
+func (h *NotificationHandler) UnreadCount(c *gin.Context) {
+	id, ok := h.recipientID(c)
+	if !ok {
+		return
+	}
+	n, err := h.Inbox.UnreadCount(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count notifications", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"unread": n})
+}
+
+// MarkRead handles POST /notifications/read with {"ids": [...]}.
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, ok := h.recipientID(c)
+	if !ok {
+		return
+	}
+	var req NotificationIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "message": err.Error()})
+		return
+	}
+	n, err := h.Inbox.MarkNotificationsAsRead(c.Request.Context(), id, req.IDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark notifications as read", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"updated": n})
+}
+
+// MarkAllRead handles POST /notifications/read-all.
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	id, ok := h.recipientID(c)
+	if !ok {
+		return
+	}
+	n, err := h.Inbox.MarkAllAsRead(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark notifications as read", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"updated": n})
+}
+
+// RemoveNotifications handles POST /notifications/remove with {"ids": [...]}.
+func (h *NotificationHandler) RemoveNotifications(c *gin.Context) {
+	id, ok := h.recipientID(c)
+	if !ok {
+		return
+	}
+	var req NotificationIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "message": err.Error()})
+		return
+	}
+	n, err := h.Inbox.RemoveNotifications(c.Request.Context(), id, req.IDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove notifications", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"removed": n})
+}
+
+// GetPreferences handles GET /notifications/preferences.
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	id, ok := h.recipientID(c)
+	if !ok {
+		return
+	}
+	prefs, err := h.Inbox.GetPreferences(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load notification preferences", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, prefs)
+}
+
+// UpdatePreferences handles PUT /notifications/preferences with
+// {"mutedTypes": [...]}. Muted types still reach the inbox but are not pushed.
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	id, ok := h.recipientID(c)
+	if !ok {
+		return
+	}
+	var req MutedTypesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "message": err.Error()})
+		return
+	}
+	prefs, err := h.Inbox.SetMutedTypes(c.Request.Context(), h.Role, id, req.MutedTypes)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save notification preferences", "message": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, prefs)
+}
@@ -106,11 +106,16 @@ func (h *ProviderHandler) RegisterProviderHandler(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"sessionID": req.SessionID, "status": status})
 	case "catalogue":
 		// Step 3: Service Catalogue & Finalization.
-		if req.SessionID == "" || req.ServiceCatalogue == nil {
+		var catalogues []models.ServiceCatalogue
+		if req.ServiceCatalogue != nil {
+			catalogues = append(catalogues, *req.ServiceCatalogue)
+		}
+		catalogues = append(catalogues, req.ServiceCatalogues...)
+		if req.SessionID == "" || len(catalogues) == 0 {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Missing sessionID or service catalogue data"})
 			return
 		}
-		providerAuthResp, err := h.Service.FinalizeRegistration(req.SessionID, *req.ServiceCatalogue)
+		providerAuthResp, err := h.Service.FinalizeRegistration(req.SessionID, catalogues)
 		if err != nil {
 			logger.Error("Failed to finalize registration", zap.Error(err))
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Registration finalization failed: " + err.Error()})
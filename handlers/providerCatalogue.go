@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+
+	"bloomify/models"
+	"bloomify/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AddCatalogueEntryHandler handles POST /providers/catalogue.
+func (h *ProviderHandler) AddCatalogueEntryHandler(c *gin.Context) {
+	logger := utils.GetLogger()
+	providerID := c.GetString("providerID")
+	if providerID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Provider not authenticated"})
+		return
+	}
+
+	var entry models.ServiceCatalogue
+	if err := c.ShouldBindJSON(&entry); err != nil {
+		logger.Error("Invalid catalogue entry", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload", "message": err.Error()})
+		return
+	}
+
+	prov, err := h.Service.AddCatalogueEntry(c.Request.Context(), providerID, entry)
+	if err != nil {
+		logger.Error("Failed to add catalogue entry", zap.String("providerID", providerID), zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to add catalogue entry", "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"serviceCatalogues": prov.ServiceCatalogues})
+}
+
+// UpdateCatalogueEntryHandler handles PUT /providers/catalogue/:catalogueID.
+func (h *ProviderHandler) UpdateCatalogueEntryHandler(c *gin.Context) {
+	logger := utils.GetLogger()
+	providerID := c.GetString("providerID")
+	if providerID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Provider not authenticated"})
+		return
+	}
+	catalogueID := c.Param("catalogueID")
+
+	var entry models.ServiceCatalogue
+	if err := c.ShouldBindJSON(&entry); err != nil {
+		logger.Error("Invalid catalogue entry", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload", "message": err.Error()})
+		return
+	}
+
+	prov, err := h.Service.UpdateCatalogueEntry(c.Request.Context(), providerID, catalogueID, entry)
+	if err != nil {
+		logger.Error("Failed to update catalogue entry", zap.String("catalogueID", catalogueID), zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to update catalogue entry", "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"serviceCatalogues": prov.ServiceCatalogues})
+}
+
+// RemoveCatalogueEntryHandler handles DELETE /providers/catalogue/:catalogueID.
+func (h *ProviderHandler) RemoveCatalogueEntryHandler(c *gin.Context) {
+	logger := utils.GetLogger()
+	providerID := c.GetString("providerID")
+	if providerID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Provider not authenticated"})
+		return
+	}
+	catalogueID := c.Param("catalogueID")
+
+	prov, err := h.Service.RemoveCatalogueEntry(c.Request.Context(), providerID, catalogueID)
+	if err != nil {
+		logger.Error("Failed to remove catalogue entry", zap.String("catalogueID", catalogueID), zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to remove catalogue entry", "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"serviceCatalogues": prov.ServiceCatalogues})
+}
@@ -95,13 +95,15 @@ func (h *ProviderDeviceHandler) UpdateProviderFCMTokenHandler(c *gin.Context) {
 		return
 	}
 
-	utils.Logger.Info("Updating FCM token for provider", zap.String("providerID", providerID), zap.String("newFCMToken", req.FCMToken))
-
-	updates := map[string]interface{}{
-		"fcmToken": req.FCMToken,
+	deviceID := c.GetString("deviceID")
+	if deviceID == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Device ID not found in context"})
+		return
 	}
 
-	updatedProvider, err := h.ProviderService.UpdateProvider(c, providerID, updates)
+	utils.Logger.Info("Updating FCM token for provider", zap.String("providerID", providerID), zap.String("deviceID", deviceID))
+
+	updatedProvider, err := h.ProviderService.RegisterFCMToken(c, providerID, deviceID, req.FCMToken)
 	if err != nil {
 		utils.Logger.Error("Failed to update FCM token for provider", zap.String("providerID", providerID), zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
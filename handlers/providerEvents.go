@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"bloomify/services/booking"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// StreamProviderEvents handles GET /api/providers/events/stream, a server-sent
+// event stream of the provider's domain events: "booking.created",
+// "booking.cancelled", "payment.captured", "review.received" and
+// "slot.filled". Each event carries its ID, so a client reconnecting with the
+// Last-Event-ID header, or ?lastEventId=, first receives what it missed.
+func (h *BookingHandler) StreamProviderEvents(c *gin.Context) {
+	providerID := c.GetString("providerID")
+	if providerID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "provider not authenticated"})
+		return
+	}
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("lastEventId")
+	}
+
+	events, err := h.BookingSvc.SubscribeProviderEvents(c.Request.Context(), providerID, lastEventID)
+	if err != nil {
+		if errors.Is(err, booking.ErrInvalidEventID) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid last event ID", "message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to open event stream", "message": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	heartbeat := time.NewTicker(trackingHeartbeat)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.Render(-1, sse.Event{Id: event.ID, Event: event.Type, Data: event})
+			return true
+		case <-heartbeat.C:
+			c.SSEvent("ping", time.Now().Unix())
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"bloomify/models"
+	"bloomify/services/booking"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetReceipt handles GET /api/booking/receipts/:bookingID.
+// Query: format=pdf|html (default pdf), store=true to keep a copy and return its download URL.
+func (h *BookingHandler) GetReceipt(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	format := c.DefaultQuery("format", models.ReceiptFormatPDF)
+	if format != models.ReceiptFormatPDF && format != models.ReceiptFormatHTML {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid format", "message": "format must be pdf or html"})
+		return
+	}
+	store, _ := strconv.ParseBool(c.DefaultQuery("store", "false"))
+
+	doc, err := h.BookingSvc.GetReceipt(userID, c.Param("bookingID"), format, store)
+	if err != nil {
+		switch {
+		case errors.Is(err, booking.ErrReceiptNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "booking not found", "message": err.Error()})
+		case errors.Is(err, booking.ErrReceiptForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate receipt", "message": err.Error()})
+		}
+		return
+	}
+
+	if store {
+		c.JSON(http.StatusOK, gin.H{"filename": doc.Filename, "url": doc.URL})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", doc.Filename))
+	c.Data(http.StatusOK, doc.ContentType, doc.Data)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"bloomify/models"
+	"bloomify/services/booking"
+
+	"github.com/gin-gonic/gin"
+)
+
+// trackingHeartbeat keeps idle location streams open through proxies.
+const trackingHeartbeat = 25 * time.Second
+
+// ShareLocation handles POST /api/providers/bookings/:bookingID/location.
+// Body: {"lat": ..., "lng": ..., "heading": ..., "speed": ..., "accuracy": ...},
+// sent while on the way to an in-home booking.
+func (h *BookingHandler) ShareLocation(c *gin.Context) {
+	providerID := c.GetString("providerID")
+	if providerID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "provider not authenticated"})
+		return
+	}
+
+	var fix models.LocationFix
+	if err := c.ShouldBindJSON(&fix); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "message": err.Error()})
+		return
+	}
+
+	loc, err := h.BookingSvc.ShareLocation(providerID, c.Param("bookingID"), fix)
+	if err != nil {
+		respondTrackingError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, loc)
+}
+
+// GetLiveLocation handles GET /api/booking/bookings/:bookingID/location and
+// returns the provider's latest position with an ETA.
+func (h *BookingHandler) GetLiveLocation(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	loc, err := h.BookingSvc.GetLiveLocation(userID, c.Param("bookingID"))
+	if err != nil {
+		respondTrackingError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"location": loc})
+}
+
+// StreamLiveLocation handles GET /api/booking/bookings/:bookingID/location/stream.
+// It is a server-sent event stream of "location" events, ending with an "end"
+// event once the job is completed or the trip otherwise closes.
+func (h *BookingHandler) StreamLiveLocation(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	updates, err := h.BookingSvc.SubscribeLiveLocation(c.Request.Context(), userID, c.Param("bookingID"))
+	if err != nil {
+		respondTrackingError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	heartbeat := time.NewTicker(trackingHeartbeat)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case loc, ok := <-updates:
+			if !ok {
+				return false
+			}
+			if loc.Ended {
+				c.SSEvent("end", loc)
+				return false
+			}
+			c.SSEvent("location", loc)
+			return true
+		case <-heartbeat.C:
+			c.SSEvent("ping", time.Now().Unix())
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func respondTrackingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, booking.ErrNotBookingParticipant):
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden", "message": err.Error()})
+	case errors.Is(err, booking.ErrTripNotActive):
+		c.JSON(http.StatusConflict, gin.H{"error": "trip not active", "message": err.Error()})
+	case errors.Is(err, booking.ErrLocationRateLimited):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "rate limited", "message": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "live location failed", "message": err.Error()})
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"bloomify/models"
+	"bloomify/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetAddressesHandler handles GET /api/users/addresses.
+func (h *UserHandler) GetAddressesHandler(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return
+	}
+
+	addresses, err := h.UserService.GetAddresses(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"addresses": addresses})
+}
+
+// AddAddressHandler handles POST /api/users/addresses.
+func (h *UserHandler) AddAddressHandler(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return
+	}
+
+	var addr models.SavedAddress
+	if err := c.ShouldBindJSON(&addr); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request format", "message": err.Error()})
+		return
+	}
+
+	created, err := h.UserService.AddAddress(userID, addr)
+	if err != nil {
+		utils.GetLogger().Error("Failed to add address", zap.String("userID", userID), zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateAddressHandler handles PUT /api/users/addresses/:addressID.
+func (h *UserHandler) UpdateAddressHandler(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return
+	}
+
+	var addr models.SavedAddress
+	if err := c.ShouldBindJSON(&addr); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request format", "message": err.Error()})
+		return
+	}
+
+	updated, err := h.UserService.UpdateAddress(userID, c.Param("addressID"), addr)
+	if err != nil {
+		utils.GetLogger().Error("Failed to update address", zap.String("userID", userID), zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// RemoveAddressHandler handles DELETE /api/users/addresses/:addressID.
+func (h *UserHandler) RemoveAddressHandler(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return
+	}
+
+	if err := h.UserService.RemoveAddress(userID, c.Param("addressID")); err != nil {
+		utils.GetLogger().Error("Failed to remove address", zap.String("userID", userID), zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Address removed"})
+}
@@ -101,13 +101,16 @@ func (h *UserHandler) UpdateFCMTokenHandler(c *gin.Context) {
 		return
 	}
 
+	deviceID := c.GetString("deviceID")
+	if deviceID == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Device ID not found in context"})
+		return
+	}
+
 	// Log the update attempt
-	utils.Logger.Info("Updating FCM token", zap.String("userID", userID), zap.String("newFCMToken", req.FCMToken))
+	utils.Logger.Info("Updating FCM token", zap.String("userID", userID), zap.String("deviceID", deviceID))
 
-	updatedUser, err := h.UserService.UpdateUser(models.UserUpdateRequest{
-		ID:       &userID,
-		FCMToken: &req.FCMToken,
-	})
+	updatedUser, err := h.UserService.RegisterFCMToken(userID, deviceID, req.FCMToken)
 	if err != nil {
 		utils.Logger.Error("Failed to update FCM token", zap.String("userID", userID), zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
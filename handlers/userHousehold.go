@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"bloomify/models"
+	"bloomify/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetHouseholdHandler handles GET /api/users/household.
+func (h *UserHandler) GetHouseholdHandler(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return
+	}
+
+	household, err := h.UserService.GetHousehold(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"household": household})
+}
+
+// AddHouseholdMemberHandler handles POST /api/users/household.
+func (h *UserHandler) AddHouseholdMemberHandler(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return
+	}
+
+	var member models.HouseholdMember
+	if err := c.ShouldBindJSON(&member); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request format", "message": err.Error()})
+		return
+	}
+
+	created, err := h.UserService.AddHouseholdMember(userID, member)
+	if err != nil {
+		utils.GetLogger().Error("Failed to add household member", zap.String("userID", userID), zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateHouseholdMemberHandler handles PUT /api/users/household/:memberID.
+func (h *UserHandler) UpdateHouseholdMemberHandler(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return
+	}
+
+	var member models.HouseholdMember
+	if err := c.ShouldBindJSON(&member); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request format", "message": err.Error()})
+		return
+	}
+
+	updated, err := h.UserService.UpdateHouseholdMember(userID, c.Param("memberID"), member)
+	if err != nil {
+		utils.GetLogger().Error("Failed to update household member", zap.String("userID", userID), zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// RemoveHouseholdMemberHandler handles DELETE /api/users/household/:memberID.
+func (h *UserHandler) RemoveHouseholdMemberHandler(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return
+	}
+
+	if err := h.UserService.RemoveHouseholdMember(userID, c.Param("memberID")); err != nil {
+		utils.GetLogger().Error("Failed to remove household member", zap.String("userID", userID), zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Household member removed"})
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"bloomify/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetReferralHandler handles GET /api/users/referral.
+func (h *UserHandler) GetReferralHandler(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return
+	}
+
+	summary, err := h.UserService.GetReferral(userID)
+	if err != nil {
+		utils.GetLogger().Error("Failed to fetch referral", zap.String("userID", userID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
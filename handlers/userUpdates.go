@@ -14,7 +14,7 @@ type SafetySettingsPayload struct {
 	NoShowThresholdMinutes int    `json:"noShowThresholdMinutes"`
 	SafetyReminderMinutes  int    `json:"safetyReminderMinutes"`
 	RequireInsured         bool   `json:"requireInsured"`
-	AlertChannel           string `json:"alertChannel"`
+	AlertChannel           string `json:"alertChannel" binding:"omitempty,oneof=push sms whatsapp both"`
 }
 
 func (h *UserHandler) UpdateSafetyPreferences(c *gin.Context) {
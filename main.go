@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,9 +13,14 @@ import (
 	"bloomify/config"
 	"bloomify/cron"
 	"bloomify/database"
+	chatRepo "bloomify/database/repository/chat"
+	ledgerRepo "bloomify/database/repository/ledger"
+	notificationRepo "bloomify/database/repository/notification"
+	promotionRepo "bloomify/database/repository/promotion"
 	providerRepo "bloomify/database/repository/provider"
 	recordsRepo "bloomify/database/repository/records"
 	schedulerRepo "bloomify/database/repository/scheduler"
+	serviceRepo "bloomify/database/repository/service"
 	timeslotRepo "bloomify/database/repository/timeslot"
 	userRepoPkg "bloomify/database/repository/user"
 	"bloomify/handlers"
@@ -25,6 +31,7 @@ import (
 	ai "bloomify/services/intelligence"
 	"bloomify/services/notification"
 	"bloomify/services/provider"
+	"bloomify/services/relay"
 	"bloomify/services/storage"
 	"bloomify/services/user"
 	"bloomify/utils"
@@ -39,6 +46,11 @@ func main() {
 	logger := utils.GetLogger()
 	database.InitDB()
 	utils.InitRedis()
+	utils.InitExchangeRates(context.Background())
+	// optional notification template overrides; the compiled-in templates are used otherwise
+	if err := utils.LoadNotificationTemplates("config/notificationTemplates.json"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Sugar().Warnf("Using built-in notification templates: %v", err)
+	}
 	utils.FirebaseInit()
 
 	utils.StartHealthMonitor(utils.GetAllRedisClients(), database.MongoClient)
@@ -55,6 +67,28 @@ func main() {
 	timeslotRepo := timeslotRepo.NewMongoTimeSlotRepo()
 	schedulerRepo := schedulerRepo.NewMongoSchedulerRepo(timeslotRepo)
 	recordsRepo := recordsRepo.NewMongoRecordRepo()
+	serviceRepo := serviceRepo.NewMongoServiceRepo()
+	promotionRepo := promotionRepo.NewMongoPromotionRepo()
+	ledgerRepo := ledgerRepo.NewMongoLedgerRepo()
+	notificationRepo := notificationRepo.NewMongoNotificationRepo()
+	chatRepo := chatRepo.NewMongoChatRepo()
+
+	// Seed the service taxonomy from the compiled-in map on first start.
+	if err := booking.SeedServiceTaxonomy(serviceRepo); err != nil {
+		logger.Sugar().Warnf("failed to seed service taxonomy: %v", err)
+	}
+	// Give earlybird slots from before tier schedules an explicit schedule.
+	if n, err := booking.MigrateEarlyBirdTiers(timeslotRepo); err != nil {
+		logger.Sugar().Warnf("failed to migrate earlybird tiers: %v", err)
+	} else if n > 0 {
+		logger.Sugar().Infof("migrated %d earlybird slots to tier schedules", n)
+	}
+	// Move inboxes still embedded in user and provider documents to their collections.
+	if n, err := notificationRepo.DrainEmbeddedNotifications(context.Background()); err != nil {
+		logger.Sugar().Warnf("failed to migrate embedded notifications: %v", err)
+	} else if n > 0 {
+		logger.Sugar().Infof("migrated the notification inboxes of %d accounts", n)
+	}
 
 	// services
 	userService, err := user.NewDefaultUserService(
@@ -64,8 +98,14 @@ func main() {
 	if err != nil {
 		logger.Sugar().Fatalf("failed to initialize user service: %v", err)
 	}
+	userService.PromotionRepo = promotionRepo
+	userService.Inbox = notificationRepo
 
-	adminService := &admin.DefaultAdminService{}
+	adminService := &admin.DefaultAdminService{
+		ServiceRepo:   serviceRepo,
+		PromotionRepo: promotionRepo,
+		ChatRepo:      chatRepo,
+	}
 
 	providerService, err := provider.NewDefaultProviderService(
 		provRepo,
@@ -77,6 +117,7 @@ func main() {
 	if err != nil {
 		logger.Sugar().Fatalf("failed to initialize provider service: %v", err)
 	}
+	providerService.Inbox = notificationRepo
 
 	notificationService, err := notification.NewDefaultNotificationService(
 		userService,
@@ -85,24 +126,24 @@ func main() {
 	if err != nil {
 		logger.Sugar().Fatalf("failed to initialize notification service: %v", err)
 	}
+	notificationService.Dispatcher = notification.NewDispatcherFromConfig(notificationRepo, notificationService)
+	notificationService.Inbox = notificationRepo
 
 	matchingService := &booking.DefaultMatchingService{ProviderRepo: provRepo}
 
 	paymentHandler := booking.NewPaymentHandler(logger, userService)
 
 	schedulingEngine := &booking.DefaultSchedulingEngine{
-		Repo:           schedulerRepo,
-		PaymentHandler: paymentHandler,
-		ProviderRepo:   provRepo,
-		TimeslotsRepo:  timeslotRepo,
-		UserService:    userService,
-		Notification:   notificationService,
-	}
-
-	bookingService := &booking.DefaultBookingSessionService{
-		MatchingSvc:     matchingService,
-		SchedulerEngine: schedulingEngine,
-		NotificationSvc: notificationService,
+		Repo:              schedulerRepo,
+		PaymentHandler:    paymentHandler,
+		ProviderRepo:      provRepo,
+		TimeslotsRepo:     timeslotRepo,
+		UserService:       userService,
+		Notification:      notificationService,
+		PromotionRepo:     promotionRepo,
+		LedgerRepo:        ledgerRepo,
+		AsynqClient:       utils.GetReminderQueueClient(),
+		ReminderInspector: utils.GetReminderQueueInspector(),
 	}
 
 	storageService, err := storage.NewFirebaseStorageService(
@@ -112,6 +153,17 @@ func main() {
 	if err != nil {
 		logger.Sugar().Fatalf("failed to initialize storage service: %v", err)
 	}
+	adminService.Storage = storageService
+
+	bookingService := &booking.DefaultBookingSessionService{
+		MatchingSvc:     matchingService,
+		SchedulerEngine: schedulingEngine,
+		NotificationSvc: notificationService,
+		ServiceRepo:     serviceRepo,
+		Storage:         storageService,
+		ChatRepo:        chatRepo,
+		Relay:           relay.NewRelayFromConfig(),
+	}
 
 	aiCtxStore := ai.NewRedisContextStore(utils.GetAIContextCacheClient(), 30*time.Minute)
 	aiService := ai.NewLocalAIService(
@@ -120,13 +172,14 @@ func main() {
 	)
 
 	// cron
-	cron.InitReminderWorker(notificationService)
+	cron.InitReminderWorker(notificationService, schedulingEngine, schedulingEngine)
 
 	// handlers
 	providerHandler := handlers.NewProviderHandler(providerService, adminService, notificationService)
 	providerDeviceHandler := handlers.NewProviderDeviceHandler(providerService)
 	userHandler := handlers.NewUserHandler(userService, providerService, adminService)
 	bookingHandler := handlers.NewBookingHandler(bookingService, matchingService, logger)
+	bookingHandler.Customers = paymentHandler
 	adminHandler := handlers.NewAdminHandler(userService, providerService, adminService)
 	storageHandler := handlers.NewStorageHandler(storageService)
 	aiHandler := handlers.NewDefaultAIHandler(aiService)
@@ -150,6 +203,9 @@ func main() {
 		DeleteTimeslotHandler:          providerHandler.DeleteTimeslotHandler,
 		ResetProviderPasswordHandler:   providerHandler.ResetProviderPasswordHandler,
 		VerifyBooking:                  providerHandler.VerifyBooking,
+		AddCatalogueEntryHandler:       providerHandler.AddCatalogueEntryHandler,
+		UpdateCatalogueEntryHandler:    providerHandler.UpdateCatalogueEntryHandler,
+		RemoveCatalogueEntryHandler:    providerHandler.RemoveCatalogueEntryHandler,
 
 		// Provider device endpoints
 		GetProviderDevicesHandler:          providerDeviceHandler.GetProviderDevicesHandler,
@@ -162,6 +218,21 @@ func main() {
 		UpdateSession:        bookingHandler.UpdateSession,
 		ConfirmBooking:       bookingHandler.ConfirmBooking,
 		CancelSession:        bookingHandler.CancelSession,
+		InitiateBasket:       bookingHandler.InitiateBasket,
+		UpdateBasketItem:     bookingHandler.UpdateBasketItem,
+		ConfirmBasket:        bookingHandler.ConfirmBasket,
+		CancelBasket:         bookingHandler.CancelBasket,
+		GetReceipt:           bookingHandler.GetReceipt,
+		ProposeAdjustment:    bookingHandler.ProposeAdjustment,
+		RespondToAdjustment:  bookingHandler.RespondToAdjustment,
+		AddTip:               bookingHandler.AddTip,
+		PayBalance:           bookingHandler.PayBalance,
+		CheckIn:              bookingHandler.CheckIn,
+		ResolveNoShow:        bookingHandler.ResolveNoShow,
+		ShareLocation:        bookingHandler.ShareLocation,
+		GetLiveLocation:      bookingHandler.GetLiveLocation,
+		StreamLiveLocation:   bookingHandler.StreamLiveLocation,
+		StreamProviderEvents: bookingHandler.StreamProviderEvents,
 		GetAvailableServices: bookingHandler.GetAvailableServices,
 		GetServiceByID:       bookingHandler.GetServiceByID,
 		GetDirections:        bookingHandler.GetDirections,
@@ -190,6 +261,27 @@ func main() {
 		ResetPasswordHandler:           userHandler.ResetUserPasswordHandler,
 		UpdateSafetyPreferences:        userHandler.UpdateSafetyPreferences,
 		UpdateTrustedProviders:         userHandler.UpdateTrustedProviders,
+		GetHouseholdHandler:            userHandler.GetHouseholdHandler,
+		AddHouseholdMemberHandler:      userHandler.AddHouseholdMemberHandler,
+		UpdateHouseholdMember:          userHandler.UpdateHouseholdMemberHandler,
+		RemoveHouseholdMember:          userHandler.RemoveHouseholdMemberHandler,
+		GetAddressesHandler:            userHandler.GetAddressesHandler,
+		AddAddressHandler:              userHandler.AddAddressHandler,
+		UpdateAddressHandler:           userHandler.UpdateAddressHandler,
+		RemoveAddressHandler:           userHandler.RemoveAddressHandler,
+		GetReferralHandler:             userHandler.GetReferralHandler,
+
+		// Notification inboxes
+		UserNotifications:     handlers.NewNotificationHandler(notificationRepo, "user"),
+		ProviderNotifications: handlers.NewNotificationHandler(notificationRepo, "provider"),
+
+		// Booking chats
+		UserChat:     handlers.NewChatHandler(bookingService, "user"),
+		ProviderChat: handlers.NewChatHandler(bookingService, "provider"),
+
+		// Masked calls
+		UserContact:     handlers.NewContactHandler(bookingService, "user"),
+		ProviderContact: handlers.NewContactHandler(bookingService, "provider"),
 
 		// Admin endpoints
 		AdminHandler:            adminHandler,
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// Booking adjustment kinds.
+const (
+	AdjustmentExtraUnits = "extra_units" // more units than booked, e.g. 7 kg of laundry instead of 5
+	AdjustmentCharge     = "charge"      // a one-off extra charge, e.g. parts or overtime
+	AdjustmentTip        = "tip"
+)
+
+// Booking adjustment statuses.
+const (
+	AdjustmentPending    = "pending"    // proposed by the provider, awaiting the user
+	AdjustmentProcessing = "processing" // approved; payment in progress
+	AdjustmentPaid       = "paid"       // captured by card, or recorded for cash collection
+	AdjustmentDeclined   = "declined"
+	AdjustmentFailed     = "failed" // payment failed; the user may approve again
+)
+
+// BookingAdjustment is an amount added to a booking after it was confirmed.
+// Amount is the provider's price and Total what the user pays for it, with the
+// platform fee and tax in Taxes; both are in the booking's charge currency.
+type BookingAdjustment struct {
+	AdjustmentID string        `bson:"adjustmentId" json:"adjustmentId"`
+	Kind         string        `bson:"kind" json:"kind"`
+	Units        int           `bson:"units,omitempty" json:"units,omitempty"` // extra units, for extra_units
+	Amount       float64       `bson:"amount" json:"amount"`
+	Taxes        []InvoiceLine `bson:"taxes,omitempty" json:"taxes,omitempty"`
+	Total        float64       `bson:"total" json:"total"`
+	Currency     string        `bson:"currency" json:"currency"`
+	Reason       string        `bson:"reason,omitempty" json:"reason,omitempty"`
+	ProposedBy   string        `bson:"proposedBy" json:"proposedBy"` // provider ID, or user ID for tips
+	Status       string        `bson:"status" json:"status"`
+	PaymentID    string        `bson:"paymentId,omitempty" json:"paymentId,omitempty"`
+	Error        string        `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedAt    time.Time     `bson:"createdAt" json:"createdAt"`
+	ResolvedAt   time.Time     `bson:"resolvedAt,omitempty" json:"resolvedAt,omitzero"`
+}
+
+// AdjustmentProposal is sent by a provider to charge for extra units or work.
+type AdjustmentProposal struct {
+	Kind   string  `json:"kind" binding:"required,oneof=extra_units charge"`
+	Units  int     `json:"units,omitempty"`  // required for extra_units
+	Amount float64 `json:"amount,omitempty"` // required for charge, in the booking's charge currency
+	Reason string  `json:"reason" binding:"required"`
+}
+
+// AdjustmentDecision is the user's answer to a proposed adjustment.
+type AdjustmentDecision struct {
+	Approve         bool   `json:"approve"`
+	PaymentIntentID string `json:"paymentIntentId,omitempty"` // authorized intent for the amount; card bookings only
+}
+
+// TipRequest adds a tip to a booking.
+type TipRequest struct {
+	Amount          float64 `json:"amount" binding:"required,gt=0"`
+	PaymentIntentID string  `json:"paymentIntentId,omitempty"` // card bookings only
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// BasketItem is one service plan inside a basket session, with its own matched
+// providers, selected provider and availability.
+type BasketItem struct {
+	ItemID            string          `json:"itemID"`
+	ServicePlan       ServicePlan     `json:"servicePlan"`
+	MatchedProviders  []ProviderDTO   `json:"matchedProviders"`
+	SelectedProvider  string          `json:"selectedProvider,omitempty"`
+	Availability      []AvailableSlot `json:"availability,omitempty"`
+	AvailabilityError string          `json:"availabilityError,omitempty"`
+	MaxAvailableDate  string          `json:"maxAvailableDate,omitempty"`
+}
+
+// BasketSession holds several service plans that are checked out together.
+type BasketSession struct {
+	BasketID   string       `json:"basketID"`
+	Items      []BasketItem `json:"items"`
+	UserID     string       `json:"userID"`
+	DeviceID   string       `json:"deviceID"`
+	DeviceName string       `json:"deviceName"`
+	CreatedAt  time.Time    `json:"createdAt"`
+}
+
+// BasketSlotSelection is the slot confirmed for a single basket item.
+type BasketSlotSelection struct {
+	ItemID        string                `json:"itemID" binding:"required"`
+	ConfirmedSlot AvailableSlotResponse `json:"confirmedSlot" binding:"required"`
+}
+
+// BasketCheckoutRequest confirms every item of a basket with one payment.
+type BasketCheckoutRequest struct {
+	Selections  []BasketSlotSelection `json:"selections" binding:"required,min=1,dive"`
+	UserPayment UserPayment           `json:"userPayment" binding:"required"`
+	PromoCode   string                `json:"promoCode,omitempty"` // applied to the first item it is valid for
+}
+
+// BasketInvoiceLine is one booking's share of a combined basket invoice.
+type BasketInvoiceLine struct {
+	BookingID    string  `json:"bookingId"`
+	ServiceType  string  `json:"serviceType"`
+	ProviderName string  `json:"providerName"`
+	Date         string  `json:"date"`
+	Units        int     `json:"units"`
+	UnitType     string  `json:"unitType"`
+	CustomOption string  `json:"customOption"`
+	Amount       float64 `json:"amount"`
+}
+
+// PublicBasketInvoice is the combined invoice returned for a basket checkout.
+type PublicBasketInvoice struct {
+	PublicInvoice
+	Lines []BasketInvoiceLine `json:"lines"`
+}
+
+// PublicBasketData is the result of a successful basket checkout.
+type PublicBasketData struct {
+	BasketID string              `json:"basketId"`
+	Bookings []PublicBookingData `json:"bookings"`
+	Invoice  PublicBasketInvoice `json:"invoice"`
+}
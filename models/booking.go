@@ -10,6 +10,8 @@ type Booking struct {
 	UserID             string               `bson:"userId" json:"userId"`
 	TimeSlotID         string               `bson:"timeSlotId" json:"timeSlotId"`
 	ServiceType        string               `bson:"serviceType" json:"serviceType"`
+	CatalogueID        string               `bson:"catalogueId,omitempty" json:"catalogueId,omitempty"`
+	BasketID           string               `bson:"basketId,omitempty" json:"basketId,omitempty"` // set when booked as part of a multi-service checkout
 	Units              int                  `bson:"units" json:"units"`
 	UnitType           string               `bson:"unitType" json:"unitType"`
 	TotalPrice         float64              `bson:"totalPrice" json:"totalPrice"`
@@ -19,12 +21,25 @@ type Booking struct {
 	Start              int                  `bson:"start" json:"start"`
 	End                int                  `bson:"end" json:"end"`
 	Priority           bool                 `bson:"priority,omitempty" json:"priority,omitempty"`
+	PriorityUnits      int                  `bson:"priorityUnits,omitempty" json:"priorityUnits,omitempty"` // units drawn from the urgency priority pool
+	Quote              *PriceQuote          `bson:"quote,omitempty" json:"quote,omitempty"`
+	Discounts          []PriceAdjustment    `bson:"discounts,omitempty" json:"discounts,omitempty"`
+	CountryCode        string               `bson:"countryCode,omitempty" json:"countryCode,omitempty"`
 	CustomOption       CustomOptionResponse `bson:"customOption,omitempty" json:"customOption,omitzero"`
 	Invoice            Invoice              `bson:"invoice,omitempty" json:"invoice,omitzero"`
 	UserPayment        UserPayment          `bson:"userPayment" json:"userPayment,omitzero"`
 	Mode               string               `bson:"mode" json:"mode"`
 	UserMinimal        UserMinimal          `bson:"userMinimal,omitempty" json:"userMinimal,omitzero"`
 	MinimalProviderDTO MinimalProviderDTO   `bson:"minimalProviderDTO,omitempty" json:"minimalProviderDTO,omitzero"`
+	Beneficiary        Beneficiary          `bson:"beneficiary,omitempty" json:"beneficiary,omitzero"`         // who the service is for; the payer is UserID
+	ServiceLocation    GeoPoint             `bson:"serviceLocation,omitempty" json:"serviceLocation,omitzero"` // where the service is performed
+	ServiceAddress     ServiceAddress       `bson:"serviceAddress,omitempty" json:"serviceAddress,omitzero"`
+	Adjustments        []BookingAdjustment  `bson:"adjustments,omitempty" json:"adjustments,omitempty"` // extra charges and tips added after confirmation
+	PaymentPlan        *PaymentPlan         `bson:"paymentPlan,omitempty" json:"paymentPlan,omitempty"` // set when only a deposit was taken at booking
+	CheckIns           []BookingCheckIn     `bson:"checkIns,omitempty" json:"checkIns,omitempty"`
+	NoShow             *NoShowRecord        `bson:"noShow,omitempty" json:"noShow,omitempty"` // set when the provider or the customer did not turn up
+	CallRelay          *CallRelay           `bson:"callRelay,omitempty" json:"-"`
+	ContactSharing     ContactSharing       `bson:"contactSharing,omitempty" json:"contactSharing,omitzero"`
 }
 
 type SubscriptionDetails struct {
@@ -51,6 +66,13 @@ type BookingRequest struct {
 	CustomOption        CustomOptionResponse `json:"customOption,omitzero"`
 	UserPayment         UserPayment          `json:"userPayment"`
 	Mode                string               `json:"mode"`
+	CatalogueID         string               `json:"catalogueId,omitempty"`
+	BookingFor          string               `json:"bookingFor,omitempty"`     // "self" or a household member ID
+	ServiceLocation     GeoPoint             `json:"serviceLocation,omitzero"` // the plan location used for matching
+	AddressID           string               `json:"addressId,omitempty"`
+	QuoteID             string               `json:"quoteId,omitempty"`
+	PromoCode           string               `json:"promoCode,omitempty"`
+	CountryCode         string               `json:"countryCode,omitempty"`
 }
 
 type SubscriptionModel struct {
@@ -60,9 +82,10 @@ type SubscriptionModel struct {
 
 // BookingConfirmation represents the result of a successful booking validation.
 type BookingConfirmation struct {
-	BookingID  string  `bson:"bookingId" json:"bookingId"`
-	TotalPrice float64 `bson:"totalPrice" json:"totalPrice"`
-	Message    string  `bson:"message,omitempty" json:"message,omitempty"`
+	BookingID  string      `bson:"bookingId" json:"bookingId"`
+	TotalPrice float64     `bson:"totalPrice" json:"totalPrice"`
+	Message    string      `bson:"message,omitempty" json:"message,omitempty"`
+	Quote      *PriceQuote `bson:"quote,omitempty" json:"quote,omitempty"`
 }
 
 type SubscriptionBooking struct {
@@ -72,16 +95,19 @@ type SubscriptionBooking struct {
 }
 
 type PublicBookingData struct {
-	ID           string        `json:"id"`
-	Date         string        `json:"date"`
-	Start        int           `json:"start"`
-	End          int           `json:"end"`
-	ServiceType  string        `json:"serviceType"`
-	Units        int           `json:"units"`
-	UnitType     string        `json:"unitType"`
-	CustomOption string        `json:"customOption"`
-	TotalPrice   float64       `json:"totalPrice"`
-	Invoice      PublicInvoice `json:"invoice"`
+	ID           string              `json:"id"`
+	Date         string              `json:"date"`
+	Start        int                 `json:"start"`
+	End          int                 `json:"end"`
+	ServiceType  string              `json:"serviceType"`
+	Units        int                 `json:"units"`
+	UnitType     string              `json:"unitType"`
+	CustomOption string              `json:"customOption"`
+	TotalPrice   float64             `json:"totalPrice"`
+	Invoice      PublicInvoice       `json:"invoice"`
+	Adjustments  []BookingAdjustment `json:"adjustments,omitempty"`
+	PaymentPlan  *PaymentPlan        `json:"paymentPlan,omitempty"`
+	NoShow       *NoShowRecord       `json:"noShow,omitempty"`
 }
 
 func ToPublicBookingData(b Booking) PublicBookingData {
@@ -96,5 +122,8 @@ func ToPublicBookingData(b Booking) PublicBookingData {
 		CustomOption: b.CustomOption.Option,
 		TotalPrice:   b.TotalPrice,
 		Invoice:      ToPublicInvoice(b.Invoice),
+		Adjustments:  b.Adjustments,
+		PaymentPlan:  b.PaymentPlan,
+		NoShow:       b.NoShow,
 	}
 }
@@ -0,0 +1,145 @@
+package models
+
+import "time"
+
+// Chat sender roles.
+const (
+	ChatRoleUser     = "user"
+	ChatRoleProvider = "provider"
+)
+
+// Chat event types sent on a thread's real-time stream.
+const (
+	ChatEventMessage = "message"
+	ChatEventRead    = "read"
+)
+
+// ChatThread is the conversation of one booking between its user and
+// provider. It is created with the first message.
+type ChatThread struct {
+	BookingID     string         `bson:"bookingId" json:"bookingId"`
+	UserID        string         `bson:"userId" json:"userId"`
+	ProviderID    string         `bson:"providerId" json:"providerId"`
+	LastMessage   string         `bson:"lastMessage,omitempty" json:"lastMessage,omitempty"` // preview of the newest message
+	LastMessageAt time.Time      `bson:"lastMessageAt" json:"lastMessageAt"`
+	Unread        ChatUnread     `bson:"unread" json:"unread"`
+	ReadAt        ChatReadAt     `bson:"readAt" json:"readAt"`
+	Flagged       bool           `bson:"flagged" json:"flagged"` // a message awaits moderation
+	CreatedAt     time.Time      `bson:"createdAt" json:"createdAt"`
+	ClosesAt      time.Time      `bson:"-" json:"closesAt,omitzero"` // when the booking stops accepting messages
+	Open          bool           `bson:"-" json:"open"`
+	Booking       *BookingSketch `bson:"-" json:"booking,omitempty"`
+}
+
+// ChatUnread counts the messages each side has not read yet.
+type ChatUnread struct {
+	User     int64 `bson:"user" json:"user"`
+	Provider int64 `bson:"provider" json:"provider"`
+}
+
+// ChatReadAt is when each side last read the thread; messages sent before it
+// count as read by that side.
+type ChatReadAt struct {
+	User     time.Time `bson:"user,omitempty" json:"user,omitzero"`
+	Provider time.Time `bson:"provider,omitempty" json:"provider,omitzero"`
+}
+
+// BookingSketch is the part of a booking shown next to its chat.
+type BookingSketch struct {
+	Date         string `json:"date"`
+	Start        int    `json:"start"`
+	End          int    `json:"end"`
+	ServiceType  string `json:"serviceType"`
+	Status       string `json:"status"`
+	UserName     string `json:"userName,omitempty"`
+	ProviderName string `json:"providerName,omitempty"`
+}
+
+// ChatMessage is one message of a booking chat. Hidden messages were removed
+// by a moderator; participants only see that they existed.
+type ChatMessage struct {
+	ID          string           `bson:"id" json:"id"`
+	BookingID   string           `bson:"bookingId" json:"bookingId"`
+	SenderID    string           `bson:"senderId" json:"senderId"`
+	SenderRole  string           `bson:"senderRole" json:"senderRole"` // "user" or "provider"
+	Text        string           `bson:"text,omitempty" json:"text,omitempty"`
+	Attachments []ChatAttachment `bson:"attachments,omitempty" json:"attachments,omitempty"`
+	CreatedAt   time.Time        `bson:"createdAt" json:"createdAt"`
+	ReadAt      time.Time        `bson:"readAt,omitempty" json:"readAt,omitzero"` // when the other side read it
+	Hidden      bool             `bson:"hidden,omitempty" json:"hidden,omitempty"`
+	Flagged     bool             `bson:"flagged,omitempty" json:"flagged,omitempty"`
+	Reports     []ChatReport     `bson:"reports,omitempty" json:"reports,omitempty"`
+	Moderation  *ChatModeration  `bson:"moderation,omitempty" json:"moderation,omitempty"`
+}
+
+// ChatAttachment is an image sent in a chat. ObjectPath is the stored file;
+// URL is a short-lived signed link filled in when messages are read.
+type ChatAttachment struct {
+	ObjectPath  string `bson:"objectPath" json:"-"`
+	URL         string `bson:"-" json:"url,omitempty"`
+	ContentType string `bson:"contentType" json:"contentType"`
+	Size        int64  `bson:"size" json:"size"`
+}
+
+// ChatReport is a participant reporting a message to the moderators.
+type ChatReport struct {
+	ReporterRole string    `bson:"reporterRole" json:"reporterRole"`
+	Reason       string    `bson:"reason,omitempty" json:"reason,omitempty"`
+	At           time.Time `bson:"at" json:"at"`
+}
+
+// ChatModeration records a moderator's decision on a message.
+type ChatModeration struct {
+	By     string    `bson:"by" json:"by"`
+	Hidden bool      `bson:"hidden" json:"hidden"`
+	Reason string    `bson:"reason,omitempty" json:"reason,omitempty"`
+	At     time.Time `bson:"at" json:"at"`
+}
+
+// ChatEvent is sent on a thread's real-time stream: a new message, or the
+// other side reading the thread up to ReadAt.
+type ChatEvent struct {
+	Type    string       `json:"type"`
+	Message *ChatMessage `json:"message,omitempty"`
+	Reader  string       `json:"reader,omitempty"` // role that read the thread
+	ReadAt  time.Time    `json:"readAt,omitzero"`
+}
+
+// ChatConversation is a thread with a page of its messages, newest first.
+type ChatConversation struct {
+	Thread   ChatThread    `json:"thread"`
+	Messages []ChatMessage `json:"messages"`
+}
+
+// ChatThreadPage is one page of chat threads, most recently active first.
+type ChatThreadPage struct {
+	Threads []ChatThread `json:"threads"`
+	Total   int64        `json:"total"`
+	Page    int64        `json:"page"`
+	Limit   int64        `json:"limit"`
+}
+
+// ChatThreadFilter selects chat threads: a participant's threads, or the
+// flagged ones for moderation.
+type ChatThreadFilter struct {
+	UserID      string
+	ProviderID  string
+	FlaggedOnly bool
+}
+
+// ChatMessageRequest is the text of a message, sent as JSON or as a multipart
+// form with an "image" file.
+type ChatMessageRequest struct {
+	Text string `json:"text" form:"text"`
+}
+
+// ChatReportRequest is sent by a participant reporting a message.
+type ChatReportRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// ChatModerationRequest is an admin hiding or restoring a message.
+type ChatModerationRequest struct {
+	Hidden bool   `json:"hidden"`
+	Reason string `json:"reason" binding:"max=500"`
+}
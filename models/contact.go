@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// CallRelay is the masked-call session of a booking. Each side dials its proxy
+// number and is connected to the other without seeing their personal number.
+type CallRelay struct {
+	SessionID     string    `bson:"sessionId" json:"-"`
+	UserProxy     string    `bson:"userProxy" json:"-"`     // the user dials this to reach the provider
+	ProviderProxy string    `bson:"providerProxy" json:"-"` // the provider dials this to reach the user
+	ExpiresAt     time.Time `bson:"expiresAt" json:"-"`
+}
+
+// ContactSharing records which sides of a booking agreed to show their own
+// phone number. Numbers are only shown once both have.
+type ContactSharing struct {
+	User     bool `bson:"user" json:"user"`
+	Provider bool `bson:"provider" json:"provider"`
+}
+
+// BookingContact is how one side of a booking can call the other.
+type BookingContact struct {
+	RelayNumber    string    `json:"relayNumber,omitempty"` // connects to the other side; both numbers stay hidden
+	RelayExpiresAt time.Time `json:"relayExpiresAt,omitzero"`
+	DirectNumber   string    `json:"directNumber,omitempty"` // only when both sides share their number
+	SharingMine    bool      `json:"sharingMine"`
+	SharingTheirs  bool      `json:"sharingTheirs"`
+	Open           bool      `json:"open"` // false once the booking's contact window has closed
+}
+
+// ContactSharingRequest opts in or out of sharing the caller's number.
+type ContactSharingRequest struct {
+	Share *bool `json:"share" binding:"required"`
+}
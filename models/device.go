@@ -11,6 +11,25 @@ type Device struct {
 	LastLogin  time.Time `bson:"lastLogin" json:"lastLogin"`
 	Creator    bool      `bson:"creator" json:"creator"`
 	TokenHash  string    `bson:"tokenHash" json:"-"`
+	FCMToken   string    `bson:"fcmToken,omitempty" json:"-"` // push token of the app on this device
+}
+
+// devicePushTokens returns the FCM tokens of devices without duplicates. legacy
+// is the single account-wide token kept before tokens moved to devices; it is
+// only used while no device has registered one.
+func devicePushTokens(devices []Device, legacy string) []string {
+	var tokens []string
+	seen := map[string]bool{}
+	for _, d := range devices {
+		if d.FCMToken != "" && !seen[d.FCMToken] {
+			seen[d.FCMToken] = true
+			tokens = append(tokens, d.FCMToken)
+		}
+	}
+	if len(tokens) == 0 && legacy != "" {
+		tokens = append(tokens, legacy)
+	}
+	return tokens
 }
 
 // DeviceOTP holds temporary OTP data for device verification.
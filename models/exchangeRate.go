@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// RateTable holds exchange rates from one base currency, as returned by a rate provider.
+type RateTable struct {
+	Base      string             `bson:"base" json:"base"`
+	Rates     map[string]float64 `bson:"rates" json:"rates"` // units of the quote currency per one unit of Base
+	Provider  string             `bson:"provider" json:"provider"`
+	FetchedAt time.Time          `bson:"fetchedAt" json:"fetchedAt"`
+}
+
+// RateSnapshot records the exchange rate a converted price was computed with.
+type RateSnapshot struct {
+	From      string    `bson:"from" json:"from"`
+	To        string    `bson:"to" json:"to"`
+	Rate      float64   `bson:"rate" json:"rate"`
+	Provider  string    `bson:"provider,omitempty" json:"provider,omitempty"`
+	FetchedAt time.Time `bson:"fetchedAt,omitzero" json:"fetchedAt,omitzero"`
+	Stale     bool      `bson:"stale,omitempty" json:"stale,omitempty"` // last-known rate used because a refresh failed
+}
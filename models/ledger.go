@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Ledger entry kinds.
+const (
+	LedgerService    = "service"
+	LedgerAdjustment = "adjustment"
+	LedgerTip        = "tip"
+	LedgerRefund     = "refund" // negative amounts, reversing a refunded service entry
+)
+
+// LedgerEntry records how one payment splits between the provider and the
+// platform. ProviderNet is Gross less Commission and Tax.
+type LedgerEntry struct {
+	ID           string    `bson:"id" json:"id"`
+	ProviderID   string    `bson:"providerId" json:"providerId"`
+	BookingID    string    `bson:"bookingId" json:"bookingId"`
+	AdjustmentID string    `bson:"adjustmentId,omitempty" json:"adjustmentId,omitempty"`
+	Kind         string    `bson:"kind" json:"kind"`
+	Gross        float64   `bson:"gross" json:"gross"`
+	Commission   float64   `bson:"commission" json:"commission"`
+	Tax          float64   `bson:"tax" json:"tax"`
+	ProviderNet  float64   `bson:"providerNet" json:"providerNet"`
+	Currency     string    `bson:"currency" json:"currency"`
+	CreatedAt    time.Time `bson:"createdAt" json:"createdAt"`
+}
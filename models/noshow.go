@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// Check-in kinds, recorded by the provider as a booking happens.
+const (
+	CheckInOnTheWay       = "on_the_way"      // the provider set off; in-home bookings share their live location from here
+	CheckInArrived        = "arrived"         // the provider is at the service location
+	CheckInStarted        = "started"         // the service has begun
+	CheckInCompleted      = "completed"       // the service is done
+	CheckInCustomerAbsent = "customer_absent" // the provider arrived but the customer is not there
+)
+
+// No-show parties.
+const (
+	NoShowByProvider = "provider"
+	NoShowByUser     = "user"
+)
+
+// No-show resolutions. Provider no-shows start pending until the user chooses a
+// refund or a rebooking, or the refund is issued automatically.
+const (
+	NoShowPending    = "pending"
+	NoShowProcessing = "processing" // refund in progress
+	NoShowRefunded   = "refunded"
+	NoShowRebooked   = "rebooked" // refunded, and the user was offered other providers
+	NoShowFailed     = "failed"   // the refund failed; the user may try again
+	NoShowCharged    = "charged"  // customer no-show; the booking stays paid
+)
+
+// BookingCheckIn is one check-in event of a booking.
+type BookingCheckIn struct {
+	Kind     string    `bson:"kind" json:"kind"`
+	By       string    `bson:"by" json:"by"` // provider ID
+	Location *GeoPoint `bson:"location,omitempty" json:"location,omitempty"`
+	At       time.Time `bson:"at" json:"at"`
+}
+
+// NoShowRecord is set on a booking one side did not turn up for.
+type NoShowRecord struct {
+	Party          string    `bson:"party" json:"party"` // "provider" or "user"
+	DetectedAt     time.Time `bson:"detectedAt" json:"detectedAt"`
+	Resolution     string    `bson:"resolution" json:"resolution"`
+	RefundedAmount float64   `bson:"refundedAmount,omitempty" json:"refundedAmount,omitempty"`
+	Error          string    `bson:"error,omitempty" json:"error,omitempty"`
+	ResolvedAt     time.Time `bson:"resolvedAt,omitempty" json:"resolvedAt,omitzero"`
+}
+
+// Reliability counts the bookings an account did not turn up for. Matching
+// ranks providers with no-shows lower.
+type Reliability struct {
+	NoShows      int       `bson:"noShows" json:"noShows"`
+	LastNoShowAt time.Time `bson:"lastNoShowAt,omitempty" json:"lastNoShowAt,omitzero"`
+}
+
+// CheckInRequest is sent by a provider at the booking.
+type CheckInRequest struct {
+	Kind     string    `json:"kind" binding:"required,oneof=on_the_way arrived started completed customer_absent"`
+	Location *GeoPoint `json:"location,omitempty"`
+}
+
+// NoShowDecision is the user's choice after a provider no-show.
+type NoShowDecision struct {
+	Action string `json:"action" binding:"required,oneof=refund rebook"`
+}
+
+// NoShowResult is the outcome of a no-show decision. Alternatives are other
+// providers for the same service when the user chose to rebook.
+type NoShowResult struct {
+	NoShow       *NoShowRecord `json:"noShow"`
+	Alternatives []ProviderDTO `json:"alternatives,omitempty"`
+}
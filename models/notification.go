@@ -2,16 +2,70 @@ package models
 
 import "time"
 
+// Notification is an entry of a user's or provider's inbox. It expires
+// ExpiresAt, which a TTL index enforces.
 type Notification struct {
-	ID        string         `json:"id"`
-	UserID    string         `json:"userId"`
-	Type      string         `json:"type"`
-	Title     string         `json:"title"`
-	Body      string         `json:"body"`
-	Data      map[string]any `json:"data"`
-	Sent      bool           `json:"sent"`
-	CreatedAt time.Time      `json:"createdAt"`
-	UpdatedAt time.Time      `json:"updatedAt"`
-	Read      bool           `json:"read"`
-	Message   string         `json:"message"`
+	ID          string         `bson:"id" json:"id"`
+	RecipientID string         `bson:"recipientId" json:"-"`
+	Role        string         `bson:"role" json:"role,omitempty"` // "user" or "provider"
+	UserID      string         `bson:"userId,omitempty" json:"userId"`
+	Type        string         `bson:"type" json:"type"`
+	Title       string         `bson:"title" json:"title"`
+	Body        string         `bson:"body,omitempty" json:"body"`
+	Data        map[string]any `bson:"data,omitempty" json:"data"`
+	Sent        bool           `bson:"sent" json:"sent"`
+	CreatedAt   time.Time      `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time      `bson:"updatedAt" json:"updatedAt"`
+	Read        bool           `bson:"read" json:"read"`
+	Message     string         `bson:"message" json:"message"`
+	ExpiresAt   time.Time      `bson:"expiresAt" json:"-"`
+}
+
+// NotificationPage is one page of an inbox.
+type NotificationPage struct {
+	Notifications []Notification `json:"notifications"`
+	Total         int64          `json:"total"`
+	Unread        int64          `json:"unread"`
+	Page          int64          `json:"page"`
+	Limit         int64          `json:"limit"`
+}
+
+// NotificationPreferences holds the notification types a recipient muted.
+// Muted types still reach the inbox but are not pushed or messaged.
+type NotificationPreferences struct {
+	RecipientID string    `bson:"recipientId" json:"-"`
+	Role        string    `bson:"role" json:"role"`
+	MutedTypes  []string  `bson:"mutedTypes" json:"mutedTypes"`
+	UpdatedAt   time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Notification channels.
+const (
+	ChannelPush     = "push"
+	ChannelSMS      = "sms"
+	ChannelWhatsApp = "whatsapp"
+	ChannelEmail    = "email"
+)
+
+// Delivery statuses.
+const (
+	DeliverySent    = "sent"
+	DeliveryFailed  = "failed"
+	DeliverySkipped = "skipped" // channel not configured or recipient unreachable on it
+)
+
+// NotificationDelivery is the outcome of sending one notification on one
+// channel. Deliveries of the same notification share a DispatchID.
+type NotificationDelivery struct {
+	ID          string    `bson:"id" json:"id"`
+	DispatchID  string    `bson:"dispatchId" json:"dispatchId"`
+	RecipientID string    `bson:"recipientId" json:"recipientId"`
+	Role        string    `bson:"role" json:"role"` // "user" or "provider"
+	Type        string    `bson:"type" json:"type"`
+	Channel     string    `bson:"channel" json:"channel"`
+	Status      string    `bson:"status" json:"status"`
+	Fallback    bool      `bson:"fallback,omitempty" json:"fallback,omitempty"` // sent instead of a push the recipient could not receive
+	ExternalID  string    `bson:"externalId,omitempty" json:"externalId,omitempty"`
+	Error       string    `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedAt   time.Time `bson:"createdAt" json:"createdAt"`
 }
@@ -11,6 +11,13 @@ type PaymentRequest struct {
 	Metadata        map[string]string
 	PaymentIntentID string
 	Action          string
+
+	// Saved card for off-session "charge" actions.
+	CustomerID      string
+	PaymentMethodID string
+	// IdempotencyKey makes a repeated "charge" return the first one instead of
+	// charging again.
+	IdempotencyKey string
 }
 
 type Invoice struct {
@@ -25,16 +32,57 @@ type Invoice struct {
 	Retries   int
 	PaymentID string
 	Error     string
+	Discounts []PriceAdjustment
+	Lines     []InvoiceLine
+	Refunds   []Refund
+
+	// Card saved on a captured PaymentIntent, for charging a later balance.
+	CustomerID      string
+	PaymentMethodID string
 }
 
-type PublicInvoice struct {
-	InvoiceID string    `json:"invoiceId"`
+// Refund is money returned against an invoice.
+type Refund struct {
+	RefundID  string    `json:"refundId"`
 	Amount    float64   `json:"amount"`
-	Currency  string    `json:"currency"`
-	Status    string    `json:"status"`
-	Method    string    `json:"method"`
+	Reason    string    `json:"reason,omitempty"`
+	Status    string    `json:"status"` // "pending", "succeeded" or "failed"
 	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Invoice line kinds.
+const (
+	InvoiceLineService     = "service"
+	InvoiceLineDiscount    = "discount"
+	InvoiceLinePlatformFee = "platform_fee"
+	InvoiceLineTax         = "tax"
+	InvoiceLineRounding    = "rounding"
+	InvoiceLineAdjustment  = "adjustment"
+	InvoiceLineTip         = "tip"
+)
+
+// InvoiceLine is one item of an invoice breakdown. Lines that are not Included
+// add up to the invoice Amount.
+type InvoiceLine struct {
+	Kind        string  `json:"kind"`
+	Description string  `json:"description"`
+	Rate        float64 `json:"rate,omitempty"`
+	Amount      float64 `json:"amount"`
+	Included    bool    `json:"included,omitempty"`  // already part of another line, e.g. VAT inside a tax-inclusive price
+	BookingID   string  `json:"bookingId,omitempty"` // set on combined basket invoices
+}
+
+type PublicInvoice struct {
+	InvoiceID string            `json:"invoiceId"`
+	Amount    float64           `json:"amount"`
+	Currency  string            `json:"currency"`
+	Status    string            `json:"status"`
+	Method    string            `json:"method"`
+	Discounts []PriceAdjustment `json:"discounts,omitempty"`
+	Lines     []InvoiceLine     `json:"lines,omitempty"`
+	Refunds   []Refund          `json:"refunds,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
 }
 
 func ToPublicInvoice(inv Invoice) PublicInvoice {
@@ -44,6 +92,9 @@ func ToPublicInvoice(inv Invoice) PublicInvoice {
 		Currency:  inv.Currency,
 		Status:    inv.Status,
 		Method:    inv.Method,
+		Discounts: inv.Discounts,
+		Lines:     inv.Lines,
+		Refunds:   inv.Refunds,
 		CreatedAt: inv.CreatedAt,
 		UpdatedAt: inv.UpdatedAt,
 	}
@@ -52,6 +103,9 @@ func ToPublicInvoice(inv Invoice) PublicInvoice {
 type PaymentIntentRequest struct {
 	Amount   float64 `json:"amount" binding:"required"`   // e.g., 10.00
 	Currency string  `json:"currency" binding:"required"` // e.g., "usd"
+	// SavePaymentMethod keeps the card for off-session use, required to pay a
+	// deposit now and the balance later.
+	SavePaymentMethod bool `json:"savePaymentMethod,omitempty"`
 }
 
 type UserPayment struct {
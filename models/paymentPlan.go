@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Balance statuses of a deposit payment plan.
+const (
+	BalanceScheduled  = "scheduled"  // waiting for the due time
+	BalanceRetrying   = "retrying"   // a charge failed and will be retried
+	BalanceProcessing = "processing" // a charge is in flight
+	BalancePaid       = "paid"
+	BalanceFailed     = "failed" // every attempt failed; the booking needs payment
+)
+
+// PaymentPlan splits a card booking into a deposit captured at booking and a
+// balance charged off-session to the saved card shortly before the service.
+// Amounts are in Currency, the booking's charge currency.
+type PaymentPlan struct {
+	DepositPercent   float64   `bson:"depositPercent" json:"depositPercent"`
+	DepositAmount    float64   `bson:"depositAmount" json:"depositAmount"`
+	BalanceAmount    float64   `bson:"balanceAmount" json:"balanceAmount"`
+	Currency         string    `bson:"currency" json:"currency"`
+	BalanceDueAt     time.Time `bson:"balanceDueAt" json:"balanceDueAt"`
+	BalanceStatus    string    `bson:"balanceStatus" json:"balanceStatus"`
+	BalancePaymentID string    `bson:"balancePaymentId,omitempty" json:"balancePaymentId,omitempty"`
+	Attempts         int       `bson:"attempts" json:"attempts"`
+	ClaimedAt        time.Time `bson:"claimedAt,omitempty" json:"-"` // when the charge in flight started
+	LastError        string    `bson:"lastError,omitempty" json:"lastError,omitempty"`
+	PaidAt           time.Time `bson:"paidAt,omitempty" json:"paidAt,omitzero"`
+
+	// Saved card the balance is charged to; never sent to clients.
+	CustomerID      string `bson:"customerId" json:"-"`
+	PaymentMethodID string `bson:"paymentMethodId" json:"-"`
+}
@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// Promo code kinds.
+const (
+	PromoPercentage = "percentage" // Value is a fraction of the booking price, e.g. 0.15
+	PromoFixed      = "fixed"      // Value is an amount in Currency
+	PromoReferral   = "referral"   // a user's personal referral code
+)
+
+// Discount sources recorded on redemptions and price adjustments.
+const (
+	DiscountPromo        = "promo"
+	DiscountReferral     = "referral"
+	DiscountFirstBooking = "first_booking"
+	DiscountCredit       = "credit"
+)
+
+// PromoCode is a marketing or referral code redeemable at checkout.
+type PromoCode struct {
+	Code        string    `bson:"code" json:"code" binding:"required"` // stored upper-case
+	Description string    `bson:"description,omitempty" json:"description,omitempty"`
+	Kind        string    `bson:"kind" json:"kind" binding:"required"`
+	Value       float64   `bson:"value" json:"value"`
+	MaxDiscount float64   `bson:"maxDiscount,omitempty" json:"maxDiscount,omitempty"` // cap for percentage codes; 0 means uncapped
+	Currency    string    `bson:"currency,omitempty" json:"currency,omitempty"`       // required for fixed codes
+	ServiceIDs  []string  `bson:"serviceIds,omitempty" json:"serviceIds,omitempty"`   // empty applies to every service
+	Regions     []string  `bson:"regions,omitempty" json:"regions,omitempty"`         // ISO country codes; empty applies everywhere
+	UsageLimit  int       `bson:"usageLimit,omitempty" json:"usageLimit,omitempty"`   // total redemptions; 0 means unlimited
+	Redemptions int       `bson:"redemptions" json:"redemptions"`
+	OwnerID     string    `bson:"ownerId,omitempty" json:"ownerId,omitempty"` // referral codes only
+	StartsAt    time.Time `bson:"startsAt,omitempty" json:"startsAt,omitzero"`
+	ExpiresAt   time.Time `bson:"expiresAt,omitempty" json:"expiresAt,omitzero"`
+	Active      bool      `bson:"active" json:"active"`
+	CreatedBy   string    `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	CreatedAt   time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// DiscountRedemption records a single use of a discount. Its ID is derived from
+// the source, code and user, so a discount can only be redeemed once per user.
+type DiscountRedemption struct {
+	ID         string    `bson:"id" json:"id"`
+	Source     string    `bson:"source" json:"source"`
+	Code       string    `bson:"code,omitempty" json:"code,omitempty"`
+	UserID     string    `bson:"userId" json:"userId"`
+	BookingID  string    `bson:"bookingId" json:"bookingId"`
+	Amount     float64   `bson:"amount" json:"amount"`
+	Currency   string    `bson:"currency" json:"currency"`
+	RedeemedAt time.Time `bson:"redeemedAt" json:"redeemedAt"`
+}
+
+// ReferralCredit is an amount owed to a user for a successful referral, spent
+// automatically on one of their later bookings.
+type ReferralCredit struct {
+	ID         string    `bson:"id" json:"id"`
+	UserID     string    `bson:"userId" json:"userId"`
+	Amount     float64   `bson:"amount" json:"amount"`
+	Currency   string    `bson:"currency" json:"currency"`
+	ReferredID string    `bson:"referredId" json:"referredId"` // the user whose first booking earned the credit
+	BookingID  string    `bson:"bookingId,omitempty" json:"bookingId,omitempty"`
+	CreatedAt  time.Time `bson:"createdAt" json:"createdAt"`
+	RedeemedAt time.Time `bson:"redeemedAt,omitempty" json:"redeemedAt,omitzero"`
+}
+
+// ReferralSummary is what a user sees about their referral code and credits.
+type ReferralSummary struct {
+	Code    string           `json:"code"`
+	Credits []ReferralCredit `json:"credits"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
@@ -32,7 +33,7 @@ type AdvancedVerification struct {
 type Security struct {
 	Password     string `bson:"-" json:"password,omitempty"`
 	PasswordHash string `bson:"passwordHash" json:"-"`
-	FCMToken     string `bson:"fcmToken" json:"fcmToken"`
+	FCMToken     string `bson:"fcmToken" json:"fcmToken"` // Deprecated: tokens are kept per device; see Provider.PushTokens
 }
 
 type BasicVerification struct {
@@ -54,6 +55,15 @@ type PaymentDetails struct {
 	// Optional cash-only metadata
 	AcceptsCash bool `bson:"acceptsCash" json:"acceptsCash"`
 
+	// TaxInclusive means catalogue prices already include tax; otherwise tax is added on top.
+	TaxInclusive bool `bson:"taxInclusive" json:"taxInclusive"`
+
+	// DepositPercent, when set, takes only this share of a card booking at checkout
+	// and charges the balance to the saved card BalanceDueHours before the service.
+	// Bookings starting sooner than that, and basket checkouts, are paid in full.
+	DepositPercent  float64 `bson:"depositPercent,omitempty" json:"depositPercent,omitempty"`
+	BalanceDueHours int     `bson:"balanceDueHours,omitempty" json:"balanceDueHours,omitempty"`
+
 	// Timestamps
 	LastUpdated time.Time `bson:"lastUpdated" json:"lastUpdated"`
 }
@@ -62,7 +72,8 @@ type Provider struct {
 	ID                   string                `bson:"id" json:"id,omitempty"`
 	Profile              Profile               `bson:"profile" json:"profile"`
 	Security             Security              `bson:"security" json:"security,omitzero"`
-	ServiceCatalogue     ServiceCatalogue      `bson:"serviceCatalogue" json:"serviceCatalogue,omitzero"`
+	ServiceCatalogue     ServiceCatalogue      `bson:"serviceCatalogue" json:"serviceCatalogue,omitzero"` // primary entry, kept in sync with ServiceCatalogues[0]
+	ServiceCatalogues    []ServiceCatalogue    `bson:"serviceCatalogues,omitempty" json:"serviceCatalogues,omitempty"`
 	VerificationLevel    string                `bson:"verificationLevel" json:"verificationLevel,omitempty"`
 	BasicVerification    BasicVerification     `bson:"verification" json:"verification,omitzero"`
 	AdvancedVerification AdvancedVerification  `bson:"advancedVerification" json:"advancedVerification,omitzero"`
@@ -70,6 +81,7 @@ type Provider struct {
 	TimeSlotRefs         []MinimalSlotDTO      `bson:"timeSlotRefs,omitempty" json:"timeSlotRefs,omitempty"`
 	PaymentDetails       PaymentDetails        `bson:"paymentDetails" json:"paymentDetails,omitzero"`
 	CompletedBookings    int                   `bson:"completedBookings" json:"completedBookings,omitempty"`
+	Reliability          Reliability           `bson:"reliability,omitempty" json:"reliability,omitzero"`
 	CreatedAt            time.Time             `bson:"createdAt" json:"createdAt,omitzero"`
 	UpdatedAt            time.Time             `bson:"updatedAt" json:"updatedAt,omitzero"`
 	Devices              []Device              `bson:"devices,omitempty" json:"devices,omitempty"`
@@ -77,8 +89,7 @@ type Provider struct {
 	SubscriptionModel    SubscriptionModel     `bson:"subscriptionModel" json:"subscriptionModel"`
 	SubscriptionBooking  []SubscriptionBooking `bson:"subscriptionBooking,omitempty" json:"subscriptionBooking,omitempty"`
 	ActiveBookings       []ActiveBookingDTO    `bson:"activeBookings,omitempty" json:"activeBookings,omitempty"`
-	Notifications        []Notification        `bson:"notifications,omitempty" json:"notifications,omitempty"`
-	Reminders            []Reminder            `bson:"reminders,omitempty" json:"reminders,omitempty"`
+	PreferredLanguage    string                `bson:"preferredLanguage,omitempty" json:"preferredLanguage,omitempty"` // notification locale, e.g. "en" or "sw"
 }
 
 type ActiveBookingDTO struct {
@@ -87,4 +98,49 @@ type ActiveBookingDTO struct {
 	End       int         `bson:"end" json:"end"`
 	User      UserMinimal `bson:"user" json:"user"`
 	Mode      string      `bson:"mode" json:"mode"`
+	// Beneficiary is who the service is for; for in-home jobs its location is
+	// where the provider goes, which may differ from the payer's.
+	Beneficiary    Beneficiary    `bson:"beneficiary,omitempty" json:"beneficiary,omitzero"`
+	ServiceAddress ServiceAddress `bson:"serviceAddress,omitempty" json:"serviceAddress,omitzero"` // in-home only
+}
+
+// Catalogues returns every catalogue entry offered by the provider.
+// Providers registered before multi-entry catalogues only carry ServiceCatalogue.
+func (p Provider) Catalogues() []ServiceCatalogue {
+	if len(p.ServiceCatalogues) > 0 {
+		return p.ServiceCatalogues
+	}
+	if p.ServiceCatalogue.Service.ID == "" {
+		return nil
+	}
+	return []ServiceCatalogue{p.ServiceCatalogue}
+}
+
+// PushTokens returns the FCM tokens of the provider's devices.
+func (p Provider) PushTokens() []string {
+	return devicePushTokens(p.Devices, p.Security.FCMToken)
+}
+
+// ResolveCatalogue picks the catalogue entry for a booking. An explicit catalogueID wins;
+// otherwise the first entry matching serviceType and mode (either may be empty) is returned.
+func (p Provider) ResolveCatalogue(catalogueID, serviceType, mode string) (ServiceCatalogue, bool) {
+	entries := p.Catalogues()
+	if catalogueID != "" {
+		for _, entry := range entries {
+			if entry.ID == catalogueID {
+				return entry, true
+			}
+		}
+		return ServiceCatalogue{}, false
+	}
+	for _, entry := range entries {
+		if serviceType != "" && !strings.EqualFold(entry.Service.ID, serviceType) {
+			continue
+		}
+		if mode != "" && entry.Mode != mode {
+			continue
+		}
+		return entry, true
+	}
+	return ServiceCatalogue{}, false
 }
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Event types on a provider's real-time stream.
+const (
+	ProviderEventNewBooking       = "booking.created"
+	ProviderEventBookingCancelled = "booking.cancelled"
+	ProviderEventPaymentCaptured  = "payment.captured"
+	ProviderEventReviewReceived   = "review.received"
+	ProviderEventSlotFilled       = "slot.filled"
+)
+
+// ProviderEvent is a domain event streamed to a provider's dashboard. ID orders
+// the provider's events; a client that reconnects with the last ID it saw gets
+// the events it missed.
+type ProviderEvent struct {
+	ID        string         `json:"id"`
+	Type      string         `json:"type"`
+	BookingID string         `json:"bookingId,omitempty"`
+	Data      map[string]any `json:"data,omitempty"`
+	At        time.Time      `json:"at"`
+}
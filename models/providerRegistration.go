@@ -30,8 +30,9 @@ type ProviderRegistrationSession struct {
 	BasicData          ProviderBasicRegistrationData `json:"basicData,omitempty"`        // Data from Step 1.
 	KYPData            KYPVerificationData           `json:"kypData,omitempty"`          // Data from Step 2.
 	ServiceCatalogue   ServiceCatalogue              `json:"serviceCatalogue,omitempty"` // Data from Step 3.
-	OTPStatus          string                        `json:"otpStatus"`                  // e.g., "pending", "verified"
-	VerificationStatus string                        `json:"verificationStatus"`         // e.g., "pending", "verified"
+	ServiceCatalogues  []ServiceCatalogue            `json:"serviceCatalogues,omitempty"`
+	OTPStatus          string                        `json:"otpStatus"`          // e.g., "pending", "verified"
+	VerificationStatus string                        `json:"verificationStatus"` // e.g., "pending", "verified"
 	VerificationLevel  string                        ` json:"verificationLevel,omitempty"`
 	CreatedAt          time.Time                     `json:"createdAt"`
 	LastUpdatedAt      time.Time                     `json:"lastUpdatedAt"`
@@ -41,12 +42,13 @@ type ProviderRegistrationSession struct {
 // RegistrationRequest is the composite request payload for multi‑step registration.
 // The client includes the "step" field to indicate which part of the flow is being executed.
 type ProviderRegistrationRequest struct {
-	Step             string                         `json:"step"`                       // "basic", "otp", "kyp", or "catalogue"
-	SessionID        string                         `json:"sessionID,omitempty"`        // Required for steps "otp", "kyp", and "catalogue"
-	OTP              string                         `json:"otp,omitempty"`              // Used only in the OTP verification step.
-	BasicData        *ProviderBasicRegistrationData `json:"basicData,omitempty"`        // For step "basic"
-	KYPData          *KYPVerificationData           `json:"kypData,omitempty"`          // For step "kyp"
-	ServiceCatalogue *ServiceCatalogue              `json:"serviceCatalogue,omitempty"` // For step "catalogue"
+	Step              string                         `json:"step"`                        // "basic", "otp", "kyp", or "catalogue"
+	SessionID         string                         `json:"sessionID,omitempty"`         // Required for steps "otp", "kyp", and "catalogue"
+	OTP               string                         `json:"otp,omitempty"`               // Used only in the OTP verification step.
+	BasicData         *ProviderBasicRegistrationData `json:"basicData,omitempty"`         // For step "basic"
+	KYPData           *KYPVerificationData           `json:"kypData,omitempty"`           // For step "kyp"
+	ServiceCatalogue  *ServiceCatalogue              `json:"serviceCatalogue,omitempty"`  // For step "catalogue"
+	ServiceCatalogues []ServiceCatalogue             `json:"serviceCatalogues,omitempty"` // For step "catalogue", additional entries
 }
 
 type ProviderDTO struct {
@@ -60,9 +62,10 @@ type ProviderDTO struct {
 }
 
 type ProviderAuthResponse struct {
-	ID               string           `json:"id"`
-	Token            string           `json:"token"`
-	Profile          Profile          `json:"profile"`
-	CreatedAt        time.Time        `json:"createdAt"`
-	ServiceCatalogue ServiceCatalogue `json:"serviceCatalogue"`
+	ID                string             `json:"id"`
+	Token             string             `json:"token"`
+	Profile           Profile            `json:"profile"`
+	CreatedAt         time.Time          `json:"createdAt"`
+	ServiceCatalogue  ServiceCatalogue   `json:"serviceCatalogue"`
+	ServiceCatalogues []ServiceCatalogue `json:"serviceCatalogues,omitempty"`
 }
@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// Pricing tiers used in quote lines.
+const (
+	TierStandard  = "standard"
+	TierEarlyBird = "earlybird"
+	TierLate      = "late"
+	TierPriority  = "priority"
+	TierDynamic   = "dynamic"
+)
+
+// PriceQuoteLine is a run of units priced at the same tier.
+type PriceQuoteLine struct {
+	Tier      string  `bson:"tier" json:"tier"`
+	Units     int     `bson:"units" json:"units"`
+	Rate      float64 `bson:"rate" json:"rate"` // adjustment vs the base price, e.g. -0.25 or 0.5
+	UnitPrice float64 `bson:"unitPrice" json:"unitPrice"`
+	Amount    float64 `bson:"amount" json:"amount"`
+}
+
+// PriceAdjustment is a quote-level amount added on top of the priced units:
+// negative for discounts, positive for taxes and fees.
+type PriceAdjustment struct {
+	Code   string  `bson:"code" json:"code"`
+	Label  string  `bson:"label" json:"label"`
+	Amount float64 `bson:"amount" json:"amount"`
+}
+
+// PriceQuote is an itemized price for booking a number of units in a slot.
+// QuoteID is signed by the server, so a quote can be honored at confirmation
+// without re-pricing as long as it has not expired.
+type PriceQuote struct {
+	QuoteID          string            `bson:"quoteId" json:"quoteId"`
+	ProviderID       string            `bson:"providerId" json:"providerId"`
+	SlotID           string            `bson:"slotId" json:"slotId"`
+	CatalogueID      string            `bson:"catalogueId,omitempty" json:"catalogueId,omitempty"`
+	Date             string            `bson:"date" json:"date"`
+	Start            int               `bson:"start" json:"start"`
+	End              int               `bson:"end" json:"end"`
+	SlotModel        string            `bson:"slotModel" json:"slotModel"`
+	RequestedUnits   int               `bson:"requestedUnits" json:"requestedUnits"`
+	StandardUnits    int               `bson:"standardUnits" json:"standardUnits"`
+	PriorityUnits    int               `bson:"priorityUnits,omitempty" json:"priorityUnits,omitempty"`
+	Lines            []PriceQuoteLine  `bson:"lines" json:"lines"`
+	Subtotal         float64           `bson:"subtotal" json:"subtotal"`
+	Option           string            `bson:"option" json:"option"`
+	OptionMultiplier float64           `bson:"optionMultiplier" json:"optionMultiplier"`
+	OptionAmount     float64           `bson:"optionAmount" json:"optionAmount"` // what the option adds to the subtotal
+	Discounts        []PriceAdjustment `bson:"discounts,omitempty" json:"discounts,omitempty"`
+	Taxes            []PriceAdjustment `bson:"taxes,omitempty" json:"taxes,omitempty"`
+	Total            float64           `bson:"total" json:"total"`
+	Currency         string            `bson:"currency" json:"currency"`                           // the provider's pricing currency
+	CountryCode      string            `bson:"countryCode,omitempty" json:"countryCode,omitempty"` // whose fee and tax Taxes holds
+
+	// The payment is taken in ChargeCurrency. When it differs from Currency,
+	// ChargeRate is the conversion locked when the quote was issued.
+	ChargeCurrency string        `bson:"chargeCurrency" json:"chargeCurrency"`
+	ChargeRate     *RateSnapshot `bson:"chargeRate,omitempty" json:"chargeRate,omitempty"`
+	ChargeTotal    float64       `bson:"chargeTotal" json:"chargeTotal"`
+
+	// Total in the user's preferred currency, for display only.
+	DisplayCurrency string        `bson:"displayCurrency,omitempty" json:"displayCurrency,omitempty"`
+	DisplayRate     *RateSnapshot `bson:"displayRate,omitempty" json:"displayRate,omitempty"`
+	DisplayTotal    float64       `bson:"displayTotal,omitempty" json:"displayTotal,omitempty"`
+
+	ExpiresAt time.Time `bson:"expiresAt" json:"expiresAt"`
+}
+
+// Units returns the number of units covered by the quote.
+func (q PriceQuote) Units() int {
+	return q.StandardUnits + q.PriorityUnits
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Receipt formats.
+const (
+	ReceiptFormatHTML = "html"
+	ReceiptFormatPDF  = "pdf"
+)
+
+// ReceiptParty is the provider or customer block of a receipt.
+type ReceiptParty struct {
+	Name    string `json:"name"`
+	Email   string `json:"email,omitempty"`
+	Phone   string `json:"phone,omitempty"`
+	Address string `json:"address,omitempty"`
+	TaxPIN  string `json:"taxPin,omitempty"`
+}
+
+// Receipt is everything rendered on a booking receipt.
+type Receipt struct {
+	Number         string        `json:"number"`
+	IssuedAt       time.Time     `json:"issuedAt"`
+	BookingID      string        `json:"bookingId"`
+	ServiceType    string        `json:"serviceType"`
+	ServiceDate    string        `json:"serviceDate"`
+	ServiceTime    string        `json:"serviceTime"`
+	ServiceAddress string        `json:"serviceAddress,omitempty"`
+	Provider       ReceiptParty  `json:"provider"`
+	Customer       ReceiptParty  `json:"customer"`
+	Lines          []InvoiceLine `json:"lines"`
+	Total          float64       `json:"total"`
+	Currency       string        `json:"currency"`
+	PaymentMethod  string        `json:"paymentMethod"`
+	PaymentStatus  string        `json:"paymentStatus"`
+	PaymentID      string        `json:"paymentId,omitempty"`
+	Refunds        []Refund      `json:"refunds,omitempty"`
+	AmountRefunded float64       `json:"amountRefunded"`
+	NetPaid        float64       `json:"netPaid"`
+}
+
+// ReceiptDocument is a rendered receipt, returned inline or stored.
+type ReceiptDocument struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Data        []byte `json:"-"`
+	URL         string `json:"url,omitempty"` // set when the receipt was stored
+}
@@ -2,16 +2,20 @@ package models
 
 type ServicePlan struct {
 	ServiceType         string              `json:"serviceType"`
-	BookingFor          string              `json:"bookingFor"`
+	BookingFor          string              `json:"bookingFor"` // "self" or the ID of a saved household member
 	Priority            bool                `json:"priority"`
 	Mode                string              `json:"mode"`
 	LocationGeo         GeoPoint            `json:"locationGeo"`
+	AddressID           string              `json:"addressId,omitempty"` // saved address to serve; overrides locationGeo
 	Date                string              `json:"date"`
 	Units               int                 `json:"units"`
 	UnitType            string              `json:"unitType"`
 	Subscription        bool                `json:"subscription"`
 	SubscriptionDetails SubscriptionDetails `json:"subscriptionDetails,omitempty"`
 	CustomOption        string              `json:"customOption,omitempty"`
+	CatalogueID         string              `json:"catalogueId,omitempty"` // optional: pin a specific provider catalogue entry
+	CountryCode         string              `json:"countryCode,omitempty"` // ISO country of the service, e.g. "KE"
+	Currency            string              `json:"currency,omitempty"`    // preferred display currency, e.g. "USD"; prices stay in the provider's currency
 }
 
 const (
@@ -31,7 +35,10 @@ type ServiceMetadata struct {
 	Category     string   `json:"category"`
 }
 
+// ServiceCatalogue is a single offering of a provider. A provider may hold several
+// entries (e.g. lawn care and handyman, or in-store and in-home), each identified by ID.
 type ServiceCatalogue struct {
+	ID            string          `bson:"id,omitempty" json:"id,omitempty"`
 	Service       ServiceMetadata `bson:"service" json:"service" binding:"required"`
 	Mode          string          `bson:"mode" json:"mode" binding:"required"`
 	CustomOptions []CustomOption  `bson:"customOptions" json:"customOptions" binding:"required"`
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// ServicePriceRange is the suggested price band for a service, in USD unless Currency says otherwise.
+type ServicePriceRange struct {
+	Min       float64 `bson:"min" json:"min"`
+	Max       float64 `bson:"max" json:"max"`
+	Suggested float64 `bson:"suggested,omitempty" json:"suggested,omitempty"`
+	Currency  string  `bson:"currency,omitempty" json:"currency"`
+
+	// RateSnapshot is set when the range was converted from the catalogue currency.
+	RateSnapshot *RateSnapshot `bson:"-" json:"rateSnapshot,omitempty"`
+}
+
+// ServiceRegionOverride adjusts a service for one region name (e.g. "Sub-Saharan Africa")
+// or ISO country code (e.g. "KE"). Nil/empty fields inherit the base definition.
+type ServiceRegionOverride struct {
+	Region        string             `bson:"region" json:"region" binding:"required"`
+	Disabled      bool               `bson:"disabled" json:"disabled"`
+	Modes         []string           `bson:"modes,omitempty" json:"modes,omitempty"`
+	PriceRange    *ServicePriceRange `bson:"priceRange,omitempty" json:"priceRange,omitempty"`
+	CustomOptions []CustomOption     `bson:"customOptions,omitempty" json:"customOptions,omitempty"`
+}
+
+// ServiceDefinition is an admin-managed entry of the platform service taxonomy.
+// Every change bumps Version and archives the previous revision.
+type ServiceDefinition struct {
+	ID              string                  `bson:"id" json:"id"`
+	Metadata        ServiceMetadata         `bson:"metadata" json:"metadata"`
+	PriceRange      *ServicePriceRange      `bson:"priceRange,omitempty" json:"priceRange,omitempty"`
+	CustomOptions   []CustomOption          `bson:"customOptions,omitempty" json:"customOptions,omitempty"`
+	Availability    []string                `bson:"availability,omitempty" json:"availability,omitempty"`
+	RegionOverrides []ServiceRegionOverride `bson:"regionOverrides,omitempty" json:"regionOverrides,omitempty"`
+	Active          bool                    `bson:"active" json:"active"`
+	Version         int                     `bson:"version" json:"version"`
+	UpdatedBy       string                  `bson:"updatedBy,omitempty" json:"updatedBy,omitempty"`
+	CreatedAt       time.Time               `bson:"createdAt" json:"createdAt"`
+	UpdatedAt       time.Time               `bson:"updatedAt" json:"updatedAt"`
+}
@@ -3,11 +3,13 @@ package models
 import "time"
 
 type Reminder struct {
-	ID       string    `bson:"id" json:"id"`
-	Title    string    `bson:"title" json:"title"`
-	Body     string    `bson:"body" json:"body"`
-	FireDate time.Time `bson:"fireDate" json:"fireDate"`
-	Sent     bool      `bson:"sent" json:"sent"`
+	ID          string    `bson:"id" json:"id"`
+	RecipientID string    `bson:"recipientId" json:"-"`
+	Role        string    `bson:"role" json:"-"` // "user" or "provider"
+	Title       string    `bson:"title" json:"title"`
+	Body        string    `bson:"body" json:"body"`
+	FireDate    time.Time `bson:"fireDate" json:"fireDate"`
+	Sent        bool      `bson:"sent" json:"sent"`
 }
 
 type ReminderPayload struct {
@@ -15,6 +17,8 @@ type ReminderPayload struct {
 	ReminderID string `json:"reminderId"` // optional
 	Title      string `json:"title"`
 	Body       string `json:"body"`
-	FireDate   string `json:"fireDate"` // optional
-	Target     string `json:"target"`   // "user" or "provider"
+	FireDate   string `json:"fireDate"`            // optional
+	Target     string `json:"target"`              // "user" or "provider"
+	Type       string `json:"type,omitempty"`      // notification type, e.g. "booking_reminder"
+	BookingID  string `json:"bookingId,omitempty"` // set on booking reminders
 }
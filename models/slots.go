@@ -1,5 +1,10 @@
 package models
 
+import (
+	"fmt"
+	"math"
+)
+
 // TimeSlot represents a provider's pre-defined booking window.
 type TimeSlot struct {
 	ID                  string             `bson:"id" json:"id"`
@@ -8,24 +13,91 @@ type TimeSlot struct {
 	End                 int                `bson:"end" json:"end"`                                 // minutes from midnight (e.g., 780 for 1:00 PM)
 	Capacity            int                `bson:"capacity" json:"capacity"`                       // total units for the slot (e.g., 30 kids)
 	CapacityMode        CapacityMode       `bson:"capacityMode" json:"capacityMode"`               // "exclusive" or "batch"
-	SlotModel           string             `bson:"slotModel" json:"slotModel"`                     // "earlybird", "urgency", or "flatrate"
+	SlotModel           string             `bson:"slotModel" json:"slotModel"`                     // "earlybird", "urgency", "dynamic" or "flatrate"
 	UnitType            string             `bson:"unitType" json:"unitType"`                       // e.g., "child", "kg", "hour"
 	Date                string             `bson:"date,omitempty" json:"date"`                     // e.g., "2025-02-25"
 	EarlyBird           *EarlyBirdSlotData `bson:"earlyBird,omitempty" json:"earlyBird,omitempty"` // non-nil when SlotModel is "earlybird"
 	Urgency             *UrgencySlotData   `bson:"urgency,omitempty" json:"urgency,omitempty"`     // non-nil when SlotModel is "urgency"
+	Dynamic             *DynamicSlotData   `bson:"dynamic,omitempty" json:"dynamic,omitempty"`     // non-nil when SlotModel is "dynamic"
 	BasePrice           float64            `bson:"basePrice" json:"basePrice"`
 	BookedUnitsStandard int                `bson:"bookedUnitsStandard,omitempty" json:"bookedUnitsStandard,omitempty"`
 	BookedUnitsPriority int                `bson:"bookedUnitsPriority,omitempty" json:"bookedUnitsPriority,omitempty"`
 	Version             int                `bson:"version" json:"version"`
+	CatalogueID         string             `bson:"catalogueId,omitempty" json:"catalogueId,omitempty"` // provider catalogue entry served; empty serves every entry
 	Catalogue           ServiceCatalogue   `bson:"catalogue,omitempty" json:"catalogue,omitzero"`
 	Blocked             bool               `bson:"blocked" json:"blocked"`
 	BlockReason         string             `bson:"blockReason,omitempty" json:"blockReason,omitempty"`
 	BookingIDs          []string           `bson:"bookingIds,omitempty" json:"bookingIds,omitempty"`
+
+	// Demand is the number of booking sessions recently started for the slot's
+	// service around the provider. It is set before pricing dynamic slots and never stored.
+	Demand int `bson:"-" json:"-"`
 }
 
 type EarlyBirdSlotData struct {
 	EarlyBirdDiscountRate float64 `bson:"earlyBirdDiscountRate" json:"earlyBirdDiscountRate"` // e.g., 0.25 for 25% discount
 	LateSurchargeRate     float64 `bson:"lateSurchargeRate" json:"lateSurchargeRate"`         // e.g., 0.25 for 25% surcharge
+
+	// Tiers is the price schedule. When empty, the legacy schedule is derived from
+	// the rates above: first 25% of capacity discounted, up to 75% standard, rest surcharged.
+	Tiers []EarlyBirdTier `bson:"tiers,omitempty" json:"tiers,omitempty"`
+}
+
+// EarlyBirdTier prices a run of units, in booking order. Units past the last
+// tier are priced at its rate.
+type EarlyBirdTier struct {
+	Units int     `bson:"units" json:"units"` // units in this tier; 0 on the last tier means "all remaining"
+	Rate  float64 `bson:"rate" json:"rate"`   // e.g., -0.3 for 30% off, 0.15 for 15% on top
+}
+
+// LegacyEarlyBirdTiers converts the fixed 25%/75% thresholds into a tier schedule.
+func LegacyEarlyBirdTiers(eb EarlyBirdSlotData, capacity int) []EarlyBirdTier {
+	early := int(math.Ceil(float64(capacity) * 0.25))
+	standard := int(math.Ceil(float64(capacity) * 0.75))
+	var tiers []EarlyBirdTier
+	if early > 0 {
+		tiers = append(tiers, EarlyBirdTier{Units: early, Rate: -eb.EarlyBirdDiscountRate})
+	}
+	if standard > early {
+		tiers = append(tiers, EarlyBirdTier{Units: standard - early, Rate: 0})
+	}
+	return append(tiers, EarlyBirdTier{Units: 0, Rate: eb.LateSurchargeRate})
+}
+
+// Schedule returns the tiers in effect for a slot of the given capacity.
+func (eb EarlyBirdSlotData) Schedule(capacity int) []EarlyBirdTier {
+	if len(eb.Tiers) > 0 {
+		return eb.Tiers
+	}
+	return LegacyEarlyBirdTiers(eb, capacity)
+}
+
+// RateAt returns the rate of the unit at zero-based position index in booking order.
+func (eb EarlyBirdSlotData) RateAt(capacity, index int) float64 {
+	tiers := eb.Schedule(capacity)
+	for i, t := range tiers {
+		if t.Units == 0 && i == len(tiers)-1 {
+			return t.Rate
+		}
+		if index < t.Units {
+			return t.Rate
+		}
+		index -= t.Units
+	}
+	return tiers[len(tiers)-1].Rate
+}
+
+// Validate checks a provider-set tier schedule.
+func (eb EarlyBirdSlotData) Validate() error {
+	for i, t := range eb.Tiers {
+		if t.Units < 0 || (t.Units == 0 && i != len(eb.Tiers)-1) {
+			return fmt.Errorf("earlybird tier %d: units must be positive; only the last tier may use 0 for the rest", i+1)
+		}
+		if t.Rate <= -1 {
+			return fmt.Errorf("earlybird tier %d: rate must be above -1", i+1)
+		}
+	}
+	return nil
 }
 
 type UrgencySlotData struct {
@@ -34,6 +106,12 @@ type UrgencySlotData struct {
 	PriorityActive        bool    `bson:"priorityActive" json:"priorityActive"`
 }
 
+// DynamicSlotData bounds the multiplier applied to BasePrice by demand-based pricing.
+type DynamicSlotData struct {
+	MinMultiplier float64 `bson:"minMultiplier" json:"minMultiplier"` // e.g., 0.8 for at most 20% off
+	MaxMultiplier float64 `bson:"maxMultiplier" json:"maxMultiplier"` // e.g., 1.5 for at most 50% on top
+}
+
 type CapacityMode string
 
 const (
@@ -47,6 +125,7 @@ const (
 	Standard  SlotModel = "flatrate"
 	EarlyBird SlotModel = "earlybird"
 	Urgency   SlotModel = "urgency"
+	Dynamic   SlotModel = "dynamic"
 )
 
 // AvailableSlotResponse represents the detailed timeslot information including the user’s selected custom option and units.
@@ -61,23 +140,26 @@ type AvailableSlotResponse struct {
 	Subscription        bool                 `json:"subscription,omitempty"`
 	SubscriptionDetails SubscriptionDetails  `json:"subscriptionDetails,omitempty"`
 	UserPayment         UserPayment          `json:"userPayment" binding:"required"`
+	QuoteID             string               `json:"quoteId,omitempty"`   // signed quote from availability; honored if still valid
+	PromoCode           string               `json:"promoCode,omitempty"` // promo or referral code
 }
 
 type AvailableSlot struct {
-	ID                        string             `json:"id"`
-	Start                     int                `json:"start"`
-	End                       int                `json:"end"`
-	UnitType                  string             `json:"unitType"`
-	RegularCapacityRemaining  int                `json:"regularCapacityRemaining"`
-	PriorityCapacityRemaining int                `json:"priorityCapacityRemaining,omitempty"`
-	RegularPricePerUnit       float64            `json:"regularPricePerUnit,omitempty"`
-	PriorityPricePerUnit      float64            `json:"priorityPricePerUnit,omitempty"`
-	Message                   string             `json:"message,omitempty"`
-	Date                      string             `json:"date"`
-	CustomOptionKey           string             `json:"customOptionKey,omitempty"`
-	Catalogue                 ServiceCatalogue   `bson:"catalogue,omitempty" json:"catalogue,omitzero"`
-	OptionPricing             map[string]float64 `json:"optionPricing,omitempty"`
-	CapacityMode              CapacityMode       `bson:"capacityMode" json:"capacityMode"` // "exclusive" or "batch"
+	ID                        string                `json:"id"`
+	Start                     int                   `json:"start"`
+	End                       int                   `json:"end"`
+	UnitType                  string                `json:"unitType"`
+	RegularCapacityRemaining  int                   `json:"regularCapacityRemaining"`
+	PriorityCapacityRemaining int                   `json:"priorityCapacityRemaining,omitempty"`
+	RegularPricePerUnit       float64               `json:"regularPricePerUnit,omitempty"`
+	PriorityPricePerUnit      float64               `json:"priorityPricePerUnit,omitempty"`
+	Message                   string                `json:"message,omitempty"`
+	Date                      string                `json:"date"`
+	CustomOptionKey           string                `json:"customOptionKey,omitempty"`
+	Catalogue                 ServiceCatalogue      `bson:"catalogue,omitempty" json:"catalogue,omitzero"`
+	OptionPricing             map[string]float64    `json:"optionPricing,omitempty"`
+	Quotes                    map[string]PriceQuote `json:"quotes,omitempty"`                 // keyed by custom option
+	CapacityMode              CapacityMode          `bson:"capacityMode" json:"capacityMode"` // "exclusive" or "batch"
 }
 
 // ProviderTimeslotDTO represents a minimal view for timeslot setup.
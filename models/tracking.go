@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// LocationFix is one position reported by the provider app while on the way.
+type LocationFix struct {
+	Lat      float64 `json:"lat" binding:"min=-90,max=90"`
+	Lng      float64 `json:"lng" binding:"min=-180,max=180"`
+	Heading  float64 `json:"heading,omitempty"`  // degrees from north
+	Speed    float64 `json:"speed,omitempty"`    // metres per second
+	Accuracy float64 `json:"accuracy,omitempty"` // metres
+}
+
+// LiveTrip is the live tracking window of an in-home booking, open from the
+// provider's on_the_way check-in until the job is completed.
+type LiveTrip struct {
+	BookingID   string    `json:"bookingId"`
+	ProviderID  string    `json:"providerId"`
+	UserID      string    `json:"userId"`
+	Destination GeoPoint  `json:"destination"`
+	StartedAt   time.Time `json:"startedAt"`
+	ExpiresAt   time.Time `json:"expiresAt"` // the window closes by itself if the job is never completed
+}
+
+// LiveLocation is the provider's latest position on a trip with a
+// straight-line distance and ETA to the service location. Ended is set on the
+// final message of a trip.
+type LiveLocation struct {
+	BookingID      string    `json:"bookingId"`
+	Lat            float64   `json:"lat,omitempty"`
+	Lng            float64   `json:"lng,omitempty"`
+	Heading        float64   `json:"heading,omitempty"`
+	Speed          float64   `json:"speed,omitempty"`
+	Accuracy       float64   `json:"accuracy,omitempty"`
+	DistanceMeters float64   `json:"distanceMeters,omitempty"`
+	ETASeconds     int       `json:"etaSeconds,omitempty"`
+	RecordedAt     time.Time `json:"recordedAt"`
+	Ended          bool      `json:"ended,omitempty"`
+	Reason         string    `json:"reason,omitempty"` // why the trip ended, e.g. "completed"
+}
@@ -9,7 +9,7 @@ type User struct {
 	Email            string            `bson:"email" json:"email"`
 	PhoneNumber      string            `bson:"phoneNumber" json:"phoneNumber"`
 	Password         string            `bson:"-" json:"password,omitempty"`
-	FCMToken         string            `bson:"fcmToken" json:"fcmToken"`
+	FCMToken         string            `bson:"fcmToken" json:"fcmToken"` // Deprecated: tokens are kept per device; see PushTokens
 	PasswordHash     string            `bson:"passwordHash" json:"-"`
 	ProfileImage     string            `bson:"profileImage,omitempty" json:"profileImage,omitempty"`
 	Preferences      []string          `bson:"preferences,omitempty" json:"preferences,omitempty"`
@@ -18,13 +18,94 @@ type User struct {
 	UpdatedAt        time.Time         `bson:"updatedAt" json:"updatedAt"`
 	Rating           int               `bson:"rating" json:"rating,omitempty"`
 	ActiveBookings   []string          `bson:"activeBookings" json:"activeBookings,omitempty"`
-	Notifications    []Notification    `bson:"notifications" json:"notifications,omitempty"`
-	Reminders        []Reminder        `bson:"reminders,omitempty" json:"reminders,omitempty"`
 	Location         GeoPoint          `bson:"location" json:"location,omitempty"`
 	BookingHistory   []string          `bson:"bookingHistory" json:"bookingHistory,omitempty"`
 	LastBookingTime  time.Time         `bson:"lastBookingTime" json:"lastBookingTime,omitempty"`
 	SafetySettings   SafetySettings    `bson:"safetySettings,omitempty" json:"safetySettings,omitempty"`
 	TrustedProviders []TrustedProvider `bson:"trustedProviders,omitempty" json:"trustedProviders,omitempty"`
+	Household        []HouseholdMember `bson:"household,omitempty" json:"household,omitempty"`
+	Addresses        []SavedAddress    `bson:"addresses,omitempty" json:"addresses,omitempty"`
+	StripeCustomerID string            `bson:"stripeCustomerId,omitempty" json:"-"`
+	Reliability      Reliability       `bson:"reliability,omitempty" json:"reliability,omitzero"` // bookings the user was absent for
+	// PreferredLanguage picks the locale notifications are written in, e.g. "en" or "sw".
+	PreferredLanguage string `bson:"preferredLanguage,omitempty" json:"preferredLanguage,omitempty"`
+}
+
+// SavedAddress is an entry of the user's address book.
+type SavedAddress struct {
+	ID               string    `bson:"id" json:"id"`
+	Label            string    `bson:"label" json:"label" binding:"required"` // e.g. "Home", "Office"
+	FormattedAddress string    `bson:"formattedAddress,omitempty" json:"formattedAddress,omitempty"`
+	Location         GeoPoint  `bson:"location" json:"location" binding:"required"`
+	Apartment        string    `bson:"apartment,omitempty" json:"apartment,omitempty"`       // unit, floor or building name
+	Instructions     string    `bson:"instructions,omitempty" json:"instructions,omitempty"` // gate codes, landmarks, parking
+	IsDefault        bool      `bson:"isDefault" json:"isDefault"`
+	CreatedAt        time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt        time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// ServiceAddress is the address a booking is performed at, snapshotted at booking
+// time so later address book edits don't move existing jobs.
+type ServiceAddress struct {
+	AddressID        string   `bson:"addressId,omitempty" json:"addressId,omitempty"`
+	Label            string   `bson:"label,omitempty" json:"label,omitempty"`
+	FormattedAddress string   `bson:"formattedAddress,omitempty" json:"formattedAddress,omitempty"`
+	Location         GeoPoint `bson:"location" json:"location,omitzero"`
+	Apartment        string   `bson:"apartment,omitempty" json:"apartment,omitempty"`
+	Instructions     string   `bson:"instructions,omitempty" json:"instructions,omitempty"`
+}
+
+// PushTokens returns the FCM tokens of the user's devices.
+func (u User) PushTokens() []string {
+	return devicePushTokens(u.Devices, u.FCMToken)
+}
+
+// FindAddress returns the saved address with the given ID.
+func (u User) FindAddress(addressID string) (SavedAddress, bool) {
+	for _, a := range u.Addresses {
+		if a.ID == addressID {
+			return a, true
+		}
+	}
+	return SavedAddress{}, false
+}
+
+// BookingForSelf is the ServicePlan.BookingFor value for booking on one's own behalf.
+const BookingForSelf = "self"
+
+// HouseholdMember is a saved profile of someone the user books services for,
+// e.g. a child or an elderly parent living at a different address.
+type HouseholdMember struct {
+	ID           string    `bson:"id" json:"id"`
+	Name         string    `bson:"name" json:"name" binding:"required"`
+	Relationship string    `bson:"relationship" json:"relationship" binding:"required"` // e.g. "child", "parent"
+	PhoneNumber  string    `bson:"phoneNumber,omitempty" json:"phoneNumber,omitempty"`
+	Address      string    `bson:"address,omitempty" json:"address,omitempty"`
+	Location     GeoPoint  `bson:"location,omitempty" json:"location,omitzero"`
+	SpecialNotes string    `bson:"specialNotes,omitempty" json:"specialNotes,omitempty"` // e.g. allergies, access needs
+	CreatedAt    time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt    time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Beneficiary is the person a booking is performed for, snapshotted at booking time.
+type Beneficiary struct {
+	MemberID     string   `bson:"memberId,omitempty" json:"memberId,omitempty"` // empty when booked for self
+	Name         string   `bson:"name" json:"name"`
+	Relationship string   `bson:"relationship,omitempty" json:"relationship,omitempty"`
+	PhoneNumber  string   `bson:"phoneNumber,omitempty" json:"-"` // reached through the booking's call relay
+	Address      string   `bson:"address,omitempty" json:"address,omitempty"`
+	Location     GeoPoint `bson:"location,omitempty" json:"location,omitzero"`
+	SpecialNotes string   `bson:"specialNotes,omitempty" json:"specialNotes,omitempty"`
+}
+
+// FindHouseholdMember returns the saved household member with the given ID.
+func (u User) FindHouseholdMember(memberID string) (HouseholdMember, bool) {
+	for _, m := range u.Household {
+		if m.ID == memberID {
+			return m, true
+		}
+	}
+	return HouseholdMember{}, false
 }
 
 type UserMinimal struct {
@@ -33,14 +114,13 @@ type UserMinimal struct {
 	ProfileImage string   `bson:"profileImage,omitempty" json:"profileImage,omitempty"`
 	Rating       int      `bson:"rating" json:"rating,omitempty"`
 	Location     GeoPoint `bson:"location" json:"location,omitzero"` // only include location if mode is provider-to-user
-	PhoneNumber  string   `bson:"phoneNumber" json:"phoneNumber"`
 }
 
 type SafetySettings struct {
 	NoShowThresholdMinutes int    `bson:"noShowThresholdMinutes" json:"noShowThresholdMinutes"`
 	SafetyReminderMinutes  int    `bson:"safetyReminderMinutes" json:"safetyReminderMinutes"`
 	RequireInsured         bool   `bson:"requireInsured" json:"requireInsured"`
-	AlertChannel           string `bson:"alertChannel" json:"alertChannel"` // "sms", "push" or "both"
+	AlertChannel           string `bson:"alertChannel" json:"alertChannel"` // "sms", "push", "whatsapp" or "both"
 	EmailUpdates           bool   `bson:"emailUpdates" json:"emailUpdates"` // Whether to send email updates
 }
 
@@ -56,13 +136,11 @@ type UserUpdateRequest struct {
 	Username              *string            `json:"username,omitempty"`
 	Email                 *string            `json:"email,omitempty"`
 	PhoneNumber           *string            `json:"phoneNumber,omitempty"`
-	FCMToken              *string            `json:"fcmToken,omitempty"`
 	ProfileImage          *string            `json:"profileImage,omitempty"`
 	Preferences           *[]string          `json:"preferences,omitempty"`
 	Devices               *[]Device          `json:"devices,omitempty"`
 	Rating                *int               `json:"rating,omitempty"`
 	ActiveBookings        *[]string          `json:"activeBookings,omitempty"`
-	Notifications         *[]Notification    `json:"notifications,omitempty"`
 	Reminders             *[]Reminder        `json:"reminders,omitempty"`
 	Location              *GeoPoint          `json:"location,omitempty"`
 	BookingHistory        *[]string          `json:"bookingHistory,omitempty"`
@@ -72,4 +150,6 @@ type UserUpdateRequest struct {
 	UpdatedAt             *time.Time         `json:"updatedAt,omitempty"`
 	MarkNotificationsRead *[]string          `json:"markNotificationsRead,omitempty"`
 	RemoveNotifications   *[]string          `json:"removeNotifications,omitempty"`
+	StripeCustomerID      *string            `json:"-"` // set by the server only
+	PreferredLanguage     *string            `json:"preferredLanguage,omitempty"`
 }
@@ -38,6 +38,24 @@ func RegisterUserRoutes(r *gin.Engine, hb *handlers.HandlerBundle) {
 		api.POST("/fcm", hb.UpdateFCMTokenHandler)
 		api.PUT("/safety-preferences", hb.UpdateSafetyPreferences)
 		api.PUT("/trusted-providers", hb.UpdateTrustedProviders)
+		api.GET("/household", hb.GetHouseholdHandler)
+		api.POST("/household", hb.AddHouseholdMemberHandler)
+		api.PUT("/household/:memberID", hb.UpdateHouseholdMember)
+		api.DELETE("/household/:memberID", hb.RemoveHouseholdMember)
+		api.GET("/addresses", hb.GetAddressesHandler)
+		api.POST("/addresses", hb.AddAddressHandler)
+		api.PUT("/addresses/:addressID", hb.UpdateAddressHandler)
+		api.DELETE("/addresses/:addressID", hb.RemoveAddressHandler)
+		api.GET("/referral", hb.GetReferralHandler)
+
+		// Notification inbox
+		api.GET("/notifications", hb.UserNotifications.ListNotifications)
+		api.GET("/notifications/unread-count", hb.UserNotifications.UnreadCount)
+		api.POST("/notifications/read", hb.UserNotifications.MarkRead)
+		api.POST("/notifications/read-all", hb.UserNotifications.MarkAllRead)
+		api.POST("/notifications/remove", hb.UserNotifications.RemoveNotifications)
+		api.GET("/notifications/preferences", hb.UserNotifications.GetPreferences)
+		api.PUT("/notifications/preferences", hb.UserNotifications.UpdatePreferences)
 	}
 }
 
@@ -77,6 +95,34 @@ func RegisterProviderRoutes(r *gin.Engine, hb *handlers.HandlerBundle) {
 			protected.POST("/timeslots", hb.GetTimeslotsHandler)
 			protected.DELETE("/timeslot", hb.DeleteTimeslotHandler)
 			protected.GET("/booking/:bookingId", hb.VerifyBooking)
+			protected.POST("/bookings/:bookingID/adjustments", hb.ProposeAdjustment)
+			protected.POST("/bookings/:bookingID/check-in", hb.CheckIn)
+			protected.POST("/bookings/:bookingID/location", hb.ShareLocation)
+			protected.GET("/events/stream", hb.StreamProviderEvents)
+
+			// Booking chats
+			protected.GET("/chats", hb.ProviderChat.ListChats)
+			protected.GET("/bookings/:bookingID/chat", hb.ProviderChat.GetChat)
+			protected.POST("/bookings/:bookingID/chat", hb.ProviderChat.SendMessage)
+			protected.POST("/bookings/:bookingID/chat/read", hb.ProviderChat.MarkRead)
+			protected.POST("/bookings/:bookingID/chat/messages/:messageID/report", hb.ProviderChat.ReportMessage)
+			protected.GET("/bookings/:bookingID/chat/stream", hb.ProviderChat.StreamChat)
+			protected.GET("/bookings/:bookingID/contact", hb.ProviderContact.GetContact)
+			protected.PUT("/bookings/:bookingID/contact", hb.ProviderContact.SetSharing)
+
+			// Service catalogue entries
+			protected.POST("/catalogue", hb.AddCatalogueEntryHandler)
+			protected.PUT("/catalogue/:catalogueID", hb.UpdateCatalogueEntryHandler)
+			protected.DELETE("/catalogue/:catalogueID", hb.RemoveCatalogueEntryHandler)
+
+			// Notification inbox
+			protected.GET("/notifications", hb.ProviderNotifications.ListNotifications)
+			protected.GET("/notifications/unread-count", hb.ProviderNotifications.UnreadCount)
+			protected.POST("/notifications/read", hb.ProviderNotifications.MarkRead)
+			protected.POST("/notifications/read-all", hb.ProviderNotifications.MarkAllRead)
+			protected.POST("/notifications/remove", hb.ProviderNotifications.RemoveNotifications)
+			protected.GET("/notifications/preferences", hb.ProviderNotifications.GetPreferences)
+			protected.PUT("/notifications/preferences", hb.ProviderNotifications.UpdatePreferences)
 		}
 	}
 }
@@ -90,6 +136,29 @@ func RegisterAdminRoutes(r *gin.Engine, hb *handlers.HandlerBundle) {
 		adminGroup.GET("/providers", hb.GetAllProvidersHandler)
 		adminGroup.POST("/legal", hb.AdminLegalDocumentation)
 		adminGroup.GET("/health", hb.AdminHandler.SystemHealthHandler)
+
+		// Service taxonomy
+		adminGroup.GET("/services", hb.AdminHandler.ListServicesHandler)
+		adminGroup.POST("/services", hb.AdminHandler.CreateServiceHandler)
+		adminGroup.GET("/services/:serviceID", hb.AdminHandler.GetServiceHandler)
+		adminGroup.PUT("/services/:serviceID", hb.AdminHandler.UpdateServiceHandler)
+		adminGroup.DELETE("/services/:serviceID", hb.AdminHandler.DeleteServiceHandler)
+		adminGroup.GET("/services/:serviceID/history", hb.AdminHandler.GetServiceHistoryHandler)
+
+		// Promotions
+		adminGroup.GET("/promotions", hb.AdminHandler.ListPromotionsHandler)
+		adminGroup.POST("/promotions", hb.AdminHandler.CreatePromotionHandler)
+		adminGroup.PUT("/promotions/:code", hb.AdminHandler.UpdatePromotionHandler)
+		adminGroup.DELETE("/promotions/:code", hb.AdminHandler.DeletePromotionHandler)
+
+		// Notification templates
+		adminGroup.GET("/notification-templates", hb.AdminHandler.ListNotificationTemplatesHandler)
+		adminGroup.POST("/notification-templates/preview", hb.AdminHandler.PreviewNotificationTemplateHandler)
+
+		// Chat moderation
+		adminGroup.GET("/chats", hb.AdminHandler.ListChatsHandler)
+		adminGroup.GET("/chats/:bookingID", hb.AdminHandler.GetChatHandler)
+		adminGroup.PUT("/chats/:bookingID/messages/:messageID", hb.AdminHandler.ModerateChatMessageHandler)
 	}
 }
 
@@ -131,6 +200,35 @@ func RegisterBookingRoutes(r *gin.Engine, hb *handlers.HandlerBundle) {
 		bookingGroup.GET("/reverse", hb.ReverseGeocode)
 		bookingGroup.POST("/payment", hb.GetPaymentIntent)
 		bookingGroup.POST("/nearby", hb.MatchNearbyProviders)
+
+		// Multi-service basket checkout
+		bookingGroup.POST("/basket", hb.InitiateBasket)
+		bookingGroup.PUT("/basket/:basketID/items/:itemID", hb.UpdateBasketItem)
+		bookingGroup.POST("/basket/:basketID/confirm", hb.ConfirmBasket)
+		bookingGroup.DELETE("/basket/:basketID", hb.CancelBasket)
+
+		// Receipts
+		bookingGroup.GET("/receipts/:bookingID", hb.GetReceipt)
+
+		// Post-service adjustments and tips
+		bookingGroup.POST("/bookings/:bookingID/adjustments/:adjustmentID", hb.RespondToAdjustment)
+		bookingGroup.POST("/bookings/:bookingID/tip", hb.AddTip)
+		bookingGroup.POST("/bookings/:bookingID/balance", hb.PayBalance)
+		bookingGroup.POST("/bookings/:bookingID/no-show", hb.ResolveNoShow)
+
+		// Live provider location
+		bookingGroup.GET("/bookings/:bookingID/location", hb.GetLiveLocation)
+		bookingGroup.GET("/bookings/:bookingID/location/stream", hb.StreamLiveLocation)
+
+		// Booking chats
+		bookingGroup.GET("/chats", hb.UserChat.ListChats)
+		bookingGroup.GET("/bookings/:bookingID/chat", hb.UserChat.GetChat)
+		bookingGroup.POST("/bookings/:bookingID/chat", hb.UserChat.SendMessage)
+		bookingGroup.POST("/bookings/:bookingID/chat/read", hb.UserChat.MarkRead)
+		bookingGroup.POST("/bookings/:bookingID/chat/messages/:messageID/report", hb.UserChat.ReportMessage)
+		bookingGroup.GET("/bookings/:bookingID/chat/stream", hb.UserChat.StreamChat)
+		bookingGroup.GET("/bookings/:bookingID/contact", hb.UserContact.GetContact)
+		bookingGroup.PUT("/bookings/:bookingID/contact", hb.UserContact.SetSharing)
 	}
 }
 
@@ -0,0 +1,78 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	chatRepo "bloomify/database/repository/chat"
+	"bloomify/models"
+)
+
+// chatAttachmentURLExpiry is how long moderators' links to chat images work.
+const chatAttachmentURLExpiry = 30 * time.Minute
+
+func (a *DefaultAdminService) chats() (chatRepo.ChatRepository, error) {
+	if a.ChatRepo == nil {
+		return nil, fmt.Errorf("chat store is not configured")
+	}
+	return a.ChatRepo, nil
+}
+
+// ListChatThreads returns a page of booking chats, most recently active first;
+// flaggedOnly limits it to chats with reported messages.
+func (a *DefaultAdminService) ListChatThreads(ctx context.Context, flaggedOnly bool, page, limit int64) (*models.ChatThreadPage, error) {
+	repo, err := a.chats()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListThreads(ctx, models.ChatThreadFilter{FlaggedOnly: flaggedOnly}, page, limit)
+}
+
+// GetChatThread returns a booking chat with its messages as stored, including
+// hidden ones and their reports.
+func (a *DefaultAdminService) GetChatThread(ctx context.Context, bookingID string, before time.Time, limit int64) (*models.ChatConversation, error) {
+	repo, err := a.chats()
+	if err != nil {
+		return nil, err
+	}
+	thread, err := repo.GetThread(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := repo.ListMessages(ctx, bookingID, before, limit, true)
+	if err != nil {
+		return nil, err
+	}
+	if a.Storage != nil {
+		for i := range messages {
+			for j := range messages[i].Attachments {
+				att := &messages[i].Attachments[j]
+				url, err := a.Storage.GetSecureDownloadURL(ctx, att.ObjectPath, chatAttachmentURLExpiry)
+				if err != nil {
+					log.Printf("[Admin] Failed to sign attachment of chat message %s: %v", messages[i].ID, err)
+					continue
+				}
+				att.URL = url
+			}
+		}
+	}
+	return &models.ChatConversation{Thread: *thread, Messages: messages}, nil
+}
+
+// ModerateChatMessage hides or restores a chat message and resolves its
+// reports.
+func (a *DefaultAdminService) ModerateChatMessage(ctx context.Context, bookingID, messageID string, req models.ChatModerationRequest, adminID string) (*models.ChatMessage, error) {
+	repo, err := a.chats()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ModerateMessage(ctx, bookingID, messageID, models.ChatModeration{
+		By:     adminID,
+		Hidden: req.Hidden,
+		Reason: strings.TrimSpace(req.Reason),
+		At:     time.Now(),
+	})
+}
@@ -1,13 +1,44 @@
 package admin
 
 import (
+	chatRepo "bloomify/database/repository/chat"
+	promotionRepo "bloomify/database/repository/promotion"
+	serviceRepo "bloomify/database/repository/service"
 	"bloomify/models"
+	"bloomify/services/storage"
+	"context"
+	"time"
 )
 
 type AdminService interface {
 	GetLegalSections() []models.LegalSection
 	GetLegalSectionsFor(role string) []models.LegalSection
+
+	// Service Taxonomy
+	ListServices(ctx context.Context, includeInactive bool) ([]models.ServiceDefinition, error)
+	GetService(ctx context.Context, serviceID string) (*models.ServiceDefinition, error)
+	CreateService(ctx context.Context, def models.ServiceDefinition, adminID string) (*models.ServiceDefinition, error)
+	UpdateService(ctx context.Context, def models.ServiceDefinition, expectedVersion int, adminID string) (*models.ServiceDefinition, error)
+	DeactivateService(ctx context.Context, serviceID string, expectedVersion int, adminID string) (*models.ServiceDefinition, error)
+	GetServiceHistory(ctx context.Context, serviceID string) ([]models.ServiceDefinition, error)
+
+	// Promotions
+	ListPromotions(ctx context.Context, includeInactive bool) ([]models.PromoCode, error)
+	CreatePromotion(ctx context.Context, promo models.PromoCode, adminID string) (*models.PromoCode, error)
+	UpdatePromotion(ctx context.Context, promo models.PromoCode) (*models.PromoCode, error)
+	DeactivatePromotion(ctx context.Context, code string) (*models.PromoCode, error)
+
+	// Chat moderation
+	ListChatThreads(ctx context.Context, flaggedOnly bool, page, limit int64) (*models.ChatThreadPage, error)
+	GetChatThread(ctx context.Context, bookingID string, before time.Time, limit int64) (*models.ChatConversation, error)
+	ModerateChatMessage(ctx context.Context, bookingID, messageID string, req models.ChatModerationRequest, adminID string) (*models.ChatMessage, error)
 }
 
 // DefaultUserService is the production implementation.
-type DefaultAdminService struct{}
+type DefaultAdminService struct {
+	ServiceRepo   serviceRepo.ServiceRepository
+	PromotionRepo promotionRepo.PromotionRepository
+	ChatRepo      chatRepo.ChatRepository
+	// Storage signs links to chat images for moderators; optional.
+	Storage storage.StorageService
+}
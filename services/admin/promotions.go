@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	promotionRepo "bloomify/database/repository/promotion"
+	"bloomify/models"
+)
+
+func (a *DefaultAdminService) promotions() (promotionRepo.PromotionRepository, error) {
+	if a.PromotionRepo == nil {
+		return nil, fmt.Errorf("promotion store is not configured")
+	}
+	return a.PromotionRepo, nil
+}
+
+func validatePromoCode(promo *models.PromoCode) error {
+	promo.Code = strings.ToUpper(strings.TrimSpace(promo.Code))
+	if promo.Code == "" {
+		return fmt.Errorf("code is required")
+	}
+	promo.Currency = strings.ToUpper(strings.TrimSpace(promo.Currency))
+	switch promo.Kind {
+	case models.PromoPercentage:
+		if promo.Value <= 0 || promo.Value > 1 {
+			return fmt.Errorf("percentage value must be between 0 and 1")
+		}
+	case models.PromoFixed:
+		if promo.Value <= 0 {
+			return fmt.Errorf("fixed value must be positive")
+		}
+		if promo.Currency == "" {
+			return fmt.Errorf("currency is required for fixed codes")
+		}
+	default:
+		return fmt.Errorf("kind must be %q or %q", models.PromoPercentage, models.PromoFixed)
+	}
+	if promo.MaxDiscount < 0 || promo.UsageLimit < 0 {
+		return fmt.Errorf("maxDiscount and usageLimit cannot be negative")
+	}
+	if !promo.StartsAt.IsZero() && !promo.ExpiresAt.IsZero() && promo.ExpiresAt.Before(promo.StartsAt) {
+		return fmt.Errorf("expiresAt must be after startsAt")
+	}
+	for i, r := range promo.Regions {
+		promo.Regions[i] = strings.ToUpper(strings.TrimSpace(r))
+	}
+	return nil
+}
+
+// ListPromotions returns marketing promo codes.
+func (a *DefaultAdminService) ListPromotions(ctx context.Context, includeInactive bool) ([]models.PromoCode, error) {
+	repo, err := a.promotions()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListPromos(ctx, includeInactive)
+}
+
+// CreatePromotion validates and stores a new promo code.
+func (a *DefaultAdminService) CreatePromotion(ctx context.Context, promo models.PromoCode, adminID string) (*models.PromoCode, error) {
+	repo, err := a.promotions()
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePromoCode(&promo); err != nil {
+		return nil, err
+	}
+	promo.Redemptions = 0
+	promo.OwnerID = ""
+	promo.CreatedBy = adminID
+
+	if err := repo.CreatePromo(ctx, &promo); err != nil {
+		return nil, err
+	}
+	return &promo, nil
+}
+
+// UpdatePromotion replaces the terms of an existing promo code.
+func (a *DefaultAdminService) UpdatePromotion(ctx context.Context, promo models.PromoCode) (*models.PromoCode, error) {
+	repo, err := a.promotions()
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePromoCode(&promo); err != nil {
+		return nil, err
+	}
+	existing, err := repo.GetPromo(ctx, promo.Code)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Kind == models.PromoReferral {
+		return nil, fmt.Errorf("referral codes cannot be edited")
+	}
+
+	if err := repo.UpdatePromo(ctx, &promo); err != nil {
+		return nil, err
+	}
+	return repo.GetPromo(ctx, promo.Code)
+}
+
+// DeactivatePromotion stops a code from being redeemed; past redemptions are kept.
+func (a *DefaultAdminService) DeactivatePromotion(ctx context.Context, code string) (*models.PromoCode, error) {
+	repo, err := a.promotions()
+	if err != nil {
+		return nil, err
+	}
+	promo, err := repo.GetPromo(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, err
+	}
+	promo.Active = false
+	if err := repo.UpdatePromo(ctx, promo); err != nil {
+		return nil, err
+	}
+	return promo, nil
+}
@@ -0,0 +1,143 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	serviceRepo "bloomify/database/repository/service"
+	"bloomify/models"
+	"bloomify/utils"
+
+	"go.uber.org/zap"
+)
+
+func (a *DefaultAdminService) repo() (serviceRepo.ServiceRepository, error) {
+	if a.ServiceRepo == nil {
+		return nil, fmt.Errorf("service taxonomy store is not configured")
+	}
+	return a.ServiceRepo, nil
+}
+
+// invalidateTaxonomyCache drops the cached taxonomy so booking reads pick up the change.
+func invalidateTaxonomyCache(ctx context.Context) {
+	if err := utils.GetBookingCacheClient().Del(ctx, utils.ServiceTaxonomyCacheKey).Err(); err != nil {
+		utils.GetLogger().Warn("Failed to invalidate service taxonomy cache", zap.Error(err))
+	}
+}
+
+func validateServiceDefinition(def *models.ServiceDefinition) error {
+	def.ID = strings.TrimSpace(def.ID)
+	if def.ID == "" {
+		return fmt.Errorf("service id is required")
+	}
+	def.Metadata.ID = def.ID
+	if len(def.Metadata.Modes) == 0 {
+		return fmt.Errorf("at least one mode is required")
+	}
+	if pr := def.PriceRange; pr != nil && (pr.Min < 0 || pr.Max < pr.Min) {
+		return fmt.Errorf("invalid price range")
+	}
+	for _, opt := range def.CustomOptions {
+		if opt.Multiplier <= 0 {
+			return fmt.Errorf("custom option %q must have a positive multiplier", opt.Option)
+		}
+	}
+	seen := make(map[string]bool, len(def.RegionOverrides))
+	for _, override := range def.RegionOverrides {
+		key := strings.ToLower(strings.TrimSpace(override.Region))
+		if key == "" {
+			return fmt.Errorf("region override requires a region")
+		}
+		if seen[key] {
+			return fmt.Errorf("duplicate override for region %s", override.Region)
+		}
+		seen[key] = true
+		if pr := override.PriceRange; pr != nil && (pr.Min < 0 || pr.Max < pr.Min) {
+			return fmt.Errorf("invalid price range for region %s", override.Region)
+		}
+	}
+	return nil
+}
+
+// ListServices returns the service taxonomy.
+func (a *DefaultAdminService) ListServices(ctx context.Context, includeInactive bool) ([]models.ServiceDefinition, error) {
+	repo, err := a.repo()
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetAll(ctx, includeInactive)
+}
+
+// GetService returns one service definition.
+func (a *DefaultAdminService) GetService(ctx context.Context, serviceID string) (*models.ServiceDefinition, error) {
+	repo, err := a.repo()
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetByID(ctx, serviceID)
+}
+
+// CreateService adds a new service to the taxonomy.
+func (a *DefaultAdminService) CreateService(ctx context.Context, def models.ServiceDefinition, adminID string) (*models.ServiceDefinition, error) {
+	repo, err := a.repo()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateServiceDefinition(&def); err != nil {
+		return nil, err
+	}
+	def.Active = true
+	def.UpdatedBy = adminID
+	if err := repo.Create(ctx, &def); err != nil {
+		return nil, err
+	}
+	invalidateTaxonomyCache(ctx)
+	return &def, nil
+}
+
+// UpdateService replaces a service definition, failing if expectedVersion is stale.
+func (a *DefaultAdminService) UpdateService(ctx context.Context, def models.ServiceDefinition, expectedVersion int, adminID string) (*models.ServiceDefinition, error) {
+	repo, err := a.repo()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateServiceDefinition(&def); err != nil {
+		return nil, err
+	}
+	def.UpdatedBy = adminID
+	if err := repo.Update(ctx, &def, expectedVersion); err != nil {
+		return nil, err
+	}
+	invalidateTaxonomyCache(ctx)
+	return &def, nil
+}
+
+// DeactivateService hides a service everywhere while keeping its history and
+// existing provider catalogues intact.
+func (a *DefaultAdminService) DeactivateService(ctx context.Context, serviceID string, expectedVersion int, adminID string) (*models.ServiceDefinition, error) {
+	repo, err := a.repo()
+	if err != nil {
+		return nil, err
+	}
+	def, err := repo.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	def.Active = false
+	def.UpdatedBy = adminID
+	if err := repo.Update(ctx, def, expectedVersion); err != nil {
+		return nil, err
+	}
+	invalidateTaxonomyCache(ctx)
+	return def, nil
+}
+
+// GetServiceHistory returns the archived revisions of a service, newest first.
+func (a *DefaultAdminService) GetServiceHistory(ctx context.Context, serviceID string) ([]models.ServiceDefinition, error) {
+	repo, err := a.repo()
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetHistory(ctx, serviceID)
+}
@@ -0,0 +1,356 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	schedulerRepo "bloomify/database/repository/scheduler"
+	"bloomify/models"
+	"bloomify/utils"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrBookingNotFound is returned when the booking does not exist.
+	ErrBookingNotFound = errors.New("booking not found")
+	// ErrAdjustmentForbidden is returned when the booking belongs to another user or provider.
+	ErrAdjustmentForbidden = errors.New("booking belongs to another account")
+	// ErrAdjustmentNotFound is returned when the booking has no such adjustment.
+	ErrAdjustmentNotFound = errors.New("adjustment not found")
+	// ErrAdjustmentClosed is returned when an adjustment was already resolved or
+	// is being paid, or when the booking no longer accepts adjustments.
+	ErrAdjustmentClosed = errors.New("adjustment can no longer be changed")
+)
+
+// ProposeAdjustment lets a provider charge for more than was booked, either
+// extra units at the booked unit price or a one-off amount. The user is asked
+// to approve it in the app.
+func (svc *DefaultBookingSessionService) ProposeAdjustment(providerID, bookingID string, req models.AdjustmentProposal) (*models.BookingAdjustment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	se := svc.SchedulerEngine
+	b, err := se.loadAdjustableBooking(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b.ProviderID != providerID {
+		return nil, ErrAdjustmentForbidden
+	}
+
+	adj := models.BookingAdjustment{
+		AdjustmentID: uuid.New().String(),
+		Kind:         req.Kind,
+		Currency:     b.Invoice.Currency,
+		Reason:       req.Reason,
+		ProposedBy:   providerID,
+		Status:       models.AdjustmentPending,
+		CreatedAt:    time.Now(),
+	}
+	switch req.Kind {
+	case models.AdjustmentExtraUnits:
+		if req.Units <= 0 {
+			return nil, fmt.Errorf("units must be positive for an extra_units adjustment")
+		}
+		adj.Units = req.Units
+		adj.Amount = roundCents(unitCharge(b) * float64(req.Units))
+	case models.AdjustmentCharge:
+		adj.Amount = roundCents(req.Amount)
+	default:
+		return nil, fmt.Errorf("unsupported adjustment kind %q", req.Kind)
+	}
+	if adj.Amount <= 0 {
+		return nil, fmt.Errorf("adjustment amount must be positive")
+	}
+	provider, err := se.ProviderRepo.GetByIDWithProjection(providerID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load provider %s: %w", providerID, err)
+	}
+	adjustmentTaxes(&adj, taxRuleFor(b.CountryCode, *provider))
+
+	if err := se.Repo.AddBookingAdjustment(ctx, b.ID, adj); err != nil {
+		return nil, err
+	}
+
+	se.notifyAdjustment("user", utils.TemplateAdjustmentRequested, b, adj, utils.TemplateParams{
+		ProviderName: b.MinimalProviderDTO.ProviderName,
+		Amount:       adj.Total,
+		Count:        adj.Units,
+		Units:        b.UnitType,
+		Message:      adj.Reason,
+	})
+
+	return &adj, nil
+}
+
+// RespondToAdjustment approves or declines a pending adjustment. An approved
+// adjustment is paid straight away with the booking's payment method: card
+// bookings pass a PaymentIntent authorized for the amount, cash bookings add it
+// to what is collected. A failed payment can be approved again.
+func (svc *DefaultBookingSessionService) RespondToAdjustment(userID, bookingID, adjustmentID string, decision models.AdjustmentDecision) (*models.BookingAdjustment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	se := svc.SchedulerEngine
+	b, err := se.loadAdjustableBooking(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b.UserID != userID {
+		return nil, ErrAdjustmentForbidden
+	}
+
+	var adj *models.BookingAdjustment
+	for i := range b.Adjustments {
+		if b.Adjustments[i].AdjustmentID == adjustmentID {
+			adj = &b.Adjustments[i]
+			break
+		}
+	}
+	if adj == nil {
+		return nil, ErrAdjustmentNotFound
+	}
+	if adj.Status != models.AdjustmentPending && adj.Status != models.AdjustmentFailed {
+		return nil, ErrAdjustmentClosed
+	}
+	from := adj.Status
+
+	if !decision.Approve {
+		adj.Status = models.AdjustmentDeclined
+		adj.ResolvedAt = time.Now()
+		if err := se.updateAdjustment(ctx, b.ID, *adj, from); err != nil {
+			return nil, err
+		}
+		se.notifyAdjustment("provider", utils.TemplateAdjustmentDeclined, b, *adj, utils.TemplateParams{Amount: adj.Amount})
+		return adj, nil
+	}
+
+	// Claim the adjustment before charging, so a repeated approval cannot pay twice.
+	adj.Status = models.AdjustmentProcessing
+	adj.Error = ""
+	if err := se.updateAdjustment(ctx, b.ID, *adj, from); err != nil {
+		return nil, err
+	}
+	if err := se.settleAdjustment(ctx, b, adj, decision.PaymentIntentID); err != nil {
+		return adj, err
+	}
+	return adj, nil
+}
+
+// AddTip pays a tip to the provider of a booking. Tips are not commissioned.
+func (svc *DefaultBookingSessionService) AddTip(userID, bookingID string, req models.TipRequest) (*models.BookingAdjustment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	se := svc.SchedulerEngine
+	b, err := se.loadAdjustableBooking(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b.UserID != userID {
+		return nil, ErrAdjustmentForbidden
+	}
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("tip amount must be positive")
+	}
+
+	adj := &models.BookingAdjustment{
+		AdjustmentID: uuid.New().String(),
+		Kind:         models.AdjustmentTip,
+		Amount:       roundCents(req.Amount),
+		Currency:     b.Invoice.Currency,
+		ProposedBy:   userID,
+		Status:       models.AdjustmentProcessing,
+		CreatedAt:    time.Now(),
+	}
+	adjustmentTaxes(adj, taxRule{})
+	if err := se.Repo.AddBookingAdjustment(ctx, b.ID, *adj); err != nil {
+		return nil, err
+	}
+	if err := se.settleAdjustment(ctx, b, adj, req.PaymentIntentID); err != nil {
+		return adj, err
+	}
+	return adj, nil
+}
+
+// settleAdjustment pays a claimed adjustment and records the outcome: the
+// invoice gains a line, the provider ledger an entry, and the provider is told.
+// On failure the adjustment is marked failed with the payment error.
+func (se *DefaultSchedulingEngine) settleAdjustment(ctx context.Context, b *models.Booking, adj *models.BookingAdjustment, paymentIntentID string) error {
+	paid, err := se.chargeAdjustment(ctx, b, *adj, paymentIntentID)
+	if err != nil {
+		adj.Status = models.AdjustmentFailed
+		adj.Error = err.Error()
+		if uerr := se.updateAdjustment(ctx, b.ID, *adj, models.AdjustmentProcessing); uerr != nil {
+			log.Printf("[Adjustments] Failed to mark adjustment %s failed: %v", adj.AdjustmentID, uerr)
+		}
+		return fmt.Errorf("adjustment payment failed: %w", err)
+	}
+
+	adj.Status = models.AdjustmentPaid
+	adj.PaymentID = paid.PaymentID
+	adj.ResolvedAt = time.Now()
+	if err := se.updateAdjustment(ctx, b.ID, *adj, models.AdjustmentProcessing); err != nil {
+		return err
+	}
+
+	line := models.InvoiceLine{Kind: models.InvoiceLineAdjustment, Description: adj.Reason, Amount: adj.Amount}
+	kind := utils.TemplateAdjustmentPaid
+	switch adj.Kind {
+	case models.AdjustmentTip:
+		line.Kind, line.Description = models.InvoiceLineTip, "Tip"
+		kind = utils.TemplateTipReceived
+	case models.AdjustmentExtraUnits:
+		line.Description = fmt.Sprintf("%d extra %s: %s", adj.Units, b.UnitType, adj.Reason)
+	}
+	if err := se.Repo.AddInvoiceCharge(ctx, b.ID, append([]models.InvoiceLine{line}, adj.Taxes...)...); err != nil {
+		log.Printf("[Adjustments] Failed to add adjustment %s to invoice: %v", adj.AdjustmentID, err)
+	}
+	se.recordAdjustmentEarnings(ctx, b, *adj)
+	if b.Invoice.Method == "card" {
+		publishPaymentCaptured(ctx, b, adj.Kind, adjustmentTotal(*adj), adj.Currency)
+	}
+	se.notifyAdjustment("provider", kind, b, *adj, utils.TemplateParams{Amount: adj.Amount})
+	return nil
+}
+
+// chargeAdjustment takes payment for an adjustment with the booking's method.
+// Card payments verify the authorized intent covers the amount before capturing
+// it, and release the authorization if the capture fails.
+func (se *DefaultSchedulingEngine) chargeAdjustment(ctx context.Context, b *models.Booking, adj models.BookingAdjustment, paymentIntentID string) (*models.Invoice, error) {
+	if se.PaymentHandler == nil {
+		return nil, errors.New("internal server error: PaymentHandler not initialized")
+	}
+	req := models.PaymentRequest{
+		UserID:   b.UserID,
+		Amount:   adjustmentTotal(adj),
+		Currency: adj.Currency,
+		Method:   b.Invoice.Method,
+		Metadata: map[string]string{
+			"bookingId":    b.ID,
+			"providerId":   b.ProviderID,
+			"adjustmentId": adj.AdjustmentID,
+			"kind":         adj.Kind,
+		},
+	}
+
+	if req.Method != "card" {
+		req.Action = "record"
+		return se.PaymentHandler.ProcessPayment(ctx, req)
+	}
+
+	if paymentIntentID == "" {
+		return nil, errors.New("paymentIntentId is required for card bookings")
+	}
+	req.PaymentIntentID = paymentIntentID
+	req.Action = "authorize"
+	if _, err := se.PaymentHandler.ProcessPayment(ctx, req); err != nil {
+		return nil, err
+	}
+	req.Action = "capture"
+	paid, err := se.PaymentHandler.ProcessPayment(ctx, req)
+	if err != nil {
+		cancelReq := models.PaymentRequest{
+			UserID:          b.UserID,
+			Method:          "card",
+			PaymentIntentID: paymentIntentID,
+			Action:          "cancel",
+		}
+		_, _ = se.PaymentHandler.ProcessPayment(ctx, cancelReq)
+		return nil, err
+	}
+	return paid, nil
+}
+
+// adjustmentTotal is what the user pays for an adjustment. Adjustments proposed
+// before fees were charged on them have no Total and cost their Amount.
+func adjustmentTotal(adj models.BookingAdjustment) float64 {
+	if adj.Total == 0 {
+		return adj.Amount
+	}
+	return adj.Total
+}
+
+// loadAdjustableBooking returns a booking that can still take adjustments.
+func (se *DefaultSchedulingEngine) loadAdjustableBooking(ctx context.Context, bookingID string) (*models.Booking, error) {
+	b, err := se.Repo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, ErrBookingNotFound
+	}
+	switch b.Status {
+	case "payment_required", "cancelled", "no_show":
+		return nil, ErrAdjustmentClosed
+	}
+	if b.Invoice.Currency == "" && b.Quote != nil {
+		b.Invoice.Currency = b.Quote.ChargeCurrency
+	}
+	return b, nil
+}
+
+func (se *DefaultSchedulingEngine) updateAdjustment(ctx context.Context, bookingID string, adj models.BookingAdjustment, fromStatus string) error {
+	err := se.Repo.UpdateBookingAdjustment(ctx, bookingID, adj, fromStatus)
+	if errors.Is(err, schedulerRepo.ErrAdjustmentConflict) {
+		return ErrAdjustmentClosed
+	}
+	return err
+}
+
+// unitCharge is what the user paid per booked unit for the service itself, in
+// the booking's charge currency.
+func unitCharge(b *models.Booking) float64 {
+	if b.Units <= 0 {
+		return 0
+	}
+	for _, line := range b.Invoice.Lines {
+		if line.Kind == models.InvoiceLineService {
+			return line.Amount / float64(b.Units)
+		}
+	}
+	return b.Invoice.Amount / float64(b.Units)
+}
+
+// notifyAdjustment pushes an adjustment update to the user or the provider of
+// a booking, rendered in their language. The customer's name, the booking date
+// and the adjustment's currency are filled in.
+func (se *DefaultSchedulingEngine) notifyAdjustment(role, kind string, b *models.Booking, adj models.BookingAdjustment, params utils.TemplateParams) {
+	if se.Notification == nil {
+		return
+	}
+	data := map[string]string{
+		"type":         "booking_adjustment",
+		"bookingId":    b.ID,
+		"adjustmentId": adj.AdjustmentID,
+		"kind":         adj.Kind,
+		"status":       adj.Status,
+		"amount":       fmt.Sprintf("%.2f", adj.Amount),
+		"total":        fmt.Sprintf("%.2f", adjustmentTotal(adj)),
+		"currency":     adj.Currency,
+	}
+	go func() {
+		user, provider := se.bookingParties(b)
+		params.CustomerName = user.Username
+		params.Currency = adj.Currency
+		params.When = bookingStartTime(b.Date, b.Start)
+		locale := user.PreferredLanguage
+		if role == "provider" {
+			locale = provider.PreferredLanguage
+		}
+		title, body, err := utils.RenderNotification(kind, locale, params)
+		if err != nil {
+			log.Printf("[Adjustments] Failed to render %s notification for booking %s: %v", kind, b.ID, err)
+			return
+		}
+		if role == "provider" {
+			err = se.Notification.NotifyProvider(context.Background(), b.ProviderID, title, body, data)
+		} else {
+			err = se.Notification.NotifyUser(context.Background(), b.UserID, title, body, data)
+		}
+		if err != nil {
+			log.Printf("[Adjustments] Failed to send push notification for booking %s: %v", b.ID, err)
+		}
+	}()
+}
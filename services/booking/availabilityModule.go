@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	ledgerRepo "bloomify/database/repository/ledger"
+	promotionRepo "bloomify/database/repository/promotion"
 	providerRepo "bloomify/database/repository/provider"
 	schedulerRepo "bloomify/database/repository/scheduler"
 	timeslotRepo "bloomify/database/repository/timeslot"
@@ -12,6 +14,7 @@ import (
 	"bloomify/services/user"
 	"bloomify/utils"
 
+	"github.com/hibiken/asynq"
 	"go.uber.org/zap"
 )
 
@@ -23,6 +26,15 @@ type DefaultSchedulingEngine struct {
 	TimeslotsRepo  timeslotRepo.TimeSlotRepository
 	UserService    user.UserService
 	Notification   notification.NotificationService
+	PromotionRepo  promotionRepo.PromotionRepository
+	// LedgerRepo records provider earnings and platform commission; optional.
+	LedgerRepo ledgerRepo.LedgerRepository
+	// AsynqClient schedules balance charges of deposit bookings and booking
+	// reminders; optional.
+	AsynqClient *asynq.Client
+	// ReminderInspector deletes scheduled reminders of cancelled or moved
+	// bookings; optional.
+	ReminderInspector *asynq.Inspector
 }
 
 type AvailableSlotsResult struct {
@@ -37,6 +49,8 @@ func (se *DefaultSchedulingEngine) GetWeeklyAvailableSlots(
 	provider models.Provider,
 	weekIndex int,
 	units int,
+	displayCurrency string,
+	countryCode string,
 ) (AvailableSlotsResult, error) {
 	logger := utils.GetLogger()
 	now := time.Now()
@@ -86,7 +100,8 @@ func (se *DefaultSchedulingEngine) GetWeeklyAvailableSlots(
 		}, nil
 	}
 
-	slots, err := BuildAvailableSlots(enriched, weekStart, weekEnd, now, provider.PaymentDetails.Currency, units, provider)
+	attachDemand(enriched, provider, now)
+	slots, err := BuildAvailableSlots(enriched, weekStart, weekEnd, now, provider.PaymentDetails.Currency, displayCurrency, countryCode, units, provider)
 	if err != nil {
 		return AvailableSlotsResult{}, fmt.Errorf("failed to build available slots: %w", err)
 	}
@@ -0,0 +1,240 @@
+package booking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"bloomify/models"
+	"bloomify/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	basketCachePrefix = "basket:"
+	basketSessionTTL  = 30 * time.Minute
+	maxBasketItems    = 10
+)
+
+// InitiateBasket creates a basket session, matching providers for every plan.
+func (s *DefaultBookingSessionService) InitiateBasket(plans []models.ServicePlan, userID, deviceID, userAgent string) (*models.BasketSession, error) {
+	if len(plans) == 0 {
+		return nil, fmt.Errorf("basket must contain at least one service plan")
+	}
+	if len(plans) > maxBasketItems {
+		return nil, fmt.Errorf("basket can contain at most %d service plans", maxBasketItems)
+	}
+
+	basket := models.BasketSession{
+		BasketID:   uuid.New().String(),
+		Items:      make([]models.BasketItem, 0, len(plans)),
+		UserID:     userID,
+		DeviceID:   deviceID,
+		DeviceName: userAgent,
+		CreatedAt:  time.Now(),
+	}
+
+	for i, plan := range plans {
+		if plan.Subscription {
+			return nil, fmt.Errorf("item %d: subscriptions cannot be booked in a basket", i+1)
+		}
+		if err := validateServicePlan(plan); err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		plan, err := s.applyServiceLocation(plan, userID)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+
+		matchedProviders, err := s.MatchingSvc.MatchProviders(plan)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: failed to match providers: %w", i+1, err)
+		}
+		if len(matchedProviders) == 0 {
+			return nil, NewMatchError(fmt.Sprintf("no providers found for %s", plan.ServiceType))
+		}
+
+		basket.Items = append(basket.Items, models.BasketItem{
+			ItemID:           uuid.New().String(),
+			ServicePlan:      plan,
+			MatchedProviders: matchedProviders,
+		})
+	}
+
+	if err := saveBasket(basket); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, item := range basket.Items {
+		if err := RecordBookingDemand(context.Background(), item.ServicePlan.ServiceType, item.ServicePlan.LocationGeo, now); err != nil {
+			log.Printf("Failed to record demand for basket %s: %v", basket.BasketID, err)
+		}
+	}
+
+	log.Printf("Successfully initiated basket: %s (%d items)", basket.BasketID, len(basket.Items))
+	return &basket, nil
+}
+
+// UpdateBasketItem selects a provider for one basket item and computes its availability.
+func (s *DefaultBookingSessionService) UpdateBasketItem(basketID, itemID, selectedProviderID string, weekIndex int) (*models.BasketSession, error) {
+	basket, err := loadBasket(basketID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := findBasketItem(basket, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedDTO, ok := findProviderDTO(item.MatchedProviders, selectedProviderID)
+	if !ok {
+		return nil, fmt.Errorf("selected provider is not in the matched providers list")
+	}
+
+	item.SelectedProvider = selectedProviderID
+	item.Availability = nil
+	item.AvailabilityError = ""
+	item.MaxAvailableDate = ""
+
+	availabilityResult, err := s.SchedulerEngine.GetWeeklyAvailableSlots(providerFromDTO(selectedDTO), weekIndex, item.ServicePlan.Units, item.ServicePlan.Currency, item.ServicePlan.CountryCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute availability for provider: %w", err)
+	}
+	if len(availabilityResult.Slots) == 0 {
+		item.AvailabilityError = availabilityResult.AvailabilityError
+	} else {
+		item.Availability = availabilityResult.Slots
+		item.MaxAvailableDate = availabilityResult.MaxAvailableDate
+	}
+
+	if err := saveBasket(*basket); err != nil {
+		return nil, err
+	}
+	return basket, nil
+}
+
+// ConfirmBasket books a slot for every basket item with a single payment. Every
+// item must have a selected provider and a confirmed slot.
+func (s *DefaultBookingSessionService) ConfirmBasket(basketID string, checkout models.BasketCheckoutRequest) (*models.PublicBasketData, error) {
+	basket, err := loadBasket(basketID)
+	if err != nil {
+		return nil, err
+	}
+
+	selections := make(map[string]models.AvailableSlotResponse, len(checkout.Selections))
+	for _, sel := range checkout.Selections {
+		selections[sel.ItemID] = sel.ConfirmedSlot
+	}
+
+	providers := make([]models.Provider, 0, len(basket.Items))
+	reqs := make([]models.BookingRequest, 0, len(basket.Items))
+	for i, item := range basket.Items {
+		slot, ok := selections[item.ItemID]
+		if !ok {
+			return nil, fmt.Errorf("item %d (%s): no slot selected", i+1, item.ServicePlan.ServiceType)
+		}
+		selectedDTO, ok := findProviderDTO(item.MatchedProviders, item.SelectedProvider)
+		if !ok {
+			return nil, fmt.Errorf("item %d (%s): no provider selected", i+1, item.ServicePlan.ServiceType)
+		}
+		provider := providerFromDTO(selectedDTO)
+
+		providers = append(providers, provider)
+		reqs = append(reqs, models.BookingRequest{
+			SlotID:          slot.SlotID,
+			ProviderID:      provider.ID,
+			UserID:          basket.UserID,
+			Date:            slot.Date,
+			Start:           slot.Start,
+			End:             slot.End,
+			Units:           slot.Units,
+			UnitType:        slot.UnitType,
+			Priority:        false,
+			UserPayment:     checkout.UserPayment,
+			CustomOption:    slot.CustomOption,
+			QuoteID:         slot.QuoteID,
+			PromoCode:       checkout.PromoCode,
+			CountryCode:     item.ServicePlan.CountryCode,
+			Mode:            item.ServicePlan.Mode,
+			CatalogueID:     provider.ServiceCatalogue.ID,
+			BookingFor:      item.ServicePlan.BookingFor,
+			ServiceLocation: item.ServicePlan.LocationGeo,
+			AddressID:       item.ServicePlan.AddressID,
+		})
+	}
+
+	result, err := s.SchedulerEngine.BookBasket(basket.BasketID, providers, reqs, checkout.UserPayment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to book basket: %w", err)
+	}
+
+	utils.GetBookingCacheClient().Del(context.Background(), basketCachePrefix+basketID)
+	return result, nil
+}
+
+// CancelBasket discards a basket session.
+func (s *DefaultBookingSessionService) CancelBasket(basketID string) error {
+	if err := utils.GetBookingCacheClient().Del(context.Background(), basketCachePrefix+basketID).Err(); err != nil {
+		return fmt.Errorf("failed to cancel basket: %w", err)
+	}
+	return nil
+}
+
+func saveBasket(basket models.BasketSession) error {
+	data, err := json.Marshal(basket)
+	if err != nil {
+		return fmt.Errorf("failed to marshal basket: %w", err)
+	}
+	if err := utils.GetBookingCacheClient().Set(context.Background(), basketCachePrefix+basket.BasketID, data, basketSessionTTL).Err(); err != nil {
+		return fmt.Errorf("failed to store basket: %w", err)
+	}
+	return nil
+}
+
+func loadBasket(basketID string) (*models.BasketSession, error) {
+	if basketID == "" {
+		return nil, fmt.Errorf("basket not initialized")
+	}
+	data, err := utils.GetBookingCacheClient().Get(context.Background(), basketCachePrefix+basketID).Result()
+	if err != nil {
+		return nil, fmt.Errorf("basket not found or expired")
+	}
+	var basket models.BasketSession
+	if err := json.Unmarshal([]byte(data), &basket); err != nil {
+		return nil, fmt.Errorf("failed to parse basket: %w", err)
+	}
+	return &basket, nil
+}
+
+func findBasketItem(basket *models.BasketSession, itemID string) (*models.BasketItem, error) {
+	for i := range basket.Items {
+		if basket.Items[i].ItemID == itemID {
+			return &basket.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("basket item %s not found", itemID)
+}
+
+func findProviderDTO(providers []models.ProviderDTO, providerID string) (models.ProviderDTO, bool) {
+	if providerID == "" {
+		return models.ProviderDTO{}, false
+	}
+	for _, p := range providers {
+		if p.ID == providerID {
+			return p, true
+		}
+	}
+	return models.ProviderDTO{}, false
+}
+
+func providerFromDTO(dto models.ProviderDTO) models.Provider {
+	return models.Provider{
+		ID:               dto.ID,
+		ServiceCatalogue: dto.ServiceCatalogue,
+		Profile:          dto.Profile,
+	}
+}
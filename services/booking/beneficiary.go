@@ -0,0 +1,96 @@
+package booking
+
+import (
+	"bloomify/models"
+	"fmt"
+)
+
+// resolveBeneficiary determines who a booking is for and where it takes place.
+// bookingFor is either "self" or a household member ID; any other value is a
+// legacy free-form label and books for the user themselves. The service address
+// is, in order: the saved address named by addressID, the member's location, the
+// plan location, and finally the user's own location.
+func resolveBeneficiary(user *models.User, bookingFor, addressID string, planLocation models.GeoPoint) (models.Beneficiary, models.ServiceAddress, error) {
+	var (
+		beneficiary models.Beneficiary
+		address     models.ServiceAddress
+	)
+
+	if member, ok := user.FindHouseholdMember(bookingFor); ok {
+		beneficiary = models.Beneficiary{
+			MemberID:     member.ID,
+			Name:         member.Name,
+			Relationship: member.Relationship,
+			PhoneNumber:  member.PhoneNumber,
+			Address:      member.Address,
+			Location:     member.Location,
+			SpecialNotes: member.SpecialNotes,
+		}
+		address = models.ServiceAddress{FormattedAddress: member.Address, Location: member.Location}
+	} else {
+		beneficiary = models.Beneficiary{
+			Name:        user.Username,
+			PhoneNumber: user.PhoneNumber,
+		}
+	}
+
+	if addressID != "" {
+		saved, ok := user.FindAddress(addressID)
+		if !ok {
+			return beneficiary, address, fmt.Errorf("saved address %s not found", addressID)
+		}
+		address = models.ServiceAddress{
+			AddressID:        saved.ID,
+			Label:            saved.Label,
+			FormattedAddress: saved.FormattedAddress,
+			Location:         saved.Location,
+			Apartment:        saved.Apartment,
+			Instructions:     saved.Instructions,
+		}
+	}
+
+	if !isValidPoint(address.Location) {
+		address.Location = fallbackLocation(planLocation, user.Location)
+	}
+	beneficiary.Location = address.Location
+	if address.FormattedAddress != "" {
+		beneficiary.Address = address.FormattedAddress
+	}
+	return beneficiary, address, nil
+}
+
+// applyServiceLocation centers matching on the plan's saved address, or on a
+// household member's location when the plan is booked on their behalf.
+func (s *DefaultBookingSessionService) applyServiceLocation(plan models.ServicePlan, userID string) (models.ServicePlan, error) {
+	forMember := plan.BookingFor != "" && plan.BookingFor != models.BookingForSelf
+	if plan.AddressID == "" && !forMember {
+		return plan, nil
+	}
+	if s.SchedulerEngine == nil {
+		return plan, nil
+	}
+
+	user, err := s.SchedulerEngine.UserService.GetUserByID(userID)
+	if err != nil {
+		return plan, fmt.Errorf("failed to load user: %w", err)
+	}
+	_, address, err := resolveBeneficiary(user, plan.BookingFor, plan.AddressID, plan.LocationGeo)
+	if err != nil {
+		return plan, err
+	}
+	if isValidPoint(address.Location) {
+		plan.LocationGeo = address.Location
+	}
+	return plan, nil
+}
+
+func isValidPoint(p models.GeoPoint) bool {
+	return p.Type == "Point" && len(p.Coordinates) == 2
+}
+
+func fallbackLocation(primary, secondary models.GeoPoint) models.GeoPoint {
+	if isValidPoint(primary) {
+		return primary
+	}
+	return secondary
+}
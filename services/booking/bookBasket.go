@@ -0,0 +1,289 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	schedulerRepo "bloomify/database/repository/scheduler"
+	"bloomify/models"
+
+	"github.com/google/uuid"
+)
+
+// basketLeg is one priced, validated booking waiting to be committed with its basket.
+type basketLeg struct {
+	provider models.Provider
+	slot     models.TimeSlot
+	booking  *models.Booking
+	lines    []models.InvoiceLine
+}
+
+// BookBasket books every request in one transaction against a single payment.
+// If pricing, payment authorization, the transaction or the capture fails,
+// nothing is booked and the authorization and discounts are released.
+func (se *DefaultSchedulingEngine) BookBasket(basketID string, providers []models.Provider, reqs []models.BookingRequest, payment models.UserPayment) (*models.PublicBasketData, error) {
+	if len(reqs) == 0 || len(reqs) != len(providers) {
+		return nil, fmt.Errorf("basket has no bookings to confirm")
+	}
+	if se.PaymentHandler == nil {
+		return nil, errors.New("internal server error: PaymentHandler not initialized")
+	}
+
+	user, err := se.UserService.GetUserByID(reqs[0].UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 1. Validate and price every leg before touching payment or storage.
+	legs := make([]basketLeg, 0, len(reqs))
+	bookings := make([]*models.Booking, 0, len(reqs))
+	now := time.Now()
+	for i, req := range reqs {
+		if req.Subscription {
+			return nil, fmt.Errorf("item %d: subscriptions cannot be booked in a basket", i+1)
+		}
+		leg, err := se.prepareBasketLeg(providers[i], req, user)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		leg.booking.BasketID = basketID
+		leg.booking.CreatedAt = now
+		legs = append(legs, leg)
+		bookings = append(bookings, leg.booking)
+	}
+
+	// Discounts are redeemed once for the whole basket, before authorization.
+	discounts, err := se.reserveDiscounts(ctx, user.ID, reqs[0].PromoCode, bookings)
+	if err != nil {
+		return nil, fmt.Errorf("discount failed: %w", err)
+	}
+
+	var total float64
+	for i := range legs {
+		legs[i].lines, err = applyTaxes(legs[i].booking, legs[i].provider)
+		if err != nil {
+			se.releaseDiscounts(ctx, discounts)
+			return nil, fmt.Errorf("item %d: tax calculation failed: %w", i+1, err)
+		}
+		total += legs[i].booking.Quote.ChargeTotal
+	}
+	total = math.Round(total)
+
+	// 2. One payment authorization for the whole basket.
+	payReq := models.PaymentRequest{
+		UserID:          user.ID,
+		Amount:          total,
+		Currency:        payment.Currency,
+		Method:          payment.PaymentMethod,
+		PaymentIntentID: payment.PaymentIntentId,
+		Action:          "authorize",
+		Metadata:        map[string]string{"basketId": basketID},
+	}
+	if payment.PaymentMethod == "cash" {
+		payReq.Action = "record"
+	}
+	invoice, err := se.PaymentHandler.ProcessPayment(ctx, payReq)
+	if err != nil {
+		se.releaseDiscounts(ctx, discounts)
+		return nil, fmt.Errorf("payment authorization failed: %w", err)
+	}
+
+	repoLegs := make([]schedulerRepo.BookingLeg, 0, len(legs))
+	for _, leg := range legs {
+		leg.booking.Invoice = models.Invoice{
+			InvoiceID: invoice.InvoiceID,
+			UserID:    user.ID,
+			Amount:    leg.booking.Quote.ChargeTotal,
+			Currency:  invoice.Currency,
+			Method:    invoice.Method,
+			Status:    invoice.Status,
+			PaymentID: invoice.PaymentID,
+			CreatedAt: now,
+			Discounts: leg.booking.Discounts,
+			Lines:     leg.lines,
+		}
+		leg.booking.Status = invoice.Status
+		repoLegs = append(repoLegs, schedulerRepo.BookingLeg{
+			ProviderID: leg.provider.ID,
+			Date:       leg.slot.Date,
+			Slot:       leg.slot,
+			Booking:    leg.booking,
+		})
+	}
+
+	// 3. Commit all legs atomically; release the authorization if any leg fails.
+	if err := se.Repo.BookSlotsTransactionally(ctx, repoLegs); err != nil {
+		se.releaseBasketPayment(ctx, invoice)
+		se.releaseDiscounts(ctx, discounts)
+		return nil, fmt.Errorf("basket booking failed, no bookings were made: %w", err)
+	}
+
+	// 4. Capture once for the whole basket; undo every leg if it fails.
+	if invoice.Method == "card" {
+		captureReq := models.PaymentRequest{
+			UserID:          user.ID,
+			Method:          "card",
+			PaymentIntentID: invoice.PaymentID,
+			Action:          "capture",
+			Amount:          total,
+		}
+		if _, err := se.PaymentHandler.ProcessPayment(ctx, captureReq); err != nil {
+			log.Printf("[BookBasket] Capture failed for basket %s: %v", basketID, err)
+			se.releaseBasketPayment(ctx, invoice)
+			if uerr := se.Repo.UnbookSlotsTransactionally(ctx, repoLegs); uerr != nil {
+				log.Printf("[BookBasket] Failed to undo bookings of basket %s: %v", basketID, uerr)
+			}
+			se.releaseDiscounts(ctx, discounts)
+			return nil, fmt.Errorf("payment capture failed, no bookings were made: %w", err)
+		}
+		invoice.Status = "confirmed"
+		for _, leg := range legs {
+			leg.booking.Status = invoice.Status
+			leg.booking.Invoice.Status = invoice.Status
+			if err := se.Repo.UpdateBooking(leg.booking.ID, leg.booking); err != nil {
+				log.Printf("[BookBasket] Failed to update status of booking %s: %v", leg.booking.ID, err)
+			}
+		}
+	}
+	se.completeDiscounts(ctx, discounts)
+
+	// 5. Per-leg capacity bookkeeping and notifications.
+	result := &models.PublicBasketData{
+		BasketID: basketID,
+		Bookings: make([]models.PublicBookingData, 0, len(legs)),
+	}
+	invoice.Amount = total
+	invoice.Lines = nil
+	for _, leg := range legs {
+		invoice.Discounts = append(invoice.Discounts, leg.booking.Discounts...)
+		for _, line := range leg.lines {
+			line.BookingID = leg.booking.ID
+			invoice.Lines = append(invoice.Lines, line)
+		}
+	}
+	result.Invoice.PublicInvoice = models.ToPublicInvoice(*invoice)
+	for _, leg := range legs {
+		provider := leg.provider
+		se.recordServiceEarnings(ctx, leg.booking)
+		if invoice.Method == "card" {
+			publishPaymentCaptured(ctx, leg.booking, "booking", leg.booking.Invoice.Amount, invoice.Currency)
+		}
+		used := se.updateSlotCapacity(ctx, provider.ID, leg.slot.Date, leg.slot, leg.booking)
+		if ok := se.NotifyUserWithBookingStatus(provider, leg.booking, false); !ok {
+			log.Printf("[BookBasket] Failed to notify user for booking %s", leg.booking.ID)
+		}
+		if ok := se.UpdateProviderWithBookingNotification(&provider, leg.booking, leg.slot, used); !ok {
+			log.Printf("[BookBasket] Failed to notify provider %s", provider.ID)
+		}
+		se.ScheduleBookingReminders(ctx, leg.booking, provider)
+
+		result.Bookings = append(result.Bookings, models.ToPublicBookingData(*leg.booking))
+		result.Invoice.Lines = append(result.Invoice.Lines, models.BasketInvoiceLine{
+			BookingID:    leg.booking.ID,
+			ServiceType:  leg.booking.ServiceType,
+			ProviderName: provider.Profile.ProviderName,
+			Date:         leg.booking.Date,
+			Units:        leg.booking.Units,
+			UnitType:     leg.booking.UnitType,
+			CustomOption: leg.booking.CustomOption.Option,
+			Amount:       leg.booking.Invoice.Amount,
+		})
+	}
+
+	log.Printf("[BookBasket] Basket %s booked: %d bookings, total %.2f", basketID, len(legs), total)
+	return result, nil
+}
+
+// prepareBasketLeg loads, enriches and prices a single basket booking.
+func (se *DefaultSchedulingEngine) prepareBasketLeg(selected models.Provider, req models.BookingRequest, user *models.User) (basketLeg, error) {
+	if req.SlotID == "" {
+		return basketLeg{}, fmt.Errorf("missing slot ID")
+	}
+
+	slot, err := se.TimeslotsRepo.GetTimeSlotByID(selected.ID, req.SlotID, req.Date, req.Start, req.End)
+	if err != nil {
+		return basketLeg{}, err
+	}
+	enrichedSlot, err := se.enrichSingleTimeSlot(*slot, selected)
+	if err != nil {
+		return basketLeg{}, err
+	}
+
+	providerPtr, err := se.ProviderRepo.GetByIDWithProjection(selected.ID, nil)
+	if err != nil {
+		return basketLeg{}, err
+	}
+	provider := *providerPtr
+
+	booking := &models.Booking{
+		ID:           uuid.New().String(),
+		ProviderID:   provider.ID,
+		UserID:       req.UserID,
+		TimeSlotID:   enrichedSlot.ID,
+		Date:         slot.Date,
+		Start:        req.Start,
+		End:          req.End,
+		Units:        req.Units,
+		UnitType:     enrichedSlot.UnitType,
+		Priority:     req.Priority,
+		CustomOption: req.CustomOption,
+		UserPayment:  req.UserPayment,
+		ServiceType:  enrichedSlot.Catalogue.Service.ID,
+		CatalogueID:  enrichedSlot.Catalogue.ID,
+		CountryCode:  req.CountryCode,
+		Mode:         req.Mode,
+		UserMinimal: models.UserMinimal{
+			ID:           user.ID,
+			Username:     user.Username,
+			ProfileImage: user.ProfileImage,
+			Rating:       user.Rating,
+			Location:     user.Location,
+		},
+		MinimalProviderDTO: models.MinimalProviderDTO{
+			ID:           provider.ID,
+			ProviderName: provider.Profile.ProviderName,
+			ProfileImage: provider.Profile.ProfileImage,
+			Location:     provider.Profile.LocationGeo,
+			Rating:       provider.Profile.Rating,
+			Verified:     provider.Profile.AdvancedVerified,
+		},
+	}
+	booking.Beneficiary, booking.ServiceAddress, err = resolveBeneficiary(user, req.BookingFor, req.AddressID, req.ServiceLocation)
+	if err != nil {
+		return basketLeg{}, err
+	}
+	booking.ServiceLocation = booking.ServiceAddress.Location
+	booking.UserMinimal.Location = booking.ServiceLocation
+
+	confirmation, err := ValidateAndBook(provider.ID, enrichedSlot, *booking, &req.CustomOption, req.QuoteID, provider)
+	if err != nil {
+		return basketLeg{}, fmt.Errorf("validation failed: %w", err)
+	}
+	booking.TotalPrice = confirmation.TotalPrice
+	applyQuote(booking, confirmation.Quote)
+
+	return basketLeg{provider: provider, slot: enrichedSlot, booking: booking}, nil
+}
+
+func (se *DefaultSchedulingEngine) releaseBasketPayment(ctx context.Context, invoice *models.Invoice) {
+	if invoice.Method != "card" || invoice.PaymentID == "" {
+		return
+	}
+	cancelReq := models.PaymentRequest{
+		UserID:          invoice.UserID,
+		Method:          "card",
+		PaymentIntentID: invoice.PaymentID,
+		Action:          "cancel",
+		Amount:          invoice.Amount,
+	}
+	if _, err := se.PaymentHandler.ProcessPayment(ctx, cancelReq); err != nil {
+		log.Printf("[BookBasket] Failed to release payment %s: %v", invoice.PaymentID, err)
+	}
+}
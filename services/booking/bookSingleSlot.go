@@ -19,6 +19,8 @@ func (se *DefaultSchedulingEngine) bookSingleSlot(
 	slot models.TimeSlot,
 	booking *models.Booking,
 	customOption models.CustomOptionResponse,
+	quoteID string,
+	promoCode string,
 ) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -34,7 +36,7 @@ func (se *DefaultSchedulingEngine) bookSingleSlot(
 	}
 
 	log.Printf("[bookSingleSlot] Validating and pricing booking for provider %s, slot %s", provider.ID, slot.ID)
-	confirmation, err := ValidateAndBook(provider.ID, slot, *booking, &customOption, provider)
+	confirmation, err := ValidateAndBook(provider.ID, slot, *booking, &customOption, quoteID, provider)
 	if err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
@@ -47,6 +49,7 @@ func (se *DefaultSchedulingEngine) bookSingleSlot(
 	booking.CreatedAt = now
 	booking.TotalPrice = confirmation.TotalPrice
 	booking.TimeSlotID = slot.ID
+	applyQuote(booking, confirmation.Quote)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -56,22 +59,63 @@ func (se *DefaultSchedulingEngine) bookSingleSlot(
 		return errors.New("internal server error: PaymentHandler not initialized")
 	}
 
+	// Discounts are part of the price, so they are redeemed before payment is taken.
+	discounts, err := se.reserveDiscounts(ctx, booking.UserID, promoCode, []*models.Booking{booking})
+	if err != nil {
+		return fmt.Errorf("discount failed: %w", err)
+	}
+	lines, err := applyTaxes(booking, provider)
+	if err != nil {
+		se.releaseDiscounts(ctx, discounts)
+		return fmt.Errorf("tax calculation failed: %w", err)
+	}
+
+	// Providers taking deposits charge the card in two parts: the deposit now and
+	// the balance shortly before the service. Other card bookings must have the
+	// whole charge authorized before the slot is taken.
+	if plan := depositPlan(booking, provider, now); plan != nil {
+		if err := se.authorizeDeposit(ctx, booking, plan); err != nil {
+			se.releaseDiscounts(ctx, discounts)
+			return fmt.Errorf("deposit authorization failed: %w", err)
+		}
+		booking.PaymentPlan = plan
+	} else if booking.UserPayment.PaymentMethod == "card" {
+		authReq := models.PaymentRequest{
+			UserID:          booking.UserID,
+			Amount:          booking.Quote.ChargeTotal,
+			Currency:        booking.Quote.ChargeCurrency,
+			Method:          "card",
+			PaymentIntentID: booking.UserPayment.PaymentIntentId,
+			Action:          "authorize",
+			Metadata: map[string]string{
+				"providerId": provider.ID,
+				"slotId":     slot.ID,
+			},
+		}
+		if _, err := se.PaymentHandler.ProcessPayment(ctx, authReq); err != nil {
+			se.releaseDiscounts(ctx, discounts)
+			return fmt.Errorf("payment authorization failed: %w", err)
+		}
+	}
+
 	invoice := &models.Invoice{
 		InvoiceID: uuid.New().String(),
 		UserID:    booking.UserID,
-		Amount:    confirmation.TotalPrice,
-		Currency:  booking.UserPayment.Currency,
+		Amount:    booking.Quote.ChargeTotal,
+		Currency:  booking.Quote.ChargeCurrency,
 		Method:    booking.UserPayment.PaymentMethod,
 		Status:    "requires_capture",
 		PaymentID: booking.UserPayment.PaymentIntentId,
 		CreatedAt: now,
+		Discounts: booking.Discounts,
+		Lines:     lines,
 	}
 
 	if invoice.Method == "cash" {
 		payReq := models.PaymentRequest{
 			UserID:   booking.UserID,
-			Amount:   confirmation.TotalPrice,
-			Currency: booking.UserPayment.Currency,
+			Amount:   booking.Quote.ChargeTotal,
+			Currency: booking.Quote.ChargeCurrency,
 			Method:   "cash",
 			Action:   "record",
 			Metadata: map[string]string{
@@ -82,8 +126,11 @@ func (se *DefaultSchedulingEngine) bookSingleSlot(
 		}
 		invoice, err = se.PaymentHandler.ProcessPayment(ctx, payReq)
 		if err != nil {
+			se.releaseDiscounts(ctx, discounts)
 			return fmt.Errorf("cash payment failed: %w", err)
 		}
+		invoice.Discounts = booking.Discounts
+		invoice.Lines = lines
 	}
 
 	booking.Invoice = *invoice
@@ -98,8 +145,10 @@ func (se *DefaultSchedulingEngine) bookSingleSlot(
 			}
 			_, _ = se.PaymentHandler.ProcessPayment(ctx, cancelReq)
 		}
+		se.releaseDiscounts(ctx, discounts)
 		return fmt.Errorf("booking transaction failed: %w", err)
 	}
+	se.completeDiscounts(ctx, discounts)
 
 	var paymentCaptureFailed bool
 	if invoice.Method == "card" {
@@ -110,7 +159,11 @@ func (se *DefaultSchedulingEngine) bookSingleSlot(
 			Action:          "capture",
 			Amount:          invoice.Amount,
 		}
-		_, err = se.PaymentHandler.ProcessPayment(ctx, captureReq)
+		// Deposit bookings capture only the deposit; the balance is charged later.
+		if booking.PaymentPlan != nil {
+			captureReq.Amount = booking.PaymentPlan.DepositAmount
+		}
+		captured, err := se.PaymentHandler.ProcessPayment(ctx, captureReq)
 		if err != nil {
 			paymentCaptureFailed = true
 			booking.Status = "payment_required"
@@ -123,39 +176,76 @@ func (se *DefaultSchedulingEngine) bookSingleSlot(
 			_, _ = se.PaymentHandler.ProcessPayment(ctx, cancelReq)
 		} else {
 			booking.Status = "confirmed"
+			kind := "booking"
+			if booking.PaymentPlan != nil {
+				kind = "deposit"
+				se.startPaymentPlan(ctx, booking)
+			}
+			publishPaymentCaptured(ctx, booking, kind, captured.Amount, captured.Currency)
 		}
 	}
+	if !paymentCaptureFailed && booking.PaymentPlan == nil {
+		se.recordServiceEarnings(ctx, booking)
+	}
 
 	// Handle user notifications
 	if ok := se.NotifyUserWithBookingStatus(provider, booking, paymentCaptureFailed); !ok {
 		log.Printf("[bookSingleSlot] Failed to notify user with booking status")
 	}
 
-	// Check capacity usage
-	var used int
-	if slot.CapacityMode == models.CapacitySingleUse {
-		if err := se.TimeslotsRepo.SetTimeSlotBlockReason(ctx, provider.ID, slot.ID, date, true, "booked exclusively"); err != nil {
-			log.Printf("[bookSingleSlot] Failed to block slot: %v", err)
-		}
-	} else {
-		used, err = se.Repo.SumOverlappingBookings(provider.ID, date, slot.Start, slot.End, &booking.Priority)
-		if err != nil {
-			log.Printf("[bookSingleSlot] Capacity check error: %v", err)
-		} else {
-			log.Printf("[bookSingleSlot] Capacity usage: %d/%d", used, slot.Capacity)
-			if used >= slot.Capacity {
-				if err := se.TimeslotsRepo.SetTimeSlotBlockReason(ctx, provider.ID, slot.ID, date, true, "capacity full"); err != nil {
-					log.Printf("[bookSingleSlot] Failed to block slot: %v", err)
-				}
-			}
-		}
-	}
+	used := se.updateSlotCapacity(ctx, provider.ID, date, slot, booking)
 
 	// Notify provider
 	if ok := se.UpdateProviderWithBookingNotification(&provider, booking, slot, used); !ok {
 		log.Printf("[bookSingleSlot] Failed to update provider with booking notification")
 	}
+	// Reminders are only sent for bookings that are paid for.
+	if !paymentCaptureFailed {
+		se.ScheduleBookingReminders(ctx, booking, provider)
+	}
 
 	log.Printf("[bookSingleSlot] Booking complete. ID: %s", booking.ID)
 	return nil
 }
+
+// applyQuote records the priced quote on the booking. Units are trimmed to what
+// the quote covers, and the booking is a priority booking if any unit comes from
+// the urgency priority pool.
+func applyQuote(booking *models.Booking, quote *models.PriceQuote) {
+	if quote == nil {
+		return
+	}
+	booking.Units = quote.Units()
+	booking.PriorityUnits = quote.PriorityUnits
+	booking.Priority = quote.PriorityUnits > 0
+	booking.Quote = quote
+}
+
+// updateSlotCapacity blocks the slot once it is exclusively booked or full and
+// returns the units used so far.
+func (se *DefaultSchedulingEngine) updateSlotCapacity(ctx context.Context, providerID, date string, slot models.TimeSlot, booking *models.Booking) int {
+	var used int
+	if slot.CapacityMode == models.CapacitySingleUse {
+		if err := se.TimeslotsRepo.SetTimeSlotBlockReason(ctx, providerID, slot.ID, date, true, "booked exclusively"); err != nil {
+			log.Printf("[bookSingleSlot] Failed to block slot: %v", err)
+		} else {
+			publishSlotFilled(ctx, providerID, date, slot, booking, "booked exclusively")
+		}
+		return used
+	}
+
+	used, err := se.Repo.SumOverlappingBookings(providerID, date, slot.Start, slot.End, &booking.Priority)
+	if err != nil {
+		log.Printf("[bookSingleSlot] Capacity check error: %v", err)
+		return used
+	}
+	log.Printf("[bookSingleSlot] Capacity usage: %d/%d", used, slot.Capacity)
+	if used >= slot.Capacity {
+		if err := se.TimeslotsRepo.SetTimeSlotBlockReason(ctx, providerID, slot.ID, date, true, "capacity full"); err != nil {
+			log.Printf("[bookSingleSlot] Failed to block slot: %v", err)
+		} else {
+			publishSlotFilled(ctx, providerID, date, slot, booking, "capacity full")
+		}
+	}
+	return used
+}
@@ -60,6 +60,9 @@ func (se *DefaultSchedulingEngine) bookSubscriptionSlots(
 				}
 
 				for _, ts := range daySlots {
+					if ts.CatalogueID != "" && baseBooking.CatalogueID != "" && ts.CatalogueID != baseBooking.CatalogueID {
+						continue
+					}
 					if ts.Start == baseBooking.Start && ts.End == baseBooking.End {
 						selectedSlot = &ts
 						break
@@ -76,7 +79,13 @@ func (se *DefaultSchedulingEngine) bookSubscriptionSlots(
 				newB.Date = dateStr
 				newB.CreatedAt = time.Now()
 
-				err = se.bookSingleSlot(provider, dateStr, *selectedSlot, &newB, baseBooking.CustomOption)
+				enrichedSlot, enrichErr := se.enrichSingleTimeSlot(*selectedSlot, provider)
+				if enrichErr != nil {
+					err = enrichErr
+					break
+				}
+
+				err = se.bookSingleSlot(provider, dateStr, enrichedSlot, &newB, baseBooking.CustomOption, "", "")
 				if err == nil {
 					// Store only the first successful booking
 					once.Do(func() {
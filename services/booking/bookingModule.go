@@ -19,20 +19,36 @@ type SubscriptionBookingResult struct {
 func (se *DefaultSchedulingEngine) BookSlot(provider models.Provider, req models.BookingRequest) (*models.PublicBookingData, error) {
 	log.Printf("[BookSlot] Starting booking process for user %s with provider %s", req.UserID, provider.ID)
 
+	user, err := se.UserService.GetUserByID(req.UserID)
+	if err != nil {
+		log.Printf("[BookSlot] Failed to fetch user %s: %v", req.UserID, err)
+		return nil, err
+	}
+	beneficiary, serviceAddress, err := resolveBeneficiary(user, req.BookingFor, req.AddressID, req.ServiceLocation)
+	if err != nil {
+		return nil, err
+	}
+
 	if req.Subscription {
 		log.Printf("[BookSlot] Detected subscription booking")
 		baseBooking := models.Booking{
-			ID:           uuid.New().String(),
-			ProviderID:   provider.ID,
-			UserID:       req.UserID,
-			Units:        req.Units,
-			Start:        req.Start,
-			End:          req.End,
-			UnitType:     req.UnitType,
-			Priority:     req.Priority,
-			CustomOption: req.CustomOption,
-			UserPayment:  req.UserPayment,
-			Mode:         req.Mode,
+			ID:              uuid.New().String(),
+			ProviderID:      provider.ID,
+			UserID:          req.UserID,
+			Units:           req.Units,
+			Start:           req.Start,
+			End:             req.End,
+			UnitType:        req.UnitType,
+			Priority:        req.Priority,
+			CustomOption:    req.CustomOption,
+			UserPayment:     req.UserPayment,
+			Mode:            req.Mode,
+			ServiceType:     provider.ServiceCatalogue.Service.ID,
+			CatalogueID:     provider.ServiceCatalogue.ID,
+			CountryCode:     req.CountryCode,
+			Beneficiary:     beneficiary,
+			ServiceLocation: serviceAddress.Location,
+			ServiceAddress:  serviceAddress,
 		}
 		return se.bookSubscriptionSlots(provider, baseBooking, req.SubscriptionDetails)
 	}
@@ -50,7 +66,10 @@ func (se *DefaultSchedulingEngine) BookSlot(provider models.Provider, req models
 	log.Printf("[BookSlot] Found timeslot: %+v", *selectedSlot)
 
 	// Enrich with latest provider data
-	enrichedSlot := se.enrichSingleTimeSlot(*selectedSlot, provider)
+	enrichedSlot, err := se.enrichSingleTimeSlot(*selectedSlot, provider)
+	if err != nil {
+		return nil, err
+	}
 	log.Printf("[BookSlot] Enriched slot options: %+v", enrichedSlot.Catalogue.CustomOptions)
 
 	valid := false
@@ -64,12 +83,6 @@ func (se *DefaultSchedulingEngine) BookSlot(provider models.Provider, req models
 		return nil, fmt.Errorf("invalid custom option %q", req.CustomOption.Option)
 	}
 
-	user, err := se.UserService.GetUserByID(req.UserID)
-	if err != nil {
-		log.Printf("[NotifyUserWithBookingStatus] Failed to fetch user %s: %v", req.UserID, err)
-		return nil, err
-	}
-
 	providerPtr, err := se.ProviderRepo.GetByIDWithProjection(req.ProviderID, nil)
 	if err != nil {
 		log.Printf("[BookSlot] Failed to fetch provider %s: %v", req.ProviderID, err)
@@ -90,14 +103,15 @@ func (se *DefaultSchedulingEngine) BookSlot(provider models.Provider, req models
 		CustomOption: req.CustomOption,
 		UserPayment:  req.UserPayment,
 		ServiceType:  enrichedSlot.Catalogue.Service.ID,
+		CatalogueID:  enrichedSlot.Catalogue.ID,
+		CountryCode:  req.CountryCode,
 		Mode:         req.Mode,
 		UserMinimal: models.UserMinimal{
 			ID:           user.ID,
 			Username:     user.Username,
 			ProfileImage: user.ProfileImage,
 			Rating:       user.Rating,
-			Location:     user.Location,
-			PhoneNumber:  user.PhoneNumber,
+			Location:     serviceAddress.Location,
 		},
 		MinimalProviderDTO: models.MinimalProviderDTO{
 			ID:           req.ProviderID,
@@ -107,11 +121,14 @@ func (se *DefaultSchedulingEngine) BookSlot(provider models.Provider, req models
 			Rating:       provider.Profile.Rating,
 			Verified:     provider.Profile.AdvancedVerified,
 		},
+		Beneficiary:     beneficiary,
+		ServiceLocation: serviceAddress.Location,
+		ServiceAddress:  serviceAddress,
 	}
 
 	log.Printf("[BookSlot] Creating booking record: %+v", booking)
 
-	if err := se.bookSingleSlot(provider, selectedSlot.Date, enrichedSlot, booking, req.CustomOption); err != nil {
+	if err := se.bookSingleSlot(provider, selectedSlot.Date, enrichedSlot, booking, req.CustomOption, req.QuoteID, req.PromoCode); err != nil {
 		log.Printf("[BookSlot] Error booking slot: %v", err)
 		return nil, err
 	}
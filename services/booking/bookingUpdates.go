@@ -3,6 +3,7 @@ package booking
 
 import (
 	"bloomify/models"
+	"bloomify/utils"
 	"context"
 	"fmt"
 	"log"
@@ -22,6 +23,13 @@ func formatBookingDateTime(dateStr string, minutesFromMidnight int) (string, err
 	return bookingTime.Format("2 January, 3:04 PM"), nil
 }
 
+// bookingStartTime returns the wall-clock time minutesFromMidnight into dateStr;
+// callers have already validated the date through formatBookingDateTime.
+func bookingStartTime(dateStr string, minutesFromMidnight int) time.Time {
+	bookingDate, _ := time.Parse("2006-01-02", dateStr)
+	return bookingDate.Add(time.Duration(minutesFromMidnight) * time.Minute)
+}
+
 func (se *DefaultSchedulingEngine) NotifyUserWithBookingStatus(
 	provider models.Provider,
 	booking *models.Booking,
@@ -41,30 +49,27 @@ func (se *DefaultSchedulingEngine) NotifyUserWithBookingStatus(
 		return false
 	}
 
-	var title, message, notificationType string
+	var notificationType string
 	var actionRequired bool
 
 	if paymentCaptureFailed {
-		title = "Payment Needed for Your Booking"
-		message = fmt.Sprintf("Your booking with %s is confirmed but we couldn't process your payment. Please update your payment method to secure your appointment on %s.",
-			provider.Profile.ProviderName, formattedDateTime)
-		notificationType = "payment_required"
+		notificationType = utils.TemplatePaymentRequired
 		actionRequired = true
 	} else {
-		title = "Booking Confirmed!"
-		notificationType = "booking_confirmed"
+		notificationType = utils.TemplateBookingConfirmed
 		actionRequired = false
+	}
 
-		message = fmt.Sprintf("Your appointment with %s on %s has been confirmed.",
-			provider.Profile.ProviderName, formattedDateTime)
-
-		if booking.UserPayment.PaymentMethod == "cash" {
-			message += fmt.Sprintf(" Please have %s %.2f in cash on arrival.",
-				booking.UserPayment.Currency, booking.TotalPrice)
-		} else {
-			message += fmt.Sprintf(" We've successfully processed your payment of %s %.2f.",
-				booking.UserPayment.Currency, booking.TotalPrice)
-		}
+	title, message, err := utils.RenderNotification(notificationType, user.PreferredLanguage, utils.TemplateParams{
+		ProviderName:  provider.Profile.ProviderName,
+		When:          bookingStartTime(booking.Date, booking.Start),
+		Amount:        booking.Invoice.Amount,
+		Currency:      booking.Invoice.Currency,
+		PaymentMethod: booking.UserPayment.PaymentMethod,
+	})
+	if err != nil {
+		log.Printf("[NotifyUserWithBookingStatus] Failed to render notification: %v", err)
+		return false
 	}
 
 	notification := models.Notification{
@@ -82,8 +87,8 @@ func (se *DefaultSchedulingEngine) NotifyUserWithBookingStatus(
 				"name":  provider.Profile.ProviderName,
 				"image": provider.Profile.ProfileImage,
 			},
-			"amount":         booking.TotalPrice,
-			"currency":       booking.UserPayment.Currency,
+			"amount":         booking.Invoice.Amount,
+			"currency":       booking.Invoice.Currency,
 			"status":         booking.Status,
 			"actionRequired": actionRequired,
 			"role":           "user",
@@ -92,19 +97,20 @@ func (se *DefaultSchedulingEngine) NotifyUserWithBookingStatus(
 		Read:      false,
 	}
 
-	user.Notifications = append(user.Notifications, notification)
 	user.UpdatedAt = time.Now()
 
 	updateReq := models.UserUpdateRequest{
 		ID:             &user.ID,
 		ActiveBookings: &user.ActiveBookings,
-		Notifications:  &user.Notifications,
 		UpdatedAt:      &user.UpdatedAt,
 	}
 	if _, err := se.UserService.UpdateUser(updateReq); err != nil {
 		log.Printf("[NotifyUserWithBookingStatus] Failed to update user %s: %v", user.ID, err)
 		return false
 	}
+	if err := se.Notification.AddToInbox(context.Background(), "user", user.ID, notification); err != nil {
+		log.Printf("[NotifyUserWithBookingStatus] Failed to store notification for user %s: %v", user.ID, err)
+	}
 
 	// Determine geo for push notification
 	var locationGeo *models.GeoPoint
@@ -129,7 +135,7 @@ func (se *DefaultSchedulingEngine) NotifyUserWithBookingStatus(
 			data["latitude"] = fmt.Sprintf("%f", locationGeo.Coordinates[1])
 		}
 
-		err := se.Notification.SendUserPushNotification(
+		err := se.Notification.NotifyUser(
 			context.Background(),
 			user.ID,
 			title,
@@ -137,7 +143,7 @@ func (se *DefaultSchedulingEngine) NotifyUserWithBookingStatus(
 			data,
 		)
 		if err != nil {
-			log.Printf("[PushNotification] Failed to send user %s push notification: %v", user.ID, err)
+			log.Printf("[Notification] Failed to notify user %s: %v", user.ID, err)
 		}
 	}()
 
@@ -155,16 +161,6 @@ func (se *DefaultSchedulingEngine) UpdateProviderWithBookingNotification(
 		log.Printf("[UpdateProviderWithBookingNotification] Could not determine remaining units for slot %s", slot.ID)
 		return false
 	}
-	startHour := slot.Start / 60
-	startMin := slot.Start % 60
-	endHour := slot.End / 60
-	endMin := slot.End % 60
-
-	startTime := time.Date(0, 1, 1, startHour, startMin, 0, 0, time.UTC)
-	endTime := time.Date(0, 1, 1, endHour, endMin, 0, 0, time.UTC)
-
-	timeRange := fmt.Sprintf("%s - %s", startTime.Format("3:04 PM"), endTime.Format("3:04 PM"))
-
 	user, err := se.UserService.GetUserByID(booking.UserID)
 	if err != nil {
 		log.Printf("[UpdateProviderWithBookingNotification] Failed to fetch user %s: %v", booking.UserID, err)
@@ -177,15 +173,21 @@ func (se *DefaultSchedulingEngine) UpdateProviderWithBookingNotification(
 		return false
 	}
 
-	title := "New Booking Received"
-	message := fmt.Sprintf(
-		"%s booked your service for %s at %s. %d %s remaining in this schedule slot.",
-		user.Username, formattedDateTime, timeRange, remaining, booking.UnitType,
-	)
+	title, message, err := utils.RenderNotification(utils.TemplateNewBooking, provider.PreferredLanguage, utils.TemplateParams{
+		CustomerName: user.Username,
+		When:         bookingStartTime(booking.Date, slot.Start),
+		Until:        bookingStartTime(booking.Date, slot.End),
+		Count:        remaining,
+		Units:        booking.UnitType,
+	})
+	if err != nil {
+		log.Printf("[UpdateProviderWithBookingNotification] Failed to render notification: %v", err)
+		return false
+	}
 
 	notification := models.Notification{
 		ID:      uuid.New().String(),
-		Type:    "new_booking",
+		Type:    utils.TemplateNewBooking,
 		Title:   title,
 		Message: message,
 		Data: map[string]any{
@@ -207,7 +209,6 @@ func (se *DefaultSchedulingEngine) UpdateProviderWithBookingNotification(
 				"username":     user.Username,
 				"profileImage": user.ProfileImage,
 				"rating":       user.Rating,
-				"phoneNumber":  user.PhoneNumber,
 			},
 		},
 		CreatedAt: time.Now(),
@@ -224,20 +225,42 @@ func (se *DefaultSchedulingEngine) UpdateProviderWithBookingNotification(
 			Username:     user.Username,
 			ProfileImage: user.ProfileImage,
 			Rating:       user.Rating,
-			PhoneNumber:  user.PhoneNumber,
 		},
 	}
 
+	// The provider works at the beneficiary's location, which may not be the payer's.
+	serviceLocation := fallbackLocation(booking.ServiceLocation, user.Location)
+	activeBooking.Beneficiary = booking.Beneficiary
 	if booking.Mode == "in_home" {
-		activeBooking.User.Location = user.Location
-		notification.Data["user"].(map[string]any)["location"] = user.Location
+		activeBooking.User.Location = serviceLocation
+		activeBooking.Beneficiary.Location = serviceLocation
+		activeBooking.ServiceAddress = booking.ServiceAddress
+		activeBooking.ServiceAddress.Location = serviceLocation
+		notification.Data["user"].(map[string]any)["location"] = serviceLocation
+		notification.Data["serviceAddress"] = map[string]any{
+			"label":            booking.ServiceAddress.Label,
+			"formattedAddress": booking.ServiceAddress.FormattedAddress,
+			"apartment":        booking.ServiceAddress.Apartment,
+			"instructions":     booking.ServiceAddress.Instructions,
+			"location":         serviceLocation,
+		}
+	} else {
+		activeBooking.Beneficiary.Location = models.GeoPoint{}
+		activeBooking.Beneficiary.Address = ""
+	}
+	if booking.Beneficiary.MemberID != "" {
+		notification.Data["beneficiary"] = map[string]any{
+			"name":         booking.Beneficiary.Name,
+			"relationship": booking.Beneficiary.Relationship,
+			"address":      activeBooking.Beneficiary.Address,
+			"specialNotes": booking.Beneficiary.SpecialNotes,
+		}
 	}
 
 	now := time.Now()
 
 	// Push to MongoDB
 	pushDoc := bson.M{
-		"notifications":  notification,
 		"activeBookings": activeBooking,
 	}
 	err = se.ProviderRepo.UpdatePushDocument(provider.ID, pushDoc)
@@ -245,6 +268,14 @@ func (se *DefaultSchedulingEngine) UpdateProviderWithBookingNotification(
 		log.Printf("[UpdateProviderWithBookingNotification] Failed to update provider %s: %v", provider.ID, err)
 		return false
 	}
+	if err := se.Notification.AddToInbox(context.Background(), "provider", provider.ID, notification); err != nil {
+		log.Printf("[UpdateProviderWithBookingNotification] Failed to store notification for provider %s: %v", provider.ID, err)
+	}
+	PublishProviderEvent(context.Background(), provider.ID, models.ProviderEvent{
+		Type:      models.ProviderEventNewBooking,
+		BookingID: booking.ID,
+		Data:      notification.Data,
+	})
 
 	setDoc := bson.M{
 		"updatedAt": now,
@@ -254,20 +285,23 @@ func (se *DefaultSchedulingEngine) UpdateProviderWithBookingNotification(
 	}
 
 	userDetails := map[string]string{
-		"userId":      user.ID,
-		"username":    user.Username,
-		"phoneNumber": user.PhoneNumber,
-		"rating":      fmt.Sprintf("%d", user.Rating),
+		"userId":   user.ID,
+		"username": user.Username,
+		"rating":   fmt.Sprintf("%d", user.Rating),
 	}
 
-	if booking.Mode == "in_home" && len(user.Location.Coordinates) == 2 {
-		userDetails["locationLongitude"] = fmt.Sprintf("%f", user.Location.Coordinates[0])
-		userDetails["locationLatitude"] = fmt.Sprintf("%f", user.Location.Coordinates[1])
+	if booking.Mode == "in_home" && len(serviceLocation.Coordinates) == 2 {
+		userDetails["locationLongitude"] = fmt.Sprintf("%f", serviceLocation.Coordinates[0])
+		userDetails["locationLatitude"] = fmt.Sprintf("%f", serviceLocation.Coordinates[1])
+	}
+	if booking.Beneficiary.MemberID != "" {
+		userDetails["beneficiaryName"] = booking.Beneficiary.Name
+		userDetails["beneficiaryRelationship"] = booking.Beneficiary.Relationship
 	}
 
 	go func() {
 		notificationData := map[string]string{
-			"type":           "new_booking",
+			"type":           utils.TemplateNewBooking,
 			"bookingId":      booking.ID,
 			"date":           booking.Date,
 			"time":           fmt.Sprintf("%d", booking.Start),
@@ -279,7 +313,7 @@ func (se *DefaultSchedulingEngine) UpdateProviderWithBookingNotification(
 		}
 		maps.Copy(notificationData, userDetails)
 
-		err := se.Notification.SendProviderPushNotification(
+		err := se.Notification.NotifyProvider(
 			context.Background(),
 			provider.ID,
 			title,
@@ -287,7 +321,7 @@ func (se *DefaultSchedulingEngine) UpdateProviderWithBookingNotification(
 			notificationData,
 		)
 		if err != nil {
-			log.Printf("[PushNotification] Failed to send provider %s notification: %v", provider.ID, err)
+			log.Printf("[Notification] Failed to notify provider %s: %v", provider.ID, err)
 		}
 	}()
 
@@ -0,0 +1,384 @@
+package booking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"bloomify/config"
+	chatRepo "bloomify/database/repository/chat"
+	"bloomify/models"
+	"bloomify/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	chatChannelPrefix = "chat:events:"
+	// chatAttachmentURLExpiry is how long the signed links of chat images work.
+	chatAttachmentURLExpiry = 30 * time.Minute
+	maxChatMessageLength    = 2000
+)
+
+// Chat images may be one of these types.
+var chatImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+var (
+	// ErrChatUnavailable is returned when no chat store is configured.
+	ErrChatUnavailable = errors.New("chat is not available")
+	// ErrChatClosed is returned when sending to the chat of a cancelled booking,
+	// or after CHAT_CLOSE_HOURS have passed since it was completed.
+	ErrChatClosed = errors.New("this booking's chat is closed")
+	// ErrChatMessageInvalid is returned for empty or overlong messages.
+	ErrChatMessageInvalid = fmt.Errorf("a message needs text of at most %d characters or an image", maxChatMessageLength)
+	// ErrChatImageInvalid is returned for attachments that are not JPEG, PNG or
+	// WebP images within CHAT_MAX_IMAGE_MB.
+	ErrChatImageInvalid = errors.New("attachments must be JPEG, PNG or WebP images within the size limit")
+	// ErrChatMessageNotFound is returned when reporting a message the thread does
+	// not have.
+	ErrChatMessageNotFound = errors.New("chat message not found")
+)
+
+// ChatUpload is an image attached to a message, saved to a temporary file.
+type ChatUpload struct {
+	Path        string
+	ContentType string
+	Size        int64
+}
+
+// chatBooking loads a booking the caller takes part in as role.
+func (svc *DefaultBookingSessionService) chatBooking(ctx context.Context, role, actorID, bookingID string) (*models.Booking, error) {
+	if svc.ChatRepo == nil {
+		return nil, ErrChatUnavailable
+	}
+	return svc.participantBooking(ctx, role, actorID, bookingID)
+}
+
+// participantBooking loads a booking the caller takes part in as role, "user"
+// or "provider".
+func (svc *DefaultBookingSessionService) participantBooking(ctx context.Context, role, actorID, bookingID string) (*models.Booking, error) {
+	b, err := svc.SchedulerEngine.Repo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, ErrBookingNotFound
+	}
+	owner := b.UserID
+	if role == models.ChatRoleProvider {
+		owner = b.ProviderID
+	}
+	if owner != actorID {
+		return nil, ErrNotBookingParticipant
+	}
+	return b, nil
+}
+
+// chatClosesAt is when a booking's chat stops accepting messages:
+// CHAT_CLOSE_HOURS after the completed check-in, or after the booking's end.
+func chatClosesAt(b *models.Booking) time.Time {
+	hours := config.AppConfig.ChatCloseHours
+	if hours <= 0 {
+		hours = 48
+	}
+	if done := findCheckIn(b, models.CheckInCompleted); done != nil {
+		return done.At.Add(time.Duration(hours) * time.Hour)
+	}
+	day, err := time.ParseInLocation("2006-01-02", b.Date, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return day.Add(time.Duration(b.End)*time.Minute + time.Duration(hours)*time.Hour)
+}
+
+func chatOpen(b *models.Booking, now time.Time) bool {
+	return b.Status != "cancelled" && now.Before(chatClosesAt(b))
+}
+
+// chatThread returns a booking's thread, or an empty one before the first
+// message, with the booking's details and whether it is open.
+func (svc *DefaultBookingSessionService) chatThread(ctx context.Context, b *models.Booking) (*models.ChatThread, error) {
+	thread, err := svc.ChatRepo.GetThread(ctx, b.ID)
+	if errors.Is(err, chatRepo.ErrThreadNotFound) {
+		thread, err = &models.ChatThread{BookingID: b.ID, UserID: b.UserID, ProviderID: b.ProviderID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	thread.ClosesAt = chatClosesAt(b)
+	thread.Open = chatOpen(b, time.Now())
+	thread.Booking = &models.BookingSketch{
+		Date:         b.Date,
+		Start:        b.Start,
+		End:          b.End,
+		ServiceType:  b.ServiceType,
+		Status:       b.Status,
+		UserName:     b.UserMinimal.Username,
+		ProviderName: b.MinimalProviderDTO.ProviderName,
+	}
+	return thread, nil
+}
+
+// signChatAttachments fills in short-lived links to the images of messages.
+func (svc *DefaultBookingSessionService) signChatAttachments(ctx context.Context, messages []models.ChatMessage) {
+	if svc.Storage == nil {
+		return
+	}
+	for i := range messages {
+		for j := range messages[i].Attachments {
+			a := &messages[i].Attachments[j]
+			url, err := svc.Storage.GetSecureDownloadURL(ctx, a.ObjectPath, chatAttachmentURLExpiry)
+			if err != nil {
+				log.Printf("[Chat] Failed to sign attachment of message %s: %v", messages[i].ID, err)
+				continue
+			}
+			a.URL = url
+		}
+	}
+}
+
+// ListChats returns a page of the caller's chat threads, most recently active
+// first.
+func (svc *DefaultBookingSessionService) ListChats(role, actorID string, page, limit int64) (*models.ChatThreadPage, error) {
+	if svc.ChatRepo == nil {
+		return nil, ErrChatUnavailable
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := models.ChatThreadFilter{UserID: actorID}
+	if role == models.ChatRoleProvider {
+		filter = models.ChatThreadFilter{ProviderID: actorID}
+	}
+	return svc.ChatRepo.ListThreads(ctx, filter, page, limit)
+}
+
+// GetChat returns a booking's thread with up to limit messages sent before
+// before, newest first.
+func (svc *DefaultBookingSessionService) GetChat(role, actorID, bookingID string, before time.Time, limit int64) (*models.ChatConversation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	b, err := svc.chatBooking(ctx, role, actorID, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := svc.chatThread(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := svc.ChatRepo.ListMessages(ctx, b.ID, before, limit, false)
+	if err != nil {
+		return nil, err
+	}
+	svc.signChatAttachments(ctx, messages)
+	return &models.ChatConversation{Thread: *thread, Messages: messages}, nil
+}
+
+// SendChatMessage posts a message with optional image to a booking's chat,
+// pushes it to the other side and publishes it on the thread's stream.
+func (svc *DefaultBookingSessionService) SendChatMessage(role, actorID, bookingID, text string, upload *ChatUpload) (*models.ChatMessage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	text = strings.TrimSpace(text)
+	if (text == "" && upload == nil) || len([]rune(text)) > maxChatMessageLength {
+		return nil, ErrChatMessageInvalid
+	}
+	if upload != nil {
+		maxMB := config.AppConfig.ChatMaxImageMB
+		if maxMB <= 0 {
+			maxMB = 5
+		}
+		if !chatImageTypes[upload.ContentType] || upload.Size <= 0 || upload.Size > int64(maxMB)<<20 {
+			return nil, ErrChatImageInvalid
+		}
+		if svc.Storage == nil {
+			return nil, errors.New("internal server error: Storage not initialized")
+		}
+	}
+
+	b, err := svc.chatBooking(ctx, role, actorID, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if !chatOpen(b, time.Now()) {
+		return nil, ErrChatClosed
+	}
+
+	msg := models.ChatMessage{
+		ID:         uuid.New().String(),
+		BookingID:  b.ID,
+		SenderID:   actorID,
+		SenderRole: role,
+		Text:       text,
+		CreatedAt:  time.Now(),
+	}
+	if upload != nil {
+		objectPath, err := svc.Storage.UploadFile(ctx, upload.Path, "chat/"+b.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store image: %w", err)
+		}
+		msg.Attachments = []models.ChatAttachment{{
+			ObjectPath:  objectPath,
+			ContentType: upload.ContentType,
+			Size:        upload.Size,
+		}}
+	}
+
+	thread := models.ChatThread{BookingID: b.ID, UserID: b.UserID, ProviderID: b.ProviderID}
+	if err := svc.ChatRepo.AddMessage(ctx, thread, msg); err != nil {
+		return nil, err
+	}
+	messages := []models.ChatMessage{msg}
+	svc.signChatAttachments(ctx, messages)
+	msg = messages[0]
+
+	publishChatEvent(ctx, b.ID, models.ChatEvent{Type: models.ChatEventMessage, Message: &msg})
+	svc.pushChatMessage(b, msg)
+	return &msg, nil
+}
+
+// pushChatMessage sends a push notification of a new message to the other
+// side of the booking, in their language.
+func (svc *DefaultBookingSessionService) pushChatMessage(b *models.Booking, msg models.ChatMessage) {
+	if svc.NotificationSvc == nil {
+		return
+	}
+	se := svc.SchedulerEngine
+	go func() {
+		user, provider := se.bookingParties(b)
+		preview := []rune(msg.Text)
+		if len(preview) > 140 {
+			preview = append(preview[:140], '…')
+		}
+		data := map[string]string{
+			"type":      utils.TemplateChatMessage,
+			"bookingId": b.ID,
+			"messageId": msg.ID,
+		}
+
+		ctx := context.Background()
+		var err error
+		if msg.SenderRole == models.ChatRoleProvider {
+			title, body, rerr := utils.RenderNotification(utils.TemplateChatMessage, user.PreferredLanguage,
+				utils.TemplateParams{Sender: b.MinimalProviderDTO.ProviderName, Message: string(preview)})
+			if rerr != nil {
+				log.Printf("[Chat] Failed to render message push for booking %s: %v", b.ID, rerr)
+				return
+			}
+			err = svc.NotificationSvc.SendUserPushNotification(ctx, b.UserID, title, body, data)
+		} else {
+			title, body, rerr := utils.RenderNotification(utils.TemplateChatMessage, provider.PreferredLanguage,
+				utils.TemplateParams{Sender: user.Username, Message: string(preview)})
+			if rerr != nil {
+				log.Printf("[Chat] Failed to render message push for booking %s: %v", b.ID, rerr)
+				return
+			}
+			err = svc.NotificationSvc.SendProviderPushNotification(ctx, b.ProviderID, title, body, data)
+		}
+		if err != nil {
+			log.Printf("[Chat] Failed to push message %s of booking %s: %v", msg.ID, b.ID, err)
+		}
+	}()
+}
+
+// MarkChatRead marks the messages the caller received as read and tells the
+// other side.
+func (svc *DefaultBookingSessionService) MarkChatRead(role, actorID, bookingID string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	b, err := svc.chatBooking(ctx, role, actorID, bookingID)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	n, err := svc.ChatRepo.MarkRead(ctx, b.ID, role, now)
+	if err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		publishChatEvent(ctx, b.ID, models.ChatEvent{Type: models.ChatEventRead, Reader: role, ReadAt: now})
+	}
+	return n, nil
+}
+
+// ReportChatMessage flags a message of the caller's chat for moderation.
+func (svc *DefaultBookingSessionService) ReportChatMessage(role, actorID, bookingID, messageID, reason string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	b, err := svc.chatBooking(ctx, role, actorID, bookingID)
+	if err != nil {
+		return err
+	}
+	err = svc.ChatRepo.ReportMessage(ctx, b.ID, messageID, models.ChatReport{
+		ReporterRole: role,
+		Reason:       strings.TrimSpace(reason),
+		At:           time.Now(),
+	})
+	if errors.Is(err, chatRepo.ErrMessageNotFound) {
+		return ErrChatMessageNotFound
+	}
+	return err
+}
+
+// SubscribeChat streams new messages and read receipts of a booking's chat.
+// The channel closes when ctx is done.
+func (svc *DefaultBookingSessionService) SubscribeChat(ctx context.Context, role, actorID, bookingID string) (<-chan models.ChatEvent, error) {
+	b, err := svc.chatBooking(ctx, role, actorID, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	pubsub := utils.GetBookingCacheClient().Subscribe(ctx, chatChannelPrefix+b.ID)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to chat: %w", err)
+	}
+
+	out := make(chan models.ChatEvent, 8)
+	go func() {
+		defer close(out)
+		defer pubsub.Close()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				var event models.ChatEvent
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					log.Printf("[Chat] Dropping malformed event on booking %s: %v", b.ID, err)
+					continue
+				}
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func publishChatEvent(ctx context.Context, bookingID string, event models.ChatEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("[Chat] Failed to encode %s event of booking %s: %v", event.Type, bookingID, err)
+		return
+	}
+	if err := utils.GetBookingCacheClient().Publish(ctx, chatChannelPrefix+bookingID, data).Err(); err != nil {
+		log.Printf("[Chat] Failed to publish %s event of booking %s: %v", event.Type, bookingID, err)
+	}
+}
@@ -2,8 +2,11 @@ package booking
 
 import (
 	"bloomify/models"
+	"errors"
 	"fmt"
 	"math"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,6 +16,7 @@ func ValidateAndBook(
 	slot models.TimeSlot,
 	booking models.Booking,
 	customOptionResp *models.CustomOptionResponse,
+	quoteID string,
 	provider models.Provider,
 ) (*models.BookingConfirmation, error) {
 
@@ -21,82 +25,106 @@ func ValidateAndBook(
 		return nil, fmt.Errorf("booking time [%d–%d] outside slot [%d–%d]", booking.Start, booking.End, slot.Start, slot.End)
 	}
 
-	// 2. Validate remaining units for freelancer/single-use or capacity logic
-	remaining, ok := getRemainingUnits(slot, provider)
-	if !ok || remaining <= 0 {
-		return nil, fmt.Errorf("slot is no longer available")
+	// 1b. Resolve the catalogue entry being booked and make sure the slot serves it
+	entry, ok := provider.ResolveCatalogue(booking.CatalogueID, booking.ServiceType, booking.Mode)
+	if !ok {
+		return nil, fmt.Errorf("provider does not offer %q in mode %q", booking.ServiceType, booking.Mode)
 	}
-	actualUnits := min(booking.Units, remaining)
-
-	// 3. Recompute base unit pricing
-	var rawTotal float64
-	switch slot.SlotModel {
-	case "earlybird":
-		if slot.EarlyBird == nil {
-			return nil, fmt.Errorf("earlybird pricing model missing data")
-		}
-		for i := 1; i <= actualUnits; i++ {
-			unitPrice := GetEarlyBirdNextUnitPrice(slot.BasePrice, *slot.EarlyBird, slot.Capacity, slot.BookedUnitsStandard+(i-1))
-			rawTotal += unitPrice
-		}
-
-	case "urgency":
-		if slot.Urgency == nil {
-			return nil, fmt.Errorf("urgency pricing model missing data")
-		}
-		if booking.Priority {
-			unitPrice := slot.BasePrice * (1 + slot.Urgency.PrioritySurchargeRate)
-			rawTotal = unitPrice * float64(actualUnits)
-		} else {
-			unitPrice := slot.BasePrice
-			rawTotal = unitPrice * float64(actualUnits)
-		}
-
-	case "flatrate":
-		rawTotal = slot.BasePrice * float64(actualUnits)
-
-	default:
-		return nil, fmt.Errorf("unknown slot model: %q", slot.SlotModel)
+	if slot.CatalogueID != "" && entry.ID != "" && slot.CatalogueID != entry.ID {
+		return nil, fmt.Errorf("slot %s does not serve the selected service", slot.ID)
 	}
 
-	// 4. Apply multiplier from custom option
 	if customOptionResp == nil {
 		return nil, fmt.Errorf("custom option missing")
 	}
-	var multiplier float64
-	found := false
-	for _, opt := range slot.Catalogue.CustomOptions {
-		if opt.Option == customOptionResp.Option {
-			multiplier = opt.Multiplier
-			found = true
-			break
+	now := time.Now()
+
+	// 2. Honor a signed quote while it is valid and its units are still free
+	if quoteID != "" {
+		quote, err := VerifyQuote(quoteID, now)
+		switch {
+		case err == nil:
+			if err := quoteMatches(quote, providerID, entry.ID, slot, booking, customOptionResp.Option); err != nil {
+				return nil, err
+			}
+			if err := checkChargeCurrency(quote, booking.UserPayment.Currency); err != nil {
+				return nil, err
+			}
+			if quoteStillAvailable(quote, slot, provider) {
+				return &models.BookingConfirmation{
+					BookingID:  uuid.New().String(),
+					TotalPrice: quote.Total,
+					Quote:      quote,
+				}, nil
+			}
+		case errors.Is(err, ErrQuoteExpired):
+			// fall through to re-pricing; the submitted price must match the current one
+		default:
+			return nil, err
 		}
 	}
-	if !found {
-		return nil, fmt.Errorf("invalid custom option: %q", customOptionResp.Option)
-	}
 
-	finalTotal := math.Round(rawTotal * multiplier) // to nearest shilling
+	// 3. Re-price the slot with the same engine availability uses
+	quote, err := QuoteSlot(slot, provider, booking.Units, customOptionResp.Option, booking.CountryCode, "", now)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkChargeCurrency(quote, booking.UserPayment.Currency); err != nil {
+		return nil, err
+	}
 
-	// 5. Validate user-provided price
-	expected := finalTotal
+	// 4. Validate user-provided price
+	expected := quote.Total
 	provided := math.Round(customOptionResp.Price)
 	if expected != provided {
 		var reason string
-		switch slot.SlotModel {
+		switch quote.SlotModel {
 		case "earlybird":
 			reason = "Pricing has changed due to other users booking before you. Early-bird slots adjust per unit."
 		case "urgency":
 			reason = "Pricing may have shifted to priority booking due to limited standard capacity."
+		case "dynamic":
+			reason = "Dynamic prices follow demand and the time left before the slot. Please refresh availability."
 		case "flatrate":
 			reason = "Flat-rate prices do not change. Please ensure you're using the latest app version."
 		}
 		return nil, fmt.Errorf("custom option price mismatch: got %.2f, expected %.2f. %s", provided, expected, reason)
 	}
 
-	// 6. Confirm booking
+	// 5. Confirm booking
 	return &models.BookingConfirmation{
 		BookingID:  uuid.New().String(),
 		TotalPrice: expected,
+		Quote:      quote,
 	}, nil
 }
+
+// quoteMatches rejects a quote issued for a different slot, provider, catalogue
+// entry, size, option or tax country.
+func quoteMatches(q *models.PriceQuote, providerID, catalogueID string, slot models.TimeSlot, booking models.Booking, option string) error {
+	if q.ProviderID != providerID || q.SlotID != slot.ID || q.Date != slot.Date {
+		return fmt.Errorf("%w: quote was issued for a different slot", ErrQuoteInvalid)
+	}
+	if q.CatalogueID != catalogueID {
+		return fmt.Errorf("%w: quote was issued for a different service", ErrQuoteInvalid)
+	}
+	if q.RequestedUnits != booking.Units {
+		return fmt.Errorf("%w: quote covers %d units, booking requests %d", ErrQuoteInvalid, q.RequestedUnits, booking.Units)
+	}
+	if q.Option != option {
+		return fmt.Errorf("%w: quote was issued for option %q", ErrQuoteInvalid, q.Option)
+	}
+	if !strings.EqualFold(q.CountryCode, booking.CountryCode) {
+		return fmt.Errorf("%w: quote was taxed for country %q", ErrQuoteInvalid, q.CountryCode)
+	}
+	return nil
+}
+
+// quoteStillAvailable reports whether the slot still has room for the quoted units.
+func quoteStillAvailable(q *models.PriceQuote, slot models.TimeSlot, provider models.Provider) bool {
+	remaining, ok := getRemainingUnits(slot, provider)
+	if !ok {
+		return false
+	}
+	return remaining >= q.StandardUnits && priorityRemaining(slot, provider) >= q.PriorityUnits
+}
@@ -0,0 +1,142 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	schedulerRepo "bloomify/database/repository/scheduler"
+	"bloomify/models"
+	"bloomify/services/relay"
+)
+
+// ErrContactClosed is returned when opting in to share a number after the
+// booking's contact window, the same as its chat's, has closed.
+var ErrContactClosed = errors.New("this booking's contact window is closed")
+
+// contactShared reports whether both sides of a booking agreed to show their
+// phone numbers.
+func contactShared(b *models.Booking) bool {
+	return b.ContactSharing.User && b.ContactSharing.Provider
+}
+
+// contactNumbers returns the user's and the provider's phone numbers. The user
+// side falls back to the beneficiary's number when the payer has none.
+func (se *DefaultSchedulingEngine) contactNumbers(b *models.Booking) (string, string) {
+	user, provider := se.bookingParties(b)
+	userNumber := user.PhoneNumber
+	if userNumber == "" {
+		userNumber = b.Beneficiary.PhoneNumber
+	}
+	return userNumber, provider.Profile.PhoneNumber
+}
+
+// GetContact returns how the caller can phone the other side of a booking: a
+// relay number leased for the booking, and the other side's own number only
+// once both have agreed to share. The relay is opened on first use and lasts
+// until the contact window closes.
+func (svc *DefaultBookingSessionService) GetContact(role, actorID, bookingID string) (*models.BookingContact, error) {
+	ctx := context.Background()
+	b, err := svc.participantBooking(ctx, role, actorID, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	contact := &models.BookingContact{
+		SharingMine:   b.ContactSharing.User,
+		SharingTheirs: b.ContactSharing.Provider,
+		Open:          chatOpen(b, now),
+	}
+	if role == models.ChatRoleProvider {
+		contact.SharingMine, contact.SharingTheirs = contact.SharingTheirs, contact.SharingMine
+	}
+	if !contact.Open {
+		return contact, nil
+	}
+
+	userNumber, providerNumber := "", ""
+	if contactShared(b) || (svc.Relay != nil && !relayActive(b.CallRelay, now)) {
+		userNumber, providerNumber = svc.SchedulerEngine.contactNumbers(b)
+	}
+	if contactShared(b) {
+		contact.DirectNumber = providerNumber
+		if role == models.ChatRoleProvider {
+			contact.DirectNumber = userNumber
+		}
+	}
+	if svc.Relay == nil {
+		return contact, nil
+	}
+
+	cr := b.CallRelay
+	if !relayActive(cr, now) {
+		if userNumber == "" || providerNumber == "" {
+			// Nothing to connect; the other side can still be reached in chat.
+			return contact, nil
+		}
+		if cr, err = svc.openCallRelay(ctx, b, userNumber, providerNumber, now); err != nil {
+			return nil, err
+		}
+	}
+	contact.RelayNumber = cr.UserProxy
+	if role == models.ChatRoleProvider {
+		contact.RelayNumber = cr.ProviderProxy
+	}
+	contact.RelayExpiresAt = cr.ExpiresAt
+	return contact, nil
+}
+
+func relayActive(cr *models.CallRelay, now time.Time) bool {
+	return cr != nil && now.Before(cr.ExpiresAt)
+}
+
+// openCallRelay leases proxy numbers for a booking until its contact window
+// closes. When another request stored a relay first, that one is used.
+func (svc *DefaultBookingSessionService) openCallRelay(ctx context.Context, b *models.Booking, userNumber, providerNumber string, now time.Time) (*models.CallRelay, error) {
+	cr, err := svc.Relay.OpenSession(ctx, relay.SessionRequest{
+		BookingID:      b.ID,
+		UserNumber:     userNumber,
+		ProviderNumber: providerNumber,
+		ExpiresAt:      chatClosesAt(b),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open call relay: %w", err)
+	}
+	err = svc.SchedulerEngine.Repo.SetCallRelay(ctx, b.ID, *cr, now)
+	if errors.Is(err, schedulerRepo.ErrCallRelayConflict) {
+		latest, err := svc.SchedulerEngine.Repo.GetBookingByID(ctx, b.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load call relay of booking %s: %w", b.ID, err)
+		}
+		if latest.CallRelay == nil {
+			return nil, fmt.Errorf("booking %s has no call relay", b.ID)
+		}
+		return latest.CallRelay, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[Contact] Opened call relay %s for booking %s until %s", cr.SessionID, b.ID, cr.ExpiresAt.Format(time.RFC3339))
+	return cr, nil
+}
+
+// SetContactSharing records whether the caller shares their own phone number
+// with the other side of the booking, and returns the resulting contact.
+// Sharing can be withdrawn at any time but only offered while the contact
+// window is open.
+func (svc *DefaultBookingSessionService) SetContactSharing(role, actorID, bookingID string, share bool) (*models.BookingContact, error) {
+	ctx := context.Background()
+	b, err := svc.participantBooking(ctx, role, actorID, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if share && !chatOpen(b, time.Now()) {
+		return nil, ErrContactClosed
+	}
+	if err := svc.SchedulerEngine.Repo.SetContactSharing(ctx, bookingID, role, share); err != nil {
+		return nil, err
+	}
+	return svc.GetContact(role, actorID, bookingID)
+}
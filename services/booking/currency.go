@@ -0,0 +1,107 @@
+package booking
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"bloomify/models"
+	"bloomify/utils"
+
+	"go.uber.org/zap"
+)
+
+// settlementCurrencies returns the currency a slot is priced in (the catalogue
+// entry's) and the one the provider is paid in. Either falls back to the other.
+func settlementCurrencies(slot models.TimeSlot, provider models.Provider) (price, charge string) {
+	price = strings.ToUpper(slot.Catalogue.Currency)
+	charge = strings.ToUpper(provider.PaymentDetails.Currency)
+	if price == "" {
+		price = charge
+	}
+	if charge == "" {
+		charge = price
+	}
+	return price, charge
+}
+
+// lockQuoteRates sets the charge and display currencies of a quote and the
+// rates used to convert into them. The rates are signed with the quote, so the
+// amount charged does not move with the market until the quote expires.
+func lockQuoteRates(q *models.PriceQuote, chargeCurrency, displayCurrency string) error {
+	ctx := context.Background()
+	rates := utils.GetExchangeRates()
+
+	q.ChargeCurrency = chargeCurrency
+	q.ChargeRate = nil
+	if chargeCurrency != q.Currency {
+		snap, err := rates.Snapshot(ctx, q.Currency, chargeCurrency)
+		if err != nil {
+			return err
+		}
+		q.ChargeRate = &snap
+	}
+
+	displayCurrency = strings.ToUpper(displayCurrency)
+	q.DisplayCurrency = ""
+	q.DisplayRate = nil
+	if displayCurrency == "" || displayCurrency == q.Currency {
+		return nil
+	}
+	snap, err := rates.Snapshot(ctx, q.Currency, displayCurrency)
+	if err != nil {
+		// Display prices are informational; the quote stays valid without them.
+		utils.GetLogger().Warn("no display rate for quote", zap.String("from", q.Currency), zap.String("to", displayCurrency), zap.Error(err))
+		return nil
+	}
+	q.DisplayCurrency = displayCurrency
+	q.DisplayRate = &snap
+	return nil
+}
+
+// convertQuoteTotals derives the charge and display totals from Total at the locked rates.
+func convertQuoteTotals(q *models.PriceQuote) {
+	q.ChargeTotal = q.Total
+	if q.ChargeRate != nil {
+		q.ChargeTotal = math.Round(q.Total * q.ChargeRate.Rate)
+	}
+	q.DisplayTotal = 0
+	if q.DisplayRate != nil {
+		q.DisplayTotal = math.Round(q.Total * q.DisplayRate.Rate)
+	}
+}
+
+// checkChargeCurrency rejects a payment in any currency but the quote's charge currency.
+func checkChargeCurrency(q *models.PriceQuote, paymentCurrency string) error {
+	if q == nil || q.ChargeCurrency == "" {
+		return nil
+	}
+	if !strings.EqualFold(q.ChargeCurrency, paymentCurrency) {
+		return &CurrencyMismatchError{Expected: q.ChargeCurrency, Got: strings.ToUpper(paymentCurrency)}
+	}
+	return nil
+}
+
+// chargeLines converts invoice lines into the charge currency at the quote's
+// locked rate, with a rounding line so they still add up to ChargeTotal.
+func chargeLines(q *models.PriceQuote, lines []models.InvoiceLine) []models.InvoiceLine {
+	if q.ChargeRate == nil {
+		return lines
+	}
+	converted := make([]models.InvoiceLine, 0, len(lines)+1)
+	var sum float64
+	for _, l := range lines {
+		if l.Kind == models.InvoiceLineRounding {
+			continue
+		}
+		l.Amount = roundCents(l.Amount * q.ChargeRate.Rate)
+		if !l.Included {
+			sum += l.Amount
+		}
+		converted = append(converted, l)
+	}
+	if diff := roundCents(q.ChargeTotal - sum); diff != 0 {
+		converted = append(converted, models.InvoiceLine{Kind: models.InvoiceLineRounding, Description: "Rounding", Amount: diff})
+	}
+	return converted
+}
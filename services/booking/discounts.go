@@ -0,0 +1,292 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"bloomify/config"
+	promotionRepo "bloomify/database/repository/promotion"
+	"bloomify/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrPromoNotApplicable is returned when a code is valid but none of the bookings qualify for it.
+	ErrPromoNotApplicable = errors.New("promo code does not apply to this booking")
+	// ErrPromoInactive is returned for codes that are disabled, not yet started or expired.
+	ErrPromoInactive = errors.New("promo code is not active")
+	// ErrReferralNotEligible is returned when a referral code is used on anything but a first booking.
+	ErrReferralNotEligible = errors.New("referral codes can only be used by new users on their first booking")
+)
+
+// discountReservation tracks the redemptions made for one checkout so they can be
+// released if the checkout fails.
+type discountReservation struct {
+	userID        string
+	redemptionIDs []string
+	creditIDs     []string
+	referral      *models.PromoCode // referral code used; its owner is credited once the checkout succeeds
+}
+
+// NormalizePromoCode returns the stored form of a user-entered code.
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// reserveDiscounts redeems every discount the user is entitled to across the
+// bookings of one checkout and reprices their quotes. It runs before payment
+// authorization; redemptions are unique per user, so concurrent confirmations
+// cannot both spend the same discount.
+//
+// An explicit code (promo or referral) replaces the first-booking discount;
+// referral credits stack with either.
+func (se *DefaultSchedulingEngine) reserveDiscounts(ctx context.Context, userID, promoCode string, bookings []*models.Booking) (*discountReservation, error) {
+	res := &discountReservation{userID: userID}
+	code := NormalizePromoCode(promoCode)
+	if se.PromotionRepo == nil {
+		if code != "" {
+			return nil, fmt.Errorf("promo codes are not available")
+		}
+		return res, nil
+	}
+
+	fail := func(err error) (*discountReservation, error) {
+		se.releaseDiscounts(ctx, res)
+		return nil, err
+	}
+
+	count, err := se.Repo.CountBookingsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	firstBooking := count == 0
+
+	// 1. Promo or referral code
+	if code != "" {
+		promo, err := se.PromotionRepo.GetPromo(ctx, code)
+		if err != nil {
+			return fail(err)
+		}
+		if err := checkPromoUsable(promo, userID, firstBooking, time.Now()); err != nil {
+			return fail(err)
+		}
+
+		var (
+			target *models.Booking
+			amount float64
+		)
+		for _, b := range bookings {
+			if amt, ok := promoAmount(promo, b); ok {
+				target, amount = b, amt
+				break
+			}
+		}
+		if target == nil {
+			return fail(ErrPromoNotApplicable)
+		}
+
+		redemption := &models.DiscountRedemption{
+			ID:        "promo:" + code + ":" + userID,
+			Source:    models.DiscountPromo,
+			Code:      code,
+			UserID:    userID,
+			BookingID: target.ID,
+			Amount:    amount,
+			Currency:  target.Quote.Currency,
+		}
+		label := "Promo " + code
+		if promo.Kind == models.PromoReferral {
+			redemption.ID = "referral:" + userID
+			redemption.Source = models.DiscountReferral
+			label = "Referral reward"
+			res.referral = promo
+		}
+		if err := se.PromotionRepo.RedeemPromo(ctx, redemption, promo.UsageLimit); err != nil {
+			res.referral = nil
+			return fail(err)
+		}
+		res.redemptionIDs = append(res.redemptionIDs, redemption.ID)
+		addDiscount(target, models.PriceAdjustment{Code: code, Label: label, Amount: -amount})
+	}
+
+	// 2. First-booking discount
+	if code == "" && firstBooking && config.AppConfig.FirstBookingDiscountRate > 0 {
+		target := bookings[0]
+		amount := discountBase(target) * config.AppConfig.FirstBookingDiscountRate
+		if limit := config.AppConfig.FirstBookingDiscountMax; limit > 0 {
+			amount = math.Min(amount, limit)
+		}
+		amount = math.Round(amount)
+
+		if amount > 0 {
+			redemption := &models.DiscountRedemption{
+				ID:        "first_booking:" + userID,
+				Source:    models.DiscountFirstBooking,
+				UserID:    userID,
+				BookingID: target.ID,
+				Amount:    amount,
+				Currency:  target.Quote.Currency,
+			}
+			switch err := se.PromotionRepo.RecordRedemption(ctx, redemption); {
+			case err == nil:
+				res.redemptionIDs = append(res.redemptionIDs, redemption.ID)
+				addDiscount(target, models.PriceAdjustment{Code: models.DiscountFirstBooking, Label: "First booking discount", Amount: -amount})
+			case errors.Is(err, promotionRepo.ErrAlreadyRedeemed):
+				// another checkout already took it
+			default:
+				return fail(err)
+			}
+		}
+	}
+
+	// 3. One referral credit per checkout, on the first booking with something left to pay
+	for _, b := range bookings {
+		remaining := discountBase(b)
+		if remaining <= 0 {
+			continue
+		}
+		credit, err := se.PromotionRepo.ClaimReferralCredit(ctx, userID, strings.ToUpper(b.Quote.Currency), b.ID)
+		if err != nil {
+			return fail(err)
+		}
+		if credit != nil {
+			res.creditIDs = append(res.creditIDs, credit.ID)
+			spent := math.Min(credit.Amount, remaining)
+			if spent < credit.Amount {
+				// Only what the booking uses is spent; the rest stays with the user.
+				rest := &models.ReferralCredit{
+					ID:         uuid.New().String(),
+					UserID:     credit.UserID,
+					Amount:     credit.Amount - spent,
+					Currency:   credit.Currency,
+					ReferredID: credit.ReferredID,
+					CreatedAt:  credit.CreatedAt,
+				}
+				if err := se.PromotionRepo.SplitReferralCredit(ctx, credit.ID, spent, rest); err != nil {
+					return fail(err)
+				}
+			}
+			addDiscount(b, models.PriceAdjustment{Code: models.DiscountCredit, Label: "Referral credit", Amount: -spent})
+		}
+		break
+	}
+
+	for _, b := range bookings {
+		if len(b.Quote.Discounts) == 0 {
+			continue
+		}
+		finalizeQuote(b.Quote)
+		if err := signQuote(b.Quote); err != nil {
+			return fail(err)
+		}
+		b.TotalPrice = b.Quote.Total
+		b.Discounts = b.Quote.Discounts
+	}
+	return res, nil
+}
+
+// releaseDiscounts returns everything a failed checkout redeemed.
+func (se *DefaultSchedulingEngine) releaseDiscounts(ctx context.Context, res *discountReservation) {
+	if res == nil || se.PromotionRepo == nil {
+		return
+	}
+	for _, id := range res.redemptionIDs {
+		if err := se.PromotionRepo.ReleaseRedemption(ctx, id); err != nil {
+			log.Printf("[Discounts] Failed to release redemption %s: %v", id, err)
+		}
+	}
+	for _, id := range res.creditIDs {
+		if err := se.PromotionRepo.ReleaseReferralCredit(ctx, id); err != nil {
+			log.Printf("[Discounts] Failed to release referral credit %s: %v", id, err)
+		}
+	}
+}
+
+// completeDiscounts runs once the checkout is committed: the owner of a used
+// referral code receives their credit.
+func (se *DefaultSchedulingEngine) completeDiscounts(ctx context.Context, res *discountReservation) {
+	if res == nil || res.referral == nil || se.PromotionRepo == nil {
+		return
+	}
+	credit := &models.ReferralCredit{
+		ID:         uuid.New().String(),
+		UserID:     res.referral.OwnerID,
+		Amount:     res.referral.Value,
+		Currency:   strings.ToUpper(res.referral.Currency),
+		ReferredID: res.userID,
+	}
+	if err := se.PromotionRepo.CreateReferralCredit(ctx, credit); err != nil {
+		log.Printf("[Discounts] Failed to credit referrer %s: %v", res.referral.OwnerID, err)
+	}
+}
+
+func checkPromoUsable(promo *models.PromoCode, userID string, firstBooking bool, now time.Time) error {
+	if !promo.Active {
+		return ErrPromoInactive
+	}
+	if !promo.StartsAt.IsZero() && now.Before(promo.StartsAt) {
+		return ErrPromoInactive
+	}
+	if !promo.ExpiresAt.IsZero() && now.After(promo.ExpiresAt) {
+		return ErrPromoInactive
+	}
+	if promo.Kind == models.PromoReferral && (promo.OwnerID == userID || !firstBooking) {
+		return ErrReferralNotEligible
+	}
+	return nil
+}
+
+// promoAmount returns the discount a code gives on a booking, if the booking qualifies.
+func promoAmount(promo *models.PromoCode, b *models.Booking) (float64, bool) {
+	if b.Quote == nil {
+		return 0, false
+	}
+	if len(promo.ServiceIDs) > 0 && !slices.Contains(promo.ServiceIDs, b.ServiceType) {
+		return 0, false
+	}
+	if len(promo.Regions) > 0 && !slices.ContainsFunc(promo.Regions, func(r string) bool {
+		return strings.EqualFold(r, b.CountryCode)
+	}) {
+		return 0, false
+	}
+
+	base := discountBase(b)
+	var amount float64
+	switch promo.Kind {
+	case models.PromoPercentage:
+		amount = base * promo.Value
+		if promo.MaxDiscount > 0 {
+			amount = math.Min(amount, promo.MaxDiscount)
+		}
+	case models.PromoFixed, models.PromoReferral:
+		if !strings.EqualFold(promo.Currency, b.Quote.Currency) {
+			return 0, false
+		}
+		amount = promo.Value
+	default:
+		return 0, false
+	}
+
+	amount = math.Round(math.Min(amount, base))
+	return amount, amount > 0
+}
+
+// discountBase is what is left of a booking's price before taxes, after the discounts applied so far.
+func discountBase(b *models.Booking) float64 {
+	base := b.Quote.Subtotal + b.Quote.OptionAmount
+	for _, d := range b.Quote.Discounts {
+		base += d.Amount
+	}
+	return math.Max(base, 0)
+}
+
+func addDiscount(b *models.Booking, d models.PriceAdjustment) {
+	b.Quote.Discounts = append(b.Quote.Discounts, d)
+}
@@ -0,0 +1,72 @@
+package booking
+
+import (
+	"testing"
+
+	"bloomify/models"
+)
+
+func TestDiscountBase(t *testing.T) {
+	tests := []struct {
+		name  string
+		quote models.PriceQuote
+		want  float64
+	}{
+		{"subtotal only", models.PriceQuote{Subtotal: 1000}, 1000},
+		{"option counts", models.PriceQuote{Subtotal: 1000, OptionAmount: 200}, 1200},
+		{"discounts applied so far", models.PriceQuote{Subtotal: 1000, Discounts: []models.PriceAdjustment{{Amount: -150}, {Amount: -50}}}, 800},
+		{"taxes are not part of it", models.PriceQuote{Subtotal: 1000, Taxes: []models.PriceAdjustment{{Amount: 160}}}, 1000},
+		{"never below zero", models.PriceQuote{Subtotal: 100, Discounts: []models.PriceAdjustment{{Amount: -300}}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.quote
+			if got := discountBase(&models.Booking{Quote: &q}); got != tt.want {
+				t.Errorf("discountBase = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromoAmount(t *testing.T) {
+	booking := func(subtotal float64) *models.Booking {
+		return &models.Booking{
+			ServiceType: "cleaning",
+			CountryCode: "KE",
+			Quote:       &models.PriceQuote{Subtotal: subtotal, Currency: "KES"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		promo   models.PromoCode
+		booking *models.Booking
+		want    float64
+		wantOK  bool
+	}{
+		{"percentage", models.PromoCode{Kind: models.PromoPercentage, Value: 0.1}, booking(1000), 100, true},
+		{"percentage is rounded", models.PromoCode{Kind: models.PromoPercentage, Value: 0.15}, booking(999), 150, true},
+		{"percentage capped", models.PromoCode{Kind: models.PromoPercentage, Value: 0.5, MaxDiscount: 300}, booking(1000), 300, true},
+		{"fixed", models.PromoCode{Kind: models.PromoFixed, Value: 250, Currency: "kes"}, booking(1000), 250, true},
+		{"fixed is limited to the price", models.PromoCode{Kind: models.PromoFixed, Value: 2500, Currency: "KES"}, booking(1000), 1000, true},
+		{"fixed in another currency", models.PromoCode{Kind: models.PromoFixed, Value: 250, Currency: "USD"}, booking(1000), 0, false},
+		{"referral", models.PromoCode{Kind: models.PromoReferral, Value: 300, Currency: "KES"}, booking(1000), 300, true},
+		{"service restriction met", models.PromoCode{Kind: models.PromoFixed, Value: 100, Currency: "KES", ServiceIDs: []string{"cleaning"}}, booking(1000), 100, true},
+		{"other service", models.PromoCode{Kind: models.PromoFixed, Value: 100, Currency: "KES", ServiceIDs: []string{"plumbing"}}, booking(1000), 0, false},
+		{"region matches case-insensitively", models.PromoCode{Kind: models.PromoFixed, Value: 100, Currency: "KES", Regions: []string{"ke"}}, booking(1000), 100, true},
+		{"other region", models.PromoCode{Kind: models.PromoFixed, Value: 100, Currency: "KES", Regions: []string{"UG"}}, booking(1000), 0, false},
+		{"unknown kind", models.PromoCode{Kind: "bogus", Value: 100}, booking(1000), 0, false},
+		{"nothing left to discount", models.PromoCode{Kind: models.PromoPercentage, Value: 0.1}, booking(0), 0, false},
+		{"unpriced booking", models.PromoCode{Kind: models.PromoPercentage, Value: 0.1}, &models.Booking{}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := promoAmount(&tt.promo, tt.booking)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("promoAmount = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"bloomify/models"
+	"bloomify/utils"
+)
+
+// Weights of the dynamic pricing signals. Each adds to a multiplier of 1,
+// and the result is clamped to the provider's bounds.
+const (
+	dynamicFillWeight       = 0.4 // -20% for an empty slot, +20% for a full one
+	dynamicLeadWeight       = 0.2 // up to +20% as the slot approaches
+	dynamicLeadWindow       = 48 * time.Hour
+	dynamicDemandWeight     = 0.3 // up to +30% at demandSaturation sessions
+	dynamicDemandSaturation = 20
+)
+
+// DemandSignals are the inputs to dynamic pricing.
+type DemandSignals struct {
+	FillRate float64       // booked share of capacity, 0-1
+	LeadTime time.Duration // time until the slot starts
+	Sessions int           // booking sessions recently started for the service in the area
+}
+
+// DynamicMultiplier returns the price multiplier for the given signals. It is a
+// pure function: the same bounds and signals always give the same multiplier,
+// rounded to two decimals.
+func DynamicMultiplier(d models.DynamicSlotData, s DemandSignals) float64 {
+	m := 1.0
+
+	fill := math.Min(math.Max(s.FillRate, 0), 1)
+	m += (fill - 0.5) * dynamicFillWeight
+
+	if s.LeadTime < dynamicLeadWindow {
+		closeness := 1 - math.Max(s.LeadTime.Hours(), 0)/dynamicLeadWindow.Hours()
+		m += closeness * dynamicLeadWeight
+	}
+
+	demand := math.Min(float64(max(s.Sessions, 0))/dynamicDemandSaturation, 1)
+	m += demand * dynamicDemandWeight
+
+	m = math.Min(math.Max(m, d.MinMultiplier), d.MaxMultiplier)
+	return math.Round(m*100) / 100
+}
+
+// dynamicSignals reads the signals of a slot at now.
+func dynamicSignals(slot models.TimeSlot, now time.Time) DemandSignals {
+	s := DemandSignals{Sessions: slot.Demand}
+	if slot.Capacity > 0 {
+		s.FillRate = float64(slot.BookedUnitsStandard+slot.BookedUnitsPriority) / float64(slot.Capacity)
+	}
+	if day, err := time.ParseInLocation("2006-01-02", slot.Date, now.Location()); err == nil {
+		s.LeadTime = day.Add(time.Duration(slot.Start) * time.Minute).Sub(now)
+	}
+	return s
+}
+
+// validateDynamicSlot checks the provider-set bounds of a dynamic slot.
+func validateDynamicSlot(d *models.DynamicSlotData) error {
+	if d == nil {
+		return fmt.Errorf("dynamic pricing model missing data")
+	}
+	if d.MinMultiplier <= 0 || d.MaxMultiplier < d.MinMultiplier {
+		return fmt.Errorf("dynamic pricing needs 0 < minMultiplier <= maxMultiplier")
+	}
+	return nil
+}
+
+// --- demand tracking ---
+
+// Booking sessions are counted per service in hourly buckets of a ~11 km grid cell.
+const (
+	demandBucket    = time.Hour
+	demandCellSize  = 0.1 // degrees
+	demandKeyPrefix = "demand:"
+)
+
+func demandCell(loc models.GeoPoint) (int, int, bool) {
+	if len(loc.Coordinates) != 2 {
+		return 0, 0, false
+	}
+	lng, lat := loc.Coordinates[0], loc.Coordinates[1]
+	return int(math.Floor(lat / demandCellSize)), int(math.Floor(lng / demandCellSize)), true
+}
+
+func demandKey(serviceType string, latCell, lngCell int, bucket time.Time) string {
+	return demandKeyPrefix + strings.ToLower(serviceType) + ":" +
+		strconv.Itoa(latCell) + ":" + strconv.Itoa(lngCell) + ":" +
+		strconv.FormatInt(bucket.Unix(), 10)
+}
+
+// RecordBookingDemand counts a booking session for the service at loc.
+func RecordBookingDemand(ctx context.Context, serviceType string, loc models.GeoPoint, now time.Time) error {
+	latCell, lngCell, ok := demandCell(loc)
+	if !ok || serviceType == "" {
+		return nil
+	}
+	key := demandKey(serviceType, latCell, lngCell, now.Truncate(demandBucket))
+	cache := utils.GetBookingCacheClient()
+	pipe := cache.TxPipeline()
+	pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, 2*demandBucket+time.Minute)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record booking demand: %w", err)
+	}
+	return nil
+}
+
+// recentDemand returns the booking sessions started for the service in the
+// current and previous hour, in the cell of loc and its neighbours.
+func recentDemand(ctx context.Context, serviceType string, loc models.GeoPoint, now time.Time) (int, error) {
+	latCell, lngCell, ok := demandCell(loc)
+	if !ok || serviceType == "" {
+		return 0, nil
+	}
+	current := now.Truncate(demandBucket)
+	var keys []string
+	for _, bucket := range []time.Time{current, current.Add(-demandBucket)} {
+		for dLat := -1; dLat <= 1; dLat++ {
+			for dLng := -1; dLng <= 1; dLng++ {
+				keys = append(keys, demandKey(serviceType, latCell+dLat, lngCell+dLng, bucket))
+			}
+		}
+	}
+
+	values, err := utils.GetBookingCacheClient().MGet(ctx, keys...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read booking demand: %w", err)
+	}
+	var total int
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			n, _ := strconv.Atoi(s)
+			total += n
+		}
+	}
+	return total, nil
+}
+
+// attachDemand sets Demand on the provider's dynamic slots. Demand is a soft
+// signal, so lookup failures price the slots as if there were none.
+func attachDemand(slots []models.TimeSlot, provider models.Provider, now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	cache := map[string]int{}
+	for i := range slots {
+		if effectiveSlotModel(slots[i], provider) != string(models.Dynamic) {
+			continue
+		}
+		service := slots[i].Catalogue.Service.ID
+		demand, ok := cache[service]
+		if !ok {
+			var err error
+			if demand, err = recentDemand(ctx, service, provider.Profile.LocationGeo, now); err != nil {
+				utils.GetLogger().Sugar().Warnf("dynamic pricing without demand signal: %v", err)
+			}
+			cache[service] = demand
+		}
+		slots[i].Demand = demand
+	}
+}
@@ -0,0 +1,51 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"bloomify/models"
+)
+
+func TestDynamicMultiplier(t *testing.T) {
+	wide := models.DynamicSlotData{MinMultiplier: 0.5, MaxMultiplier: 2}
+	far := 72 * time.Hour
+
+	tests := []struct {
+		name    string
+		bounds  models.DynamicSlotData
+		signals DemandSignals
+		want    float64
+	}{
+		{"neutral", wide, DemandSignals{FillRate: 0.5, LeadTime: far}, 1},
+		{"empty slot", wide, DemandSignals{FillRate: 0, LeadTime: far}, 0.8},
+		{"full slot", wide, DemandSignals{FillRate: 1, LeadTime: far}, 1.2},
+		{"fill rate above one is clamped", wide, DemandSignals{FillRate: 2, LeadTime: far}, 1.2},
+		{"fill rate below zero is clamped", wide, DemandSignals{FillRate: -1, LeadTime: far}, 0.8},
+
+		{"lead time at window", wide, DemandSignals{FillRate: 0.5, LeadTime: 48 * time.Hour}, 1},
+		{"lead time half window", wide, DemandSignals{FillRate: 0.5, LeadTime: 24 * time.Hour}, 1.1},
+		{"lead time quarter window", wide, DemandSignals{FillRate: 0.5, LeadTime: 12 * time.Hour}, 1.15},
+		{"slot starting now", wide, DemandSignals{FillRate: 0.5, LeadTime: 0}, 1.2},
+		{"slot already started", wide, DemandSignals{FillRate: 0.5, LeadTime: -3 * time.Hour}, 1.2},
+
+		{"no demand", wide, DemandSignals{FillRate: 0.5, LeadTime: far, Sessions: 0}, 1},
+		{"half saturated demand", wide, DemandSignals{FillRate: 0.5, LeadTime: far, Sessions: 10}, 1.15},
+		{"saturated demand", wide, DemandSignals{FillRate: 0.5, LeadTime: far, Sessions: 20}, 1.3},
+		{"demand beyond saturation", wide, DemandSignals{FillRate: 0.5, LeadTime: far, Sessions: 500}, 1.3},
+		{"negative sessions count as none", wide, DemandSignals{FillRate: 0.5, LeadTime: far, Sessions: -5}, 1},
+
+		{"all signals high", wide, DemandSignals{FillRate: 1, LeadTime: 0, Sessions: 20}, 1.7},
+		{"clamped to floor", models.DynamicSlotData{MinMultiplier: 0.9, MaxMultiplier: 1.5}, DemandSignals{FillRate: 0, LeadTime: far}, 0.9},
+		{"clamped to ceiling", models.DynamicSlotData{MinMultiplier: 0.5, MaxMultiplier: 1.25}, DemandSignals{FillRate: 1, LeadTime: 0, Sessions: 20}, 1.25},
+		{"fixed bounds", models.DynamicSlotData{MinMultiplier: 1, MaxMultiplier: 1}, DemandSignals{FillRate: 1, LeadTime: 0, Sessions: 20}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DynamicMultiplier(tt.bounds, tt.signals); got != tt.want {
+				t.Errorf("DynamicMultiplier(%+v, %+v) = %v, want %v", tt.bounds, tt.signals, got, tt.want)
+			}
+		})
+	}
+}
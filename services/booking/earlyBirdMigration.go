@@ -0,0 +1,37 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	timeslotRepo "bloomify/database/repository/timeslot"
+	"bloomify/models"
+)
+
+// MigrateEarlyBirdTiers writes the legacy 25%/75% schedule onto earlybird slots
+// created before tier schedules existed, so every slot carries explicit tiers.
+// Slots that already have tiers are left alone, so it is safe to run on every start.
+func MigrateEarlyBirdTiers(repo timeslotRepo.TimeSlotRepository) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	slots, err := repo.ListEarlyBirdSlotsWithoutTiers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tiers := make(map[string][]models.EarlyBirdTier, len(slots))
+	for _, slot := range slots {
+		if slot.EarlyBird == nil || slot.ID == "" {
+			continue
+		}
+		tiers[slot.ID] = models.LegacyEarlyBirdTiers(*slot.EarlyBird, slot.Capacity)
+	}
+
+	migrated, err := repo.SetEarlyBirdTiers(ctx, tiers)
+	if err != nil {
+		return 0, fmt.Errorf("earlybird tier migration failed: %w", err)
+	}
+	return migrated, nil
+}
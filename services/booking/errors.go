@@ -1,6 +1,13 @@
 package booking
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotBookingParticipant is returned when the caller is not the user or
+// provider of the booking they act on, e.g. its chat, trip, no-show or balance.
+var ErrNotBookingParticipant = errors.New("you are not part of this booking")
 
 type MatchError struct {
 	Code    string
@@ -17,3 +24,14 @@ func NewMatchError(msg string) error {
 		Message: msg,
 	}
 }
+
+// CurrencyMismatchError is returned when a booking is paid in a currency other
+// than the one its quote is charged in.
+type CurrencyMismatchError struct {
+	Expected string
+	Got      string
+}
+
+func (e *CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("currency mismatch: quote is charged in %s, payment is in %s", e.Expected, e.Got)
+}
@@ -1,8 +1,15 @@
 package booking
 
 import (
+	"context"
+	"time"
+
+	chatRepo "bloomify/database/repository/chat"
+	serviceRepo "bloomify/database/repository/service"
 	"bloomify/models"
 	"bloomify/services/notification"
+	"bloomify/services/relay"
+	"bloomify/services/storage"
 )
 
 // BookingSessionService defines the interface for managing a stateful booking session.
@@ -13,6 +20,47 @@ type BookingSessionService interface {
 	CancelSession(sessionID string) error
 	GetAvailableServices(region string) ([]models.ServiceMetadata, error)
 	GetServiceByID(serviceID string, countryCode string, currency string) (*ServiceDetails, error)
+
+	// Multi-service basket checkout
+	InitiateBasket(plans []models.ServicePlan, userID, deviceID, userAgent string) (*models.BasketSession, error)
+	UpdateBasketItem(basketID, itemID, selectedProviderID string, weekIndex int) (*models.BasketSession, error)
+	ConfirmBasket(basketID string, checkout models.BasketCheckoutRequest) (*models.PublicBasketData, error)
+	CancelBasket(basketID string) error
+
+	// Receipts
+	GetReceipt(userID, bookingID, format string, store bool) (*models.ReceiptDocument, error)
+
+	// Post-service adjustments and tips
+	ProposeAdjustment(providerID, bookingID string, req models.AdjustmentProposal) (*models.BookingAdjustment, error)
+	RespondToAdjustment(userID, bookingID, adjustmentID string, decision models.AdjustmentDecision) (*models.BookingAdjustment, error)
+	AddTip(userID, bookingID string, req models.TipRequest) (*models.BookingAdjustment, error)
+
+	// Deposit bookings
+	PayBalance(userID, bookingID, paymentIntentID string) (*models.PaymentPlan, error)
+
+	// Check-ins and no-shows
+	CheckIn(providerID, bookingID string, req models.CheckInRequest) (*models.Booking, error)
+	ResolveNoShow(userID, bookingID string, decision models.NoShowDecision) (*models.NoShowResult, error)
+
+	// Live provider location
+	ShareLocation(providerID, bookingID string, fix models.LocationFix) (*models.LiveLocation, error)
+	GetLiveLocation(userID, bookingID string) (*models.LiveLocation, error)
+	SubscribeLiveLocation(ctx context.Context, userID, bookingID string) (<-chan models.LiveLocation, error)
+
+	// Booking chat; role is "user" or "provider" and actorID the caller's ID
+	ListChats(role, actorID string, page, limit int64) (*models.ChatThreadPage, error)
+	GetChat(role, actorID, bookingID string, before time.Time, limit int64) (*models.ChatConversation, error)
+	SendChatMessage(role, actorID, bookingID, text string, upload *ChatUpload) (*models.ChatMessage, error)
+	MarkChatRead(role, actorID, bookingID string) (int64, error)
+	ReportChatMessage(role, actorID, bookingID, messageID, reason string) error
+	SubscribeChat(ctx context.Context, role, actorID, bookingID string) (<-chan models.ChatEvent, error)
+
+	// Masked calls between a booking's user and provider
+	GetContact(role, actorID, bookingID string) (*models.BookingContact, error)
+	SetContactSharing(role, actorID, bookingID string, share bool) (*models.BookingContact, error)
+
+	// Provider dashboard events
+	SubscribeProviderEvents(ctx context.Context, providerID, lastEventID string) (<-chan models.ProviderEvent, error)
 }
 
 // DefaultBookingSessionService implements BookingSessionService.
@@ -20,4 +68,13 @@ type DefaultBookingSessionService struct {
 	MatchingSvc     MatchingService
 	SchedulerEngine *DefaultSchedulingEngine
 	NotificationSvc notification.NotificationService
+	// ServiceRepo backs the service taxonomy; when nil the compiled-in seed map is used.
+	ServiceRepo serviceRepo.ServiceRepository
+	// Storage keeps rendered receipts when requested, and chat images; optional.
+	Storage storage.StorageService
+	// ChatRepo stores booking chats; without it chat is unavailable.
+	ChatRepo chatRepo.ChatRepository
+	// Relay leases the proxy numbers users and providers call each other on;
+	// without it only numbers both sides chose to share are given out.
+	Relay relay.NumberProxy
 }
@@ -0,0 +1,91 @@
+package booking
+
+import (
+	"context"
+	"log"
+
+	"bloomify/models"
+)
+
+// recordServiceEarnings adds the ledger entry of a paid booking. The platform
+// keeps the platform fee lines and collects the tax charged on top; the rest of
+// the invoice is the provider's.
+func (se *DefaultSchedulingEngine) recordServiceEarnings(ctx context.Context, b *models.Booking) {
+	if se.LedgerRepo == nil {
+		return
+	}
+	se.recordLedger(ctx, serviceEntry(b))
+}
+
+// recordServiceRefund reverses the service entry of a booking whose payment
+// was refunded, so the provider is not paid for it.
+func (se *DefaultSchedulingEngine) recordServiceRefund(ctx context.Context, b *models.Booking) {
+	if se.LedgerRepo == nil {
+		return
+	}
+	entry := serviceEntry(b)
+	entry.ID = "refund:" + b.ID
+	entry.Kind = models.LedgerRefund
+	entry.Gross = -entry.Gross
+	entry.Commission = -entry.Commission
+	entry.Tax = -entry.Tax
+	se.recordLedger(ctx, entry)
+}
+
+func serviceEntry(b *models.Booking) *models.LedgerEntry {
+	entry := &models.LedgerEntry{
+		ID:         "service:" + b.ID,
+		ProviderID: b.ProviderID,
+		BookingID:  b.ID,
+		Kind:       models.LedgerService,
+		Gross:      b.Invoice.Amount,
+		Currency:   b.Invoice.Currency,
+	}
+	addPlatformShare(entry, b.Invoice.Lines)
+	return entry
+}
+
+// addPlatformShare adds the platform fee lines to the entry's commission and the
+// tax charged on top to its tax.
+func addPlatformShare(entry *models.LedgerEntry, lines []models.InvoiceLine) {
+	for _, line := range lines {
+		switch {
+		case line.Kind == models.InvoiceLinePlatformFee:
+			entry.Commission += line.Amount
+		case line.Kind == models.InvoiceLineTax && !line.Included:
+			entry.Tax += line.Amount
+		}
+	}
+}
+
+// recordAdjustmentEarnings adds the ledger entry of a paid adjustment. Tips go to
+// the provider in full; other adjustments carry the platform fee and tax they
+// were charged with.
+func (se *DefaultSchedulingEngine) recordAdjustmentEarnings(ctx context.Context, b *models.Booking, adj models.BookingAdjustment) {
+	if se.LedgerRepo == nil {
+		return
+	}
+	entry := &models.LedgerEntry{
+		ID:           "adjustment:" + adj.AdjustmentID,
+		ProviderID:   b.ProviderID,
+		BookingID:    b.ID,
+		AdjustmentID: adj.AdjustmentID,
+		Kind:         models.LedgerAdjustment,
+		Gross:        adjustmentTotal(adj),
+		Currency:     adj.Currency,
+	}
+	if adj.Kind == models.AdjustmentTip {
+		entry.Kind = models.LedgerTip
+	}
+	addPlatformShare(entry, adj.Taxes)
+	se.recordLedger(ctx, entry)
+}
+
+func (se *DefaultSchedulingEngine) recordLedger(ctx context.Context, entry *models.LedgerEntry) {
+	entry.Commission = roundCents(entry.Commission)
+	entry.Tax = roundCents(entry.Tax)
+	entry.ProviderNet = roundCents(entry.Gross - entry.Commission - entry.Tax)
+	if err := se.LedgerRepo.Record(ctx, entry); err != nil {
+		log.Printf("[Ledger] Failed to record %s entry for booking %s: %v", entry.Kind, entry.BookingID, err)
+	}
+}
@@ -55,7 +55,7 @@ func (s *DefaultMatchingService) MatchProviders(plan models.ServicePlan) ([]mode
 		log.Printf("No providers matched for service '%s'", plan.ServiceType)
 		return []models.ProviderDTO{}, nil
 	}
-	return extractProvidersDTO(rankedProviders), nil
+	return extractProvidersDTO(rankedProviders, plan), nil
 }
 
 func (s *DefaultMatchingService) matchProviders(
@@ -111,7 +111,7 @@ func (s *DefaultMatchingService) MatchNearbyProviders(
 		p := rp.Provider
 		dtos = append(dtos, models.ProviderDTO{
 			ID:               p.ID,
-			Profile:          p.Profile,
+			Profile:          publicProfile(p.Profile),
 			ServiceCatalogue: p.ServiceCatalogue,
 			LocationGeo:      p.Profile.LocationGeo,
 			Preferred:        rp.Preferred,
@@ -121,13 +121,27 @@ func (s *DefaultMatchingService) MatchNearbyProviders(
 	return dtos, nil
 }
 
-func extractProvidersDTO(ranked []RankedProvider) []models.ProviderDTO {
+// publicProfile is a provider's profile as users see it. Their phone number is
+// left out; calls go through the booking's relay.
+func publicProfile(p models.Profile) models.Profile {
+	p.PhoneNumber = ""
+	return p
+}
+
+// extractProvidersDTO maps ranked providers to DTOs, exposing the catalogue entry
+// that serves the plan so later steps price and book against that entry.
+func extractProvidersDTO(ranked []RankedProvider, plan models.ServicePlan) []models.ProviderDTO {
 	var dtos []models.ProviderDTO
 	for _, rp := range ranked {
+		entry, ok := rp.Provider.ResolveCatalogue(plan.CatalogueID, plan.ServiceType, plan.Mode)
+		if !ok {
+			log.Printf("Provider %s matched but has no catalogue entry for %s/%s", rp.Provider.ID, plan.ServiceType, plan.Mode)
+			continue
+		}
 		dto := models.ProviderDTO{
 			ID:               rp.Provider.ID,
-			Profile:          rp.Provider.Profile,
-			ServiceCatalogue: rp.Provider.ServiceCatalogue,
+			Profile:          publicProfile(rp.Provider.Profile),
+			ServiceCatalogue: entry,
 			LocationGeo:      rp.Provider.Profile.LocationGeo,
 			Preferred:        rp.Preferred,
 			Proximity:        rp.Proximity,
@@ -150,6 +164,8 @@ func scoreAndRankProviders(
 		MaxRatingPts      = 20.0
 		MaxSlotPts        = 10.0
 		MaxDistanceKm     = 5.0
+		NoShowPenalty     = 10.0 // per recorded provider no-show
+		MaxNoShowPenalty  = 30.0
 	)
 
 	computeLocationScore := func(distKm float64) float64 {
@@ -173,6 +189,9 @@ func scoreAndRankProviders(
 		}
 		return float64(count) / 20.0 * MaxSlotPts
 	}
+	computeReliabilityScore := func(noShows int) float64 {
+		return -math.Min(float64(noShows)*NoShowPenalty, MaxNoShowPenalty)
+	}
 
 	type scoreData struct {
 		sd RankedProvider
@@ -199,18 +218,20 @@ func scoreAndRankProviders(
 			ratS := computeRatingScore(p.Profile.Rating)
 			slotCount := len(p.TimeSlotRefs)
 			slotS := computeSlotScore(slotCount)
-			total := locS + verS + compS + ratS + slotS
+			relS := computeReliabilityScore(p.Reliability.NoShows)
+			total := locS + verS + compS + ratS + slotS + relS
 
 			ch <- RankedProvider{
 				Provider:   p,
 				RankPoints: total,
 				Proximity:  distKm * 1000,
 				ScoreBreakdown: map[string]float64{
-					"proximity": locS,
-					"verified":  verS,
-					"completed": compS,
-					"rating":    ratS,
-					"slots":     slotS,
+					"proximity":   locS,
+					"verified":    verS,
+					"completed":   compS,
+					"rating":      ratS,
+					"slots":       slotS,
+					"reliability": relS,
 				},
 			}
 		}(p)
@@ -0,0 +1,498 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"bloomify/config"
+	schedulerRepo "bloomify/database/repository/scheduler"
+	"bloomify/models"
+	"bloomify/services/tasks"
+	"bloomify/utils"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+var (
+	// ErrCheckInClosed is returned when a booking is no longer confirmed or
+	// already has a check-in of the same kind.
+	ErrCheckInClosed = errors.New("booking is not confirmed or already has this check-in")
+	// ErrCustomerAbsentTooEarly is returned when a provider reports the customer
+	// absent before arriving and waiting out the no-show threshold.
+	ErrCustomerAbsentTooEarly = errors.New("the customer can only be reported absent after arriving and waiting the no-show threshold")
+	// ErrNoShowClosed is returned when a booking has no provider no-show awaiting
+	// the user's decision.
+	ErrNoShowClosed = errors.New("booking has no open provider no-show")
+)
+
+// noShowThreshold is how long after the start of a booking a provider who has
+// not checked in counts as a no-show. Users may set their own; the same wait
+// applies before a provider can report the customer absent.
+func noShowThreshold(minutes int) time.Duration {
+	if minutes <= 0 {
+		minutes = config.AppConfig.NoShowThresholdMinutes
+	}
+	if minutes <= 0 {
+		minutes = 15
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// scheduleNoShowCheck enqueues the provider no-show check of a booking.
+func (se *DefaultSchedulingEngine) scheduleNoShowCheck(b *models.Booking, startsAt time.Time, user *models.User) {
+	checkAt := startsAt.Add(noShowThreshold(user.SafetySettings.NoShowThresholdMinutes))
+	task, opts, err := tasks.NewNoShowCheckTask(b.ID, checkAt)
+	if err != nil {
+		log.Printf("[NoShow] Failed to create no-show check for booking %s: %v", b.ID, err)
+		return
+	}
+	if _, err := se.AsynqClient.Enqueue(task, opts...); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		log.Printf("[NoShow] Failed to schedule no-show check of booking %s: %v", b.ID, err)
+	}
+}
+
+func findCheckIn(b *models.Booking, kind string) *models.BookingCheckIn {
+	for i := range b.CheckIns {
+		if b.CheckIns[i].Kind == kind {
+			return &b.CheckIns[i]
+		}
+	}
+	return nil
+}
+
+// CheckProviderNoShow runs when the no-show threshold of a booking has passed.
+// A confirmed booking the provider has neither arrived at nor started is
+// flagged as a provider no-show: the provider's reliability drops, the user is
+// asked to choose a refund or another provider, and an automatic refund is
+// scheduled in case they don't.
+func (se *DefaultSchedulingEngine) CheckProviderNoShow(ctx context.Context, bookingID string) error {
+	b, err := se.Repo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		log.Printf("[NoShow] Booking %s not found, dropping no-show check: %v", bookingID, err)
+		return nil
+	}
+	if b.Status != "confirmed" || b.NoShow != nil ||
+		findCheckIn(b, models.CheckInArrived) != nil || findCheckIn(b, models.CheckInStarted) != nil {
+		return nil
+	}
+	startsAt, err := bookingStartsAt(b)
+	if err != nil || time.Now().Before(startsAt) {
+		return nil
+	}
+
+	record := models.NoShowRecord{
+		Party:      models.NoShowByProvider,
+		DetectedAt: time.Now(),
+		Resolution: models.NoShowPending,
+	}
+	if err := se.Repo.MarkNoShow(ctx, b, record); err != nil {
+		if errors.Is(err, schedulerRepo.ErrNoShowConflict) {
+			return nil
+		}
+		return err
+	}
+	b.Status, b.NoShow = "no_show", &record
+	se.endTrip(ctx, b.ID, "no_show")
+	// This runs as the no-show check, so only the reminders are left to cancel.
+	se.cancelReminders(b.ID, false)
+	log.Printf("[NoShow] Provider %s did not check in for booking %s", b.ProviderID, b.ID)
+
+	refundHours := config.AppConfig.NoShowRefundHours
+	if refundHours > 0 && se.AsynqClient != nil {
+		task, opts, err := tasks.NewNoShowRefundTask(b.ID, record.DetectedAt.Add(time.Duration(refundHours)*time.Hour))
+		if err == nil {
+			_, err = se.AsynqClient.Enqueue(task, opts...)
+		}
+		if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			log.Printf("[NoShow] Failed to schedule automatic refund of booking %s: %v", b.ID, err)
+		}
+	}
+
+	user, provider := se.bookingParties(b)
+	se.notifyBooking("user", b.UserID, user.PreferredLanguage, utils.TemplateProviderNoShow, b,
+		utils.TemplateParams{ProviderName: b.MinimalProviderDTO.ProviderName, When: startsAt, Count: refundHours})
+	se.notifyBooking("provider", b.ProviderID, provider.PreferredLanguage, utils.TemplateMissedBooking, b,
+		utils.TemplateParams{CustomerName: user.Username, When: startsAt})
+	return nil
+}
+
+// RefundNoShow refunds a provider no-show the user has not resolved in time.
+func (se *DefaultSchedulingEngine) RefundNoShow(ctx context.Context, bookingID string) error {
+	b, err := se.Repo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		log.Printf("[NoShow] Booking %s not found, dropping automatic refund: %v", bookingID, err)
+		return nil
+	}
+	if !noShowOpen(b) {
+		return nil
+	}
+	if _, err := se.refundNoShow(ctx, b, models.NoShowRefunded); err != nil && !errors.Is(err, ErrNoShowClosed) {
+		return err
+	}
+	return nil
+}
+
+// noShowOpen reports whether a booking has a provider no-show still awaiting a
+// refund, including one whose refund failed.
+func noShowOpen(b *models.Booking) bool {
+	if b.NoShow == nil || b.NoShow.Party != models.NoShowByProvider {
+		return false
+	}
+	return b.NoShow.Resolution == models.NoShowPending || b.NoShow.Resolution == models.NoShowFailed
+}
+
+// refundNoShow claims an open provider no-show, refunds what the user paid and
+// resolves it as resolution. A failed refund leaves it failed, so the user or
+// the automatic refund can try again.
+func (se *DefaultSchedulingEngine) refundNoShow(ctx context.Context, b *models.Booking, resolution string) (*models.NoShowRecord, error) {
+	from := b.NoShow.Resolution
+	record := *b.NoShow
+	record.Resolution = models.NoShowProcessing
+	record.Error = ""
+	if err := se.Repo.UpdateNoShow(ctx, b.ID, record, from); err != nil {
+		if errors.Is(err, schedulerRepo.ErrNoShowConflict) {
+			return nil, ErrNoShowClosed
+		}
+		return nil, err
+	}
+
+	refunded, err := se.refundBookingPayments(ctx, b)
+	record.RefundedAmount = roundCents(refunded)
+	if err != nil {
+		record.Resolution = models.NoShowFailed
+		record.Error = err.Error()
+		if uerr := se.Repo.UpdateNoShow(ctx, b.ID, record, models.NoShowProcessing); uerr != nil {
+			log.Printf("[NoShow] Failed to mark refund of booking %s failed: %v", b.ID, uerr)
+		}
+		return &record, fmt.Errorf("no-show refund failed: %w", err)
+	}
+
+	record.Resolution = resolution
+	record.ResolvedAt = time.Now()
+	if err := se.Repo.UpdateNoShow(ctx, b.ID, record, models.NoShowProcessing); err != nil {
+		return nil, err
+	}
+	b.NoShow = &record
+	se.CancelBookingReminders(b.ID)
+
+	// The provider was only credited once the booking was paid in full.
+	if record.RefundedAmount > 0 && (b.PaymentPlan == nil || b.PaymentPlan.BalanceStatus == models.BalancePaid) {
+		se.recordServiceRefund(ctx, b)
+	}
+
+	user, _ := se.bookingParties(b)
+	se.notifyBooking("user", b.UserID, user.PreferredLanguage, utils.TemplateNoShowRefund, b, utils.TemplateParams{
+		ProviderName: b.MinimalProviderDTO.ProviderName,
+		Amount:       record.RefundedAmount,
+		Currency:     b.Invoice.Currency,
+	})
+	return &record, nil
+}
+
+// refundBookingPayments refunds the card payments of a booking: the checkout
+// payment, and the balance of a deposit booking once it was paid. Cash bookings
+// are paid at the service, so there is nothing to refund. It returns the amount
+// refunded, including by earlier attempts.
+func (se *DefaultSchedulingEngine) refundBookingPayments(ctx context.Context, b *models.Booking) (float64, error) {
+	if b.Invoice.Method != "card" {
+		return 0, nil
+	}
+	if se.PaymentHandler == nil {
+		return 0, errors.New("internal server error: PaymentHandler not initialized")
+	}
+
+	type payment struct {
+		intentID string
+		amount   float64
+	}
+	var payments []payment
+	if plan := b.PaymentPlan; plan != nil {
+		payments = append(payments, payment{b.Invoice.PaymentID, plan.DepositAmount})
+		if plan.BalanceStatus == models.BalancePaid && plan.BalancePaymentID != "" {
+			payments = append(payments, payment{plan.BalancePaymentID, plan.BalanceAmount})
+		}
+	} else {
+		amount := b.TotalPrice
+		if b.Quote != nil {
+			amount = b.Quote.ChargeTotal
+		}
+		payments = append(payments, payment{b.Invoice.PaymentID, amount})
+	}
+
+	var refunded float64
+	for _, p := range payments {
+		if p.intentID == "" || p.amount <= 0 {
+			continue
+		}
+		// Refund requests are idempotent per intent and amount, so a retry after a
+		// partial failure gets the earlier refund back instead of a second one.
+		res, err := se.PaymentHandler.ProcessPayment(ctx, models.PaymentRequest{
+			UserID:          b.UserID,
+			Amount:          p.amount,
+			Currency:        b.Invoice.Currency,
+			Method:          "card",
+			Action:          "refund",
+			PaymentIntentID: p.intentID,
+			Metadata: map[string]string{
+				"bookingId":  b.ID,
+				"providerId": b.ProviderID,
+				"context":    "no_show",
+			},
+		})
+		if err != nil {
+			return refunded, err
+		}
+		refunded += res.Amount
+		if refundRecorded(b, res.PaymentID) {
+			continue
+		}
+		refund := models.Refund{
+			RefundID:  res.PaymentID,
+			Amount:    res.Amount,
+			Reason:    "Provider no-show",
+			Status:    res.Status,
+			CreatedAt: time.Now(),
+		}
+		if err := se.Repo.AddInvoiceRefund(ctx, b.ID, refund); err != nil {
+			log.Printf("[NoShow] Failed to add refund %s to booking %s: %v", refund.RefundID, b.ID, err)
+		}
+		b.Invoice.Refunds = append(b.Invoice.Refunds, refund)
+	}
+	return refunded, nil
+}
+
+func refundRecorded(b *models.Booking, refundID string) bool {
+	for _, r := range b.Invoice.Refunds {
+		if r.RefundID == refundID {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckIn records a provider check-in on a confirmed booking. Setting off opens
+// the live location of an in-home booking, and completing the job closes it.
+// Reporting the customer absent needs an earlier arrival and the no-show threshold to have
+// passed since the later of the arrival and the booking start; the booking is
+// then closed as a customer no-show and stays paid.
+func (svc *DefaultBookingSessionService) CheckIn(providerID, bookingID string, req models.CheckInRequest) (*models.Booking, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	se := svc.SchedulerEngine
+	b, err := se.Repo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, ErrBookingNotFound
+	}
+	if b.ProviderID != providerID {
+		return nil, ErrNotBookingParticipant
+	}
+
+	now := time.Now()
+	switch req.Kind {
+	case models.CheckInOnTheWay:
+		if err := checkTripStart(b, now); err != nil {
+			return nil, err
+		}
+	case models.CheckInCompleted:
+		if findCheckIn(b, models.CheckInStarted) == nil {
+			return nil, ErrCheckInOutOfOrder
+		}
+	case models.CheckInCustomerAbsent:
+		arrived := findCheckIn(b, models.CheckInArrived)
+		if arrived == nil || findCheckIn(b, models.CheckInStarted) != nil {
+			return nil, ErrCustomerAbsentTooEarly
+		}
+		startsAt, err := bookingStartsAt(b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid booking date: %w", err)
+		}
+		waitFrom := arrived.At
+		if startsAt.After(waitFrom) {
+			waitFrom = startsAt
+		}
+		if now.Before(waitFrom.Add(noShowThreshold(0))) {
+			return nil, ErrCustomerAbsentTooEarly
+		}
+	}
+
+	checkIn := models.BookingCheckIn{
+		Kind:     req.Kind,
+		By:       providerID,
+		Location: req.Location,
+		At:       now,
+	}
+	if err := se.Repo.AddCheckIn(ctx, b.ID, checkIn); err != nil {
+		if errors.Is(err, schedulerRepo.ErrNoShowConflict) {
+			return nil, ErrCheckInClosed
+		}
+		return nil, err
+	}
+	b.CheckIns = append(b.CheckIns, checkIn)
+
+	switch req.Kind {
+	case models.CheckInOnTheWay:
+		se.startTrip(ctx, b, req.Location)
+	case models.CheckInCompleted:
+		se.endTrip(ctx, b.ID, models.CheckInCompleted)
+	case models.CheckInCustomerAbsent:
+		if err := se.flagCustomerNoShow(ctx, b); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// flagCustomerNoShow closes a booking the customer was absent for and counts it
+// against the user.
+func (se *DefaultSchedulingEngine) flagCustomerNoShow(ctx context.Context, b *models.Booking) error {
+	now := time.Now()
+	record := models.NoShowRecord{
+		Party:      models.NoShowByUser,
+		DetectedAt: now,
+		Resolution: models.NoShowCharged,
+		ResolvedAt: now,
+	}
+	if err := se.Repo.MarkNoShow(ctx, b, record); err != nil {
+		if errors.Is(err, schedulerRepo.ErrNoShowConflict) {
+			return ErrCheckInClosed
+		}
+		return err
+	}
+	b.Status, b.NoShow = "no_show", &record
+	se.endTrip(ctx, b.ID, "no_show")
+	se.CancelBookingReminders(b.ID)
+
+	user, _ := se.bookingParties(b)
+	se.notifyBooking("user", b.UserID, user.PreferredLanguage, utils.TemplateCustomerNoShow, b,
+		utils.TemplateParams{ProviderName: b.MinimalProviderDTO.ProviderName, When: bookingStartTime(b.Date, b.Start)})
+	return nil
+}
+
+// ResolveNoShow applies the user's choice after a provider no-show. Both
+// choices refund the booking; rebooking also returns other providers for the
+// same service, to be booked through a new session.
+func (svc *DefaultBookingSessionService) ResolveNoShow(userID, bookingID string, decision models.NoShowDecision) (*models.NoShowResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	se := svc.SchedulerEngine
+	b, err := se.Repo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, ErrBookingNotFound
+	}
+	if b.UserID != userID {
+		return nil, ErrNotBookingParticipant
+	}
+	if !noShowOpen(b) {
+		return nil, ErrNoShowClosed
+	}
+
+	resolution := models.NoShowRefunded
+	if decision.Action == "rebook" {
+		resolution = models.NoShowRebooked
+	}
+	record, err := se.refundNoShow(ctx, b, resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.NoShowResult{NoShow: record}
+	if decision.Action == "rebook" {
+		result.Alternatives = svc.rebookAlternatives(b)
+	}
+	return result, nil
+}
+
+// rebookAlternatives matches other providers for a missed booking.
+func (svc *DefaultBookingSessionService) rebookAlternatives(b *models.Booking) []models.ProviderDTO {
+	if svc.MatchingSvc == nil {
+		return nil
+	}
+	plan := models.ServicePlan{
+		ServiceType: b.ServiceType,
+		Mode:        b.Mode,
+		LocationGeo: b.ServiceLocation,
+		Date:        b.Date,
+		Units:       b.Units,
+		UnitType:    b.UnitType,
+		CountryCode: b.CountryCode,
+	}
+	matches, err := svc.MatchingSvc.MatchProviders(plan)
+	if err != nil {
+		log.Printf("[NoShow] Failed to match alternatives for booking %s: %v", b.ID, err)
+		return nil
+	}
+	alternatives := make([]models.ProviderDTO, 0, len(matches))
+	for _, p := range matches {
+		if p.ID != b.ProviderID {
+			alternatives = append(alternatives, p)
+		}
+	}
+	return alternatives
+}
+
+// bookingParties loads the user and provider of a booking for their
+// notification locale. Either may be empty when it cannot be loaded.
+func (se *DefaultSchedulingEngine) bookingParties(b *models.Booking) (*models.User, *models.Provider) {
+	user, err := se.UserService.GetUserByID(b.UserID)
+	if err != nil {
+		log.Printf("[Booking] Failed to fetch user %s: %v", b.UserID, err)
+		user = &models.User{ID: b.UserID, Username: b.UserMinimal.Username}
+	}
+	provider, err := se.ProviderRepo.GetByIDWithProjection(b.ProviderID, nil)
+	if err != nil {
+		log.Printf("[Booking] Failed to fetch provider %s: %v", b.ProviderID, err)
+		provider = &models.Provider{ID: b.ProviderID}
+	}
+	return user, provider
+}
+
+// notifyBooking stores a booking notification in the recipient's inbox and
+// pushes it.
+func (se *DefaultSchedulingEngine) notifyBooking(role, recipientID, locale, kind string, b *models.Booking, params utils.TemplateParams) {
+	if se.Notification == nil {
+		return
+	}
+	title, body, err := utils.RenderNotification(kind, locale, params)
+	if err != nil {
+		log.Printf("[Booking] Failed to render %s for booking %s: %v", kind, b.ID, err)
+		return
+	}
+	data := map[string]string{
+		"type":      kind,
+		"bookingId": b.ID,
+		"status":    b.Status,
+	}
+	if b.NoShow != nil {
+		data["party"] = b.NoShow.Party
+		data["resolution"] = b.NoShow.Resolution
+	}
+	n := models.Notification{
+		ID:        uuid.New().String(),
+		Type:      kind,
+		Title:     title,
+		Message:   body,
+		Data:      map[string]any{"bookingId": b.ID, "status": b.Status, "role": role},
+		CreatedAt: time.Now(),
+	}
+	go func() {
+		ctx := context.Background()
+		var err error
+		if err = se.Notification.AddToInbox(ctx, role, recipientID, n); err != nil {
+			log.Printf("[Booking] Failed to store notification for %s %s: %v", role, recipientID, err)
+		}
+		if role == "provider" {
+			err = se.Notification.NotifyProvider(ctx, recipientID, title, body, data)
+		} else {
+			err = se.Notification.NotifyUser(ctx, recipientID, title, body, data)
+		}
+		if err != nil {
+			log.Printf("[Booking] Failed to send %s to %s %s: %v", kind, role, recipientID, err)
+		}
+	}()
+}
@@ -22,6 +22,10 @@ func (s *DefaultBookingSessionService) InitiateSession(plan models.ServicePlan,
 
 	ctx := context.Background()
 	sessionID := uuid.New().String()
+	plan, err := s.applyServiceLocation(plan, userID)
+	if err != nil {
+		return "", nil, err
+	}
 
 	matchedProviders, err := s.MatchingSvc.MatchProviders(plan)
 	if err != nil {
@@ -53,6 +57,9 @@ func (s *DefaultBookingSessionService) InitiateSession(plan models.ServicePlan,
 		log.Printf("Error storing session in cache: %v", err)
 		return "", nil, fmt.Errorf("failed to store booking session: %w", err)
 	}
+	if err := RecordBookingDemand(ctx, plan.ServiceType, plan.LocationGeo, time.Now()); err != nil {
+		log.Printf("Failed to record demand for session %s: %v", sessionID, err)
+	}
 
 	log.Printf("Successfully initiated session: %s", sessionID)
 	return sessionID, matchedProviders, nil
@@ -99,7 +106,7 @@ func (s *DefaultBookingSessionService) UpdateSession(sessionID string, selectedP
 		Profile:          selectedDTO.Profile,
 	}
 
-	availabilityResult, err := s.SchedulerEngine.GetWeeklyAvailableSlots(selectedProvider, weekIndex, session.ServicePlan.Units)
+	availabilityResult, err := s.SchedulerEngine.GetWeeklyAvailableSlots(selectedProvider, weekIndex, session.ServicePlan.Units, session.ServicePlan.Currency, session.ServicePlan.CountryCode)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compute availability for provider: %w", err)
 	}
@@ -168,9 +175,16 @@ func (s *DefaultBookingSessionService) ConfirmBooking(sessionID string, confirme
 		Priority:            false,
 		UserPayment:         confirmedSlot.UserPayment,
 		CustomOption:        confirmedSlot.CustomOption,
+		QuoteID:             confirmedSlot.QuoteID,
+		PromoCode:           confirmedSlot.PromoCode,
+		CountryCode:         session.ServicePlan.CountryCode,
 		Subscription:        confirmedSlot.Subscription,
 		SubscriptionDetails: confirmedSlot.SubscriptionDetails,
 		Mode:                session.ServicePlan.Mode,
+		CatalogueID:         selectedProvider.ServiceCatalogue.ID,
+		BookingFor:          session.ServicePlan.BookingFor,
+		ServiceLocation:     session.ServicePlan.LocationGeo,
+		AddressID:           session.ServicePlan.AddressID,
 	}
 
 	result, err := s.SchedulerEngine.BookSlot(selectedProvider, req)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"bloomify/models"
@@ -11,7 +13,9 @@ import (
 
 	"github.com/google/uuid"
 	stripe "github.com/stripe/stripe-go/v76"
+	"github.com/stripe/stripe-go/v76/customer"
 	"github.com/stripe/stripe-go/v76/paymentintent"
+	"github.com/stripe/stripe-go/v76/refund"
 	"go.uber.org/zap"
 )
 
@@ -19,6 +23,11 @@ type PaymentHandler interface {
 	ProcessPayment(ctx context.Context, req models.PaymentRequest) (*models.Invoice, error)
 }
 
+// StripeCustomers resolves the Stripe customer cards are saved against.
+type StripeCustomers interface {
+	StripeCustomerID(ctx context.Context, userID string) (string, error)
+}
+
 type UnifiedPaymentHandler struct {
 	logger      *zap.Logger
 	userService user.UserService
@@ -54,6 +63,10 @@ func (h *UnifiedPaymentHandler) ProcessPayment(
 			return h.captureCardPayment(ctx, req.PaymentIntentID, req)
 		case "cancel":
 			return nil, h.cancelCardPayment(ctx, req.PaymentIntentID)
+		case "charge":
+			return h.chargeSavedCard(ctx, req)
+		case "refund":
+			return h.refundCardPayment(ctx, req)
 		default:
 			return nil, fmt.Errorf("unsupported card action: %s", req.Action)
 		}
@@ -77,11 +90,17 @@ func validateRequest(req models.PaymentRequest) error {
 	case "cash":
 		return nil
 	case "card":
+		if req.Action == "charge" {
+			if req.CustomerID == "" || req.PaymentMethodID == "" {
+				return errors.New("missing saved card for off-session charge")
+			}
+			return nil
+		}
 		if req.PaymentIntentID == "" {
 			return errors.New("missing PaymentIntent ID for card payment")
 		}
 		if req.Action == "" {
-			return errors.New("missing Action for card payment (authorize|capture|cancel)")
+			return errors.New("missing Action for card payment (authorize|capture|cancel|charge|refund)")
 		}
 		return nil
 	default:
@@ -139,6 +158,15 @@ func (h *UnifiedPaymentHandler) authorizeCardPayment(
 		return nil, fmt.Errorf("payment not authorized, status: %s", intent.Status)
 	}
 
+	// The amount alone says nothing if the intent was made out in another currency.
+	if req.Currency != "" && !strings.EqualFold(string(intent.Currency), req.Currency) {
+		return nil, &CurrencyMismatchError{Expected: strings.ToUpper(req.Currency), Got: strings.ToUpper(string(intent.Currency))}
+	}
+
+	if float64(intent.Amount)/100.0 < req.Amount {
+		return nil, fmt.Errorf("authorized amount %.2f is less than %.2f", float64(intent.Amount)/100.0, req.Amount)
+	}
+
 	inv := &models.Invoice{
 		InvoiceID: uuid.New().String(),
 		UserID:    req.UserID,
@@ -150,6 +178,11 @@ func (h *UnifiedPaymentHandler) authorizeCardPayment(
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	// Only cards saved for off-session use can be charged again later.
+	if intent.SetupFutureUsage == stripe.PaymentIntentSetupFutureUsageOffSession && intent.Customer != nil && intent.PaymentMethod != nil {
+		inv.CustomerID = intent.Customer.ID
+		inv.PaymentMethodID = intent.PaymentMethod.ID
+	}
 
 	h.logger.Info("Payment authorized",
 		zap.String("invoiceID", inv.InvoiceID),
@@ -158,13 +191,31 @@ func (h *UnifiedPaymentHandler) authorizeCardPayment(
 	return inv, nil
 }
 
+// captureCardPayment captures req.Amount of an authorized PaymentIntent and
+// releases the rest of the hold. Amounts above what was authorized are
+// rejected.
 func (h *UnifiedPaymentHandler) captureCardPayment(
 	ctx context.Context,
 	intentID string,
 	req models.PaymentRequest,
 ) (*models.Invoice, error) {
 
-	pi, err := paymentintent.Capture(intentID, nil)
+	amount := int64(math.Round(req.Amount * 100))
+	if amount <= 0 {
+		return nil, fmt.Errorf("invalid capture amount %.2f", req.Amount)
+	}
+	intent, err := paymentintent.Get(intentID, nil)
+	if err != nil {
+		h.logger.Error("Stripe: unable to fetch PaymentIntent", zap.Error(err))
+		return nil, fmt.Errorf("stripe verification failed: %w", err)
+	}
+	if amount > intent.AmountCapturable {
+		return nil, fmt.Errorf("capture amount %.2f exceeds authorized amount %.2f", req.Amount, float64(intent.AmountCapturable)/100.0)
+	}
+
+	pi, err := paymentintent.Capture(intentID, &stripe.PaymentIntentCaptureParams{
+		AmountToCapture: stripe.Int64(amount),
+	})
 	if err != nil {
 		h.logger.Error("Stripe capture failed", zap.Error(err))
 		return nil, fmt.Errorf("stripe capture failed: %w", err)
@@ -173,7 +224,7 @@ func (h *UnifiedPaymentHandler) captureCardPayment(
 	inv := &models.Invoice{
 		InvoiceID: uuid.New().String(),
 		UserID:    req.UserID,
-		Amount:    float64(pi.Amount) / 100.0,
+		Amount:    float64(pi.AmountReceived) / 100.0,
 		Currency:  string(pi.Currency),
 		Method:    "card",
 		PaymentID: pi.ID,
@@ -181,6 +232,12 @@ func (h *UnifiedPaymentHandler) captureCardPayment(
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	if pi.Customer != nil {
+		inv.CustomerID = pi.Customer.ID
+	}
+	if pi.PaymentMethod != nil {
+		inv.PaymentMethodID = pi.PaymentMethod.ID
+	}
 
 	h.logger.Info("Payment captured",
 		zap.String("invoiceID", inv.InvoiceID),
@@ -205,3 +262,126 @@ func (h *UnifiedPaymentHandler) cancelCardPayment(
 	)
 	return nil
 }
+
+// chargeSavedCard charges a saved card while the user is not in the app, e.g.
+// the balance of a deposit booking. Cards that need authentication fail and
+// the user has to pay in the app instead.
+func (h *UnifiedPaymentHandler) chargeSavedCard(
+	ctx context.Context,
+	req models.PaymentRequest,
+) (*models.Invoice, error) {
+
+	params := &stripe.PaymentIntentParams{
+		Amount:        stripe.Int64(int64(math.Round(req.Amount * 100))),
+		Currency:      stripe.String(strings.ToLower(req.Currency)),
+		Customer:      stripe.String(req.CustomerID),
+		PaymentMethod: stripe.String(req.PaymentMethodID),
+		OffSession:    stripe.Bool(true),
+		Confirm:       stripe.Bool(true),
+		Metadata:      req.Metadata,
+	}
+	if req.IdempotencyKey != "" {
+		params.SetIdempotencyKey(req.IdempotencyKey)
+	}
+	pi, err := paymentintent.New(params)
+	if err != nil {
+		h.logger.Error("Stripe off-session charge failed", zap.Error(err))
+		return nil, fmt.Errorf("stripe charge failed: %w", err)
+	}
+	if pi.Status != stripe.PaymentIntentStatusSucceeded {
+		return nil, fmt.Errorf("charge not completed, status: %s", pi.Status)
+	}
+
+	inv := &models.Invoice{
+		InvoiceID:       uuid.New().String(),
+		UserID:          req.UserID,
+		Amount:          float64(pi.Amount) / 100.0,
+		Currency:        string(pi.Currency),
+		Method:          "card",
+		PaymentID:       pi.ID,
+		Status:          "completed",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		CustomerID:      req.CustomerID,
+		PaymentMethodID: req.PaymentMethodID,
+	}
+
+	h.logger.Info("Saved card charged",
+		zap.String("invoiceID", inv.InvoiceID),
+	)
+
+	return inv, nil
+}
+
+// refundCardPayment returns req.Amount of a captured PaymentIntent to the card.
+// The invoice it returns describes the refund: PaymentID is the Stripe refund.
+func (h *UnifiedPaymentHandler) refundCardPayment(
+	ctx context.Context,
+	req models.PaymentRequest,
+) (*models.Invoice, error) {
+
+	params := &stripe.RefundParams{
+		PaymentIntent: stripe.String(req.PaymentIntentID),
+		Amount:        stripe.Int64(int64(math.Round(req.Amount * 100))),
+	}
+	for k, v := range req.Metadata {
+		params.AddMetadata(k, v)
+	}
+	// Repeating a refund of the same amount returns the first one.
+	params.SetIdempotencyKey(fmt.Sprintf("refund-%s-%d", req.PaymentIntentID, *params.Amount))
+	r, err := refund.New(params)
+	if err != nil {
+		h.logger.Error("Stripe refund failed", zap.Error(err))
+		return nil, fmt.Errorf("stripe refund failed: %w", err)
+	}
+	if r.Status == stripe.RefundStatusFailed || r.Status == stripe.RefundStatusCanceled {
+		return nil, fmt.Errorf("refund not completed, status: %s", r.Status)
+	}
+
+	inv := &models.Invoice{
+		InvoiceID: uuid.New().String(),
+		UserID:    req.UserID,
+		Amount:    float64(r.Amount) / 100.0,
+		Currency:  string(r.Currency),
+		Method:    "card",
+		PaymentID: r.ID,
+		Status:    string(r.Status),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	h.logger.Info("Payment refunded",
+		zap.String("intentID", req.PaymentIntentID),
+		zap.String("refundID", r.ID),
+	)
+
+	return inv, nil
+}
+
+// StripeCustomerID returns the user's Stripe customer, creating it on first
+// use. Cards are saved against it for off-session charges.
+func (h *UnifiedPaymentHandler) StripeCustomerID(ctx context.Context, userID string) (string, error) {
+	u, err := h.userService.GetUserByID(userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load user %s: %w", userID, err)
+	}
+	if u.StripeCustomerID != "" {
+		return u.StripeCustomerID, nil
+	}
+
+	params := &stripe.CustomerParams{
+		Email:    stripe.String(u.Email),
+		Name:     stripe.String(u.Username),
+		Metadata: map[string]string{"userId": userID},
+	}
+	c, err := customer.New(params)
+	if err != nil {
+		h.logger.Error("Stripe customer creation failed", zap.Error(err))
+		return "", fmt.Errorf("stripe customer creation failed: %w", err)
+	}
+
+	if _, err := h.userService.UpdateUser(models.UserUpdateRequest{ID: &userID, StripeCustomerID: &c.ID}); err != nil {
+		return "", fmt.Errorf("failed to save stripe customer: %w", err)
+	}
+	return c.ID, nil
+}
@@ -0,0 +1,307 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"bloomify/config"
+	schedulerRepo "bloomify/database/repository/scheduler"
+	"bloomify/models"
+	"bloomify/services/tasks"
+	"bloomify/utils"
+
+	"github.com/hibiken/asynq"
+)
+
+var (
+	// ErrBalanceSettled is returned when a booking has no outstanding balance.
+	ErrBalanceSettled = errors.New("booking has no outstanding balance")
+	// ErrBalanceInProgress is returned while another charge of the balance is in
+	// flight.
+	ErrBalanceInProgress = errors.New("the balance is already being charged")
+)
+
+// balanceClaimTimeout is how long a charge may hold the balance. A claim older
+// than that was left by a crashed worker and is taken over; the charge keeps its
+// idempotency key, so Stripe returns the first result instead of charging again.
+const balanceClaimTimeout = 5 * time.Minute
+
+// depositPlan returns the payment plan of a card booking with a provider that
+// takes deposits, or nil when the booking is paid in full. Bookings whose
+// balance would already be due are paid in full.
+func depositPlan(b *models.Booking, provider models.Provider, now time.Time) *models.PaymentPlan {
+	pct := provider.PaymentDetails.DepositPercent
+	if b.UserPayment.PaymentMethod != "card" || pct <= 0 || pct >= 100 || b.Quote == nil {
+		return nil
+	}
+	hours := provider.PaymentDetails.BalanceDueHours
+	if hours <= 0 {
+		hours = config.AppConfig.BalanceDueHours
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", b.Date, now.Location())
+	if err != nil {
+		return nil
+	}
+	dueAt := day.Add(time.Duration(b.Start)*time.Minute - time.Duration(hours)*time.Hour)
+	if !dueAt.After(now) {
+		return nil
+	}
+
+	deposit := roundCents(b.Quote.ChargeTotal * pct / 100)
+	return &models.PaymentPlan{
+		DepositPercent: pct,
+		DepositAmount:  deposit,
+		BalanceAmount:  roundCents(b.Quote.ChargeTotal - deposit),
+		Currency:       b.Quote.ChargeCurrency,
+		BalanceDueAt:   dueAt,
+		BalanceStatus:  models.BalanceScheduled,
+	}
+}
+
+// authorizeDeposit checks the booking's PaymentIntent covers the deposit and
+// saves the card for the balance.
+func (se *DefaultSchedulingEngine) authorizeDeposit(ctx context.Context, b *models.Booking, plan *models.PaymentPlan) error {
+	auth, err := se.PaymentHandler.ProcessPayment(ctx, models.PaymentRequest{
+		UserID:          b.UserID,
+		Amount:          plan.DepositAmount,
+		Currency:        plan.Currency,
+		Method:          "card",
+		PaymentIntentID: b.UserPayment.PaymentIntentId,
+		Action:          "authorize",
+	})
+	if err != nil {
+		return err
+	}
+	if auth.CustomerID == "" || auth.PaymentMethodID == "" {
+		return errors.New("deposit bookings need a saved card; create the payment intent with savePaymentMethod")
+	}
+	plan.CustomerID = auth.CustomerID
+	plan.PaymentMethodID = auth.PaymentMethodID
+	return nil
+}
+
+// startPaymentPlan saves the plan once exactly the deposit is captured and
+// schedules the balance charge.
+func (se *DefaultSchedulingEngine) startPaymentPlan(ctx context.Context, b *models.Booking) {
+	plan := b.PaymentPlan
+	if err := se.Repo.UpdatePaymentPlan(ctx, b.ID, plan, b.Status); err != nil {
+		log.Printf("[PaymentPlan] Failed to save payment plan of booking %s: %v", b.ID, err)
+	}
+
+	if se.AsynqClient == nil {
+		log.Printf("[PaymentPlan] No task queue; balance of booking %s must be charged manually", b.ID)
+		return
+	}
+	task, opts, err := tasks.NewBalanceChargeTask(b.ID, plan.BalanceDueAt, config.AppConfig.BalanceChargeRetries)
+	if err != nil {
+		log.Printf("[PaymentPlan] Failed to create balance task for booking %s: %v", b.ID, err)
+		return
+	}
+	if _, err := se.AsynqClient.Enqueue(task, opts...); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		log.Printf("[PaymentPlan] Failed to schedule balance of booking %s: %v", b.ID, err)
+	}
+}
+
+// ChargeBookingBalance charges the balance of a deposit booking to the saved
+// card. A failed charge returns an error so the task queue retries it; the
+// user is told on the first failure, and when final is set the booking is
+// marked payment_required and both sides are told.
+func (se *DefaultSchedulingEngine) ChargeBookingBalance(ctx context.Context, bookingID string, final bool) error {
+	b, err := se.Repo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		log.Printf("[PaymentPlan] Booking %s not found, dropping balance charge: %v", bookingID, err)
+		return nil
+	}
+	plan := b.PaymentPlan
+	if plan == nil || plan.BalanceStatus == models.BalancePaid || b.Status == "cancelled" || b.Status == "no_show" {
+		return nil
+	}
+	if err := se.claimBalance(ctx, b); err != nil {
+		if errors.Is(err, ErrBalanceInProgress) && final {
+			// The charge in flight settles the balance either way.
+			return nil
+		}
+		return fmt.Errorf("balance charge for booking %s: %w", b.ID, err)
+	}
+
+	paid, err := se.PaymentHandler.ProcessPayment(ctx, models.PaymentRequest{
+		UserID:          b.UserID,
+		Amount:          plan.BalanceAmount,
+		Currency:        plan.Currency,
+		Method:          "card",
+		Action:          "charge",
+		CustomerID:      plan.CustomerID,
+		PaymentMethodID: plan.PaymentMethodID,
+		IdempotencyKey:  fmt.Sprintf("balance-%s-%d", b.ID, plan.Attempts),
+		Metadata: map[string]string{
+			"bookingId":  b.ID,
+			"providerId": b.ProviderID,
+			"context":    "balance",
+		},
+	})
+
+	if err != nil {
+		plan.LastError = err.Error()
+		plan.BalanceStatus = models.BalanceRetrying
+		status := ""
+		if final {
+			plan.BalanceStatus = models.BalanceFailed
+			status = "payment_required"
+		}
+		if uerr := se.Repo.UpdatePaymentPlan(ctx, b.ID, plan, status); uerr != nil {
+			log.Printf("[PaymentPlan] Failed to save payment plan of booking %s: %v", b.ID, uerr)
+		}
+		switch {
+		case final:
+			se.notifyBalance(b, utils.TemplateBalanceFailed, true)
+		case plan.Attempts == 1:
+			se.notifyBalance(b, utils.TemplateBalanceDeclined, false)
+		}
+		return fmt.Errorf("balance charge for booking %s failed: %w", b.ID, err)
+	}
+
+	se.completeBalance(ctx, b, paid.PaymentID)
+	return nil
+}
+
+// PayBalance lets the user pay an outstanding balance in the app with a new
+// PaymentIntent, e.g. after the automatic charge failed.
+func (svc *DefaultBookingSessionService) PayBalance(userID, bookingID, paymentIntentID string) (*models.PaymentPlan, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	se := svc.SchedulerEngine
+	b, err := se.Repo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, ErrBookingNotFound
+	}
+	if b.UserID != userID {
+		return nil, ErrNotBookingParticipant
+	}
+	plan := b.PaymentPlan
+	if plan == nil || plan.BalanceStatus == models.BalancePaid || b.Status == "no_show" {
+		return nil, ErrBalanceSettled
+	}
+	if paymentIntentID == "" {
+		return nil, errors.New("paymentIntentId is required")
+	}
+	from := plan.BalanceStatus
+	if err := se.claimBalance(ctx, b); err != nil {
+		return nil, err
+	}
+	// Hand the balance back to the scheduled charge if this payment fails.
+	release := func(err error) (*models.PaymentPlan, error) {
+		plan.BalanceStatus = from
+		plan.LastError = err.Error()
+		plan.ClaimedAt = time.Time{}
+		if uerr := se.Repo.UpdatePaymentPlan(ctx, b.ID, plan, ""); uerr != nil {
+			log.Printf("[PaymentPlan] Failed to save payment plan of booking %s: %v", b.ID, uerr)
+		}
+		return nil, fmt.Errorf("balance payment failed: %w", err)
+	}
+
+	req := models.PaymentRequest{
+		UserID:          b.UserID,
+		Amount:          plan.BalanceAmount,
+		Currency:        plan.Currency,
+		Method:          "card",
+		PaymentIntentID: paymentIntentID,
+		Action:          "authorize",
+	}
+	if _, err := se.PaymentHandler.ProcessPayment(ctx, req); err != nil {
+		return release(err)
+	}
+	req.Action = "capture"
+	paid, err := se.PaymentHandler.ProcessPayment(ctx, req)
+	if err != nil {
+		return release(err)
+	}
+	se.completeBalance(ctx, b, paid.PaymentID)
+	return b.PaymentPlan, nil
+}
+
+// claimBalance moves the balance to processing before it is charged, so the
+// scheduled charge and an in-app payment cannot both take it. Each claim is a
+// new attempt, except when a stale claim is taken over.
+func (se *DefaultSchedulingEngine) claimBalance(ctx context.Context, b *models.Booking) error {
+	plan := b.PaymentPlan
+	now := time.Now()
+	from, fromAttempts := plan.BalanceStatus, plan.Attempts
+	if from == models.BalanceProcessing {
+		if now.Sub(plan.ClaimedAt) < balanceClaimTimeout {
+			return ErrBalanceInProgress
+		}
+	} else {
+		plan.Attempts++
+	}
+	plan.BalanceStatus = models.BalanceProcessing
+	plan.ClaimedAt = now
+
+	err := se.Repo.ClaimPaymentPlan(ctx, b.ID, plan, from, fromAttempts)
+	if errors.Is(err, schedulerRepo.ErrPaymentPlanConflict) {
+		return ErrBalanceInProgress
+	}
+	return err
+}
+
+// completeBalance marks the balance paid, restores a booking that was waiting
+// for it, and books the provider's earnings.
+func (se *DefaultSchedulingEngine) completeBalance(ctx context.Context, b *models.Booking, paymentID string) {
+	plan := b.PaymentPlan
+	plan.BalanceStatus = models.BalancePaid
+	plan.BalancePaymentID = paymentID
+	plan.LastError = ""
+	plan.ClaimedAt = time.Time{}
+	plan.PaidAt = time.Now()
+	status := ""
+	if b.Status == "payment_required" {
+		status = "confirmed"
+		b.Status = status
+	}
+	if err := se.Repo.UpdatePaymentPlan(ctx, b.ID, plan, status); err != nil {
+		log.Printf("[PaymentPlan] Failed to save payment plan of booking %s: %v", b.ID, err)
+	}
+	se.recordServiceEarnings(ctx, b)
+	publishPaymentCaptured(ctx, b, "balance", plan.BalanceAmount, plan.Currency)
+	se.notifyBalance(b, utils.TemplateBalancePaid, false)
+}
+
+// notifyBalance pushes a balance update to the user, and to the provider too
+// when the booking is at risk, each in their language.
+func (se *DefaultSchedulingEngine) notifyBalance(b *models.Booking, kind string, notifyProvider bool) {
+	if se.Notification == nil {
+		return
+	}
+	data := map[string]string{
+		"type":          "balance_payment",
+		"bookingId":     b.ID,
+		"balanceStatus": b.PaymentPlan.BalanceStatus,
+	}
+	params := utils.TemplateParams{
+		ProviderName: b.MinimalProviderDTO.ProviderName,
+		Amount:       b.PaymentPlan.BalanceAmount,
+		Currency:     b.PaymentPlan.Currency,
+		When:         bookingStartTime(b.Date, b.Start),
+	}
+	go func() {
+		user, provider := se.bookingParties(b)
+		params.CustomerName = user.Username
+		if title, body, err := utils.RenderNotification(kind, user.PreferredLanguage, params); err != nil {
+			log.Printf("[PaymentPlan] Failed to render %s notification for booking %s: %v", kind, b.ID, err)
+		} else if err := se.Notification.NotifyUser(context.Background(), b.UserID, title, body, data); err != nil {
+			log.Printf("[PaymentPlan] Failed to notify user %s: %v", b.UserID, err)
+		}
+		if !notifyProvider {
+			return
+		}
+		if title, body, err := utils.RenderNotification(utils.TemplateBalanceUnpaid, provider.PreferredLanguage, params); err != nil {
+			log.Printf("[PaymentPlan] Failed to render %s notification for booking %s: %v", utils.TemplateBalanceUnpaid, b.ID, err)
+		} else if err := se.Notification.NotifyProvider(context.Background(), b.ProviderID, title, body, data); err != nil {
+			log.Printf("[PaymentPlan] Failed to notify provider %s: %v", b.ProviderID, err)
+		}
+	}()
+}
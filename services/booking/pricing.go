@@ -1,39 +1,34 @@
 package booking
 
 import (
-	"math"
-
 	"bloomify/models"
 )
 
+// earlyBirdTier returns the tier and rate adjustment for the next unit booked in an
+// earlybird slot, given the units already booked. It follows the slot's tier
+// schedule, so availability and confirmation price units the same way.
+func earlyBirdTier(eb models.EarlyBirdSlotData, capacity int, usage int) (string, float64) {
+	rate := eb.RateAt(capacity, usage)
+	switch {
+	case rate < 0:
+		return models.TierEarlyBird, rate
+	case rate > 0:
+		return models.TierLate, rate
+	}
+	return models.TierStandard, 0
+}
+
 // GetEarlyBirdNextUnitPrice calculates the price for the next unit to be booked in an earlybird slot.
 func GetEarlyBirdNextUnitPrice(basePrice float64, eb models.EarlyBirdSlotData, capacity int, usage int) float64 {
-	nextUnitIndex := usage + 1
-	earlyThreshold := int(math.Ceil(float64(capacity) * 0.25))
-	standardThreshold := int(math.Ceil(float64(capacity) * 0.75))
-	if nextUnitIndex <= earlyThreshold {
-		return basePrice * (1 - eb.EarlyBirdDiscountRate)
-	} else if nextUnitIndex <= standardThreshold {
-		return basePrice
-	} else {
-		return basePrice * (1 + eb.LateSurchargeRate)
-	}
+	_, rate := earlyBirdTier(eb, capacity, usage)
+	return basePrice * (1 + rate)
 }
 
 // CalculateEarlyBirdPrice computes the total price for booking 'units' in an earlybird slot.
 func CalculateEarlyBirdPrice(basePrice float64, eb models.EarlyBirdSlotData, capacity, usage, units int) float64 {
 	totalPrice := 0.0
-	earlyThreshold := int(math.Ceil(float64(capacity) * 0.25))
-	standardThreshold := int(math.Ceil(float64(capacity) * 0.75))
-	for i := 1; i <= units; i++ {
-		unitIndex := usage + i
-		if unitIndex <= earlyThreshold {
-			totalPrice += basePrice * (1 - eb.EarlyBirdDiscountRate)
-		} else if unitIndex <= standardThreshold {
-			totalPrice += basePrice
-		} else {
-			totalPrice += basePrice * (1 + eb.LateSurchargeRate)
-		}
+	for i := 0; i < units; i++ {
+		totalPrice += GetEarlyBirdNextUnitPrice(basePrice, eb, capacity, usage+i)
 	}
 	return totalPrice
 }
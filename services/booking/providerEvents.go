@@ -0,0 +1,219 @@
+package booking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"bloomify/config"
+	"bloomify/models"
+	"bloomify/utils"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// A provider's events are appended to the Redis stream
+// provider:events:<providerID>:log, which backs replay, and published on
+// provider:events:<providerID> to every API instance with an open stream.
+const (
+	providerEventChannelPrefix = "provider:events:"
+	providerEventLogSuffix     = ":log"
+)
+
+// ErrInvalidEventID is returned when resuming a provider's event stream from
+// something that is not one of its event IDs.
+var ErrInvalidEventID = errors.New("last event ID is not a valid event ID")
+
+// PublishProviderEvent records a domain event for a provider and sends it to
+// the provider's open dashboard streams. Failures are logged; the event is then
+// lost to the stream but the operation that raised it stands.
+func PublishProviderEvent(ctx context.Context, providerID string, event models.ProviderEvent) {
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("[ProviderEvents] Failed to encode %s event for provider %s: %v", event.Type, providerID, err)
+		return
+	}
+
+	backlog := config.AppConfig.ProviderEventBacklog
+	if backlog <= 0 {
+		backlog = 500
+	}
+	retention := config.AppConfig.ProviderEventRetentionHours
+	if retention <= 0 {
+		retention = 72
+	}
+	cache := utils.GetBookingCacheClient()
+	key := providerEventChannelPrefix + providerID + providerEventLogSuffix
+	id, err := cache.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: int64(backlog),
+		Approx: true,
+		Values: map[string]interface{}{"event": body},
+	}).Result()
+	if err != nil {
+		log.Printf("[ProviderEvents] Failed to record %s event for provider %s: %v", event.Type, providerID, err)
+		return
+	}
+	if err := cache.Expire(ctx, key, time.Duration(retention)*time.Hour).Err(); err != nil {
+		log.Printf("[ProviderEvents] Failed to set retention of provider %s events: %v", providerID, err)
+	}
+
+	event.ID = id
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("[ProviderEvents] Failed to encode %s event for provider %s: %v", event.Type, providerID, err)
+		return
+	}
+	if err := cache.Publish(ctx, providerEventChannelPrefix+providerID, data).Err(); err != nil {
+		log.Printf("[ProviderEvents] Failed to publish %s event for provider %s: %v", event.Type, providerID, err)
+	}
+}
+
+// publishPaymentCaptured tells a booking's provider that a payment of kind
+// "booking", "deposit", "balance" or an adjustment kind was taken.
+func publishPaymentCaptured(ctx context.Context, b *models.Booking, kind string, amount float64, currency string) {
+	PublishProviderEvent(ctx, b.ProviderID, models.ProviderEvent{
+		Type:      models.ProviderEventPaymentCaptured,
+		BookingID: b.ID,
+		Data: map[string]any{
+			"kind":     kind,
+			"amount":   amount,
+			"currency": strings.ToUpper(currency),
+		},
+	})
+}
+
+// publishSlotFilled tells a provider that a booking closed one of their slots.
+func publishSlotFilled(ctx context.Context, providerID, date string, slot models.TimeSlot, b *models.Booking, reason string) {
+	PublishProviderEvent(ctx, providerID, models.ProviderEvent{
+		Type:      models.ProviderEventSlotFilled,
+		BookingID: b.ID,
+		Data: map[string]any{
+			"slotId": slot.ID,
+			"date":   date,
+			"start":  slot.Start,
+			"end":    slot.End,
+			"reason": reason,
+		},
+	})
+}
+
+// SubscribeProviderEvents streams a provider's domain events until ctx ends.
+// With lastEventID the events recorded after it are sent first.
+func (svc *DefaultBookingSessionService) SubscribeProviderEvents(ctx context.Context, providerID, lastEventID string) (<-chan models.ProviderEvent, error) {
+	if lastEventID != "" {
+		if _, _, ok := parseStreamID(lastEventID); !ok {
+			return nil, ErrInvalidEventID
+		}
+	}
+
+	cache := utils.GetBookingCacheClient()
+	// Subscribe before reading the backlog so nothing recorded in between is
+	// missed; events seen twice are dropped by ID.
+	pubsub := cache.Subscribe(ctx, providerEventChannelPrefix+providerID)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to provider events: %w", err)
+	}
+
+	var missed []models.ProviderEvent
+	if lastEventID != "" {
+		entries, err := cache.XRange(ctx, providerEventChannelPrefix+providerID+providerEventLogSuffix, lastEventID, "+").Result()
+		if err != nil {
+			pubsub.Close()
+			return nil, fmt.Errorf("failed to load missed provider events: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.ID == lastEventID {
+				continue
+			}
+			raw, _ := entry.Values["event"].(string)
+			var event models.ProviderEvent
+			if err := json.Unmarshal([]byte(raw), &event); err != nil {
+				log.Printf("[ProviderEvents] Skipping malformed event %s of provider %s: %v", entry.ID, providerID, err)
+				continue
+			}
+			event.ID = entry.ID
+			missed = append(missed, event)
+		}
+	}
+
+	out := make(chan models.ProviderEvent, 16)
+	go func() {
+		defer close(out)
+		defer pubsub.Close()
+		last := lastEventID
+		send := func(event models.ProviderEvent) bool {
+			select {
+			case out <- event:
+				last = event.ID
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for _, event := range missed {
+			if !send(event) {
+				return
+			}
+		}
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				var event models.ProviderEvent
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					log.Printf("[ProviderEvents] Dropping malformed event for provider %s: %v", providerID, err)
+					continue
+				}
+				if last != "" && !streamIDAfter(event.ID, last) {
+					continue
+				}
+				if !send(event) {
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// parseStreamID splits a Redis stream ID, "<milliseconds>-<sequence>".
+func parseStreamID(id string) (uint64, uint64, bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
+
+// streamIDAfter reports whether stream ID a comes after b.
+func streamIDAfter(a, b string) bool {
+	aMs, aSeq, okA := parseStreamID(a)
+	bMs, bSeq, okB := parseStreamID(b)
+	if !okA || !okB {
+		return true
+	}
+	return aMs > bMs || (aMs == bMs && aSeq > bSeq)
+}
@@ -0,0 +1,264 @@
+package booking
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"bloomify/config"
+	"bloomify/models"
+)
+
+var (
+	// ErrQuoteInvalid is returned when a quote ID is malformed or its signature does not verify.
+	ErrQuoteInvalid = errors.New("invalid price quote")
+	// ErrQuoteExpired is returned when a quote ID is authentic but past its expiry.
+	ErrQuoteExpired = errors.New("price quote has expired")
+)
+
+const defaultQuoteTTL = 15 * time.Minute
+
+func quoteTTL() time.Duration {
+	if config.AppConfig.QuoteTTLMinutes > 0 {
+		return time.Duration(config.AppConfig.QuoteTTLMinutes) * time.Minute
+	}
+	return defaultQuoteTTL
+}
+
+func quoteSigningKey() []byte {
+	if config.AppConfig.QuoteSigningSecret != "" {
+		return []byte(config.AppConfig.QuoteSigningSecret)
+	}
+	return []byte(config.AppConfig.JWTSecret)
+}
+
+// effectiveSlotModel returns the pricing model for a slot. Freelancers and
+// single-use slots are always priced flat.
+func effectiveSlotModel(slot models.TimeSlot, provider models.Provider) string {
+	if provider.Profile.ProviderType == "freelancer" || slot.CapacityMode == models.CapacitySingleUse {
+		return "flatrate"
+	}
+	return slot.SlotModel
+}
+
+// priorityRemaining returns the free units of an urgency slot's priority pool.
+func priorityRemaining(slot models.TimeSlot, provider models.Provider) int {
+	if effectiveSlotModel(slot, provider) != "urgency" || slot.Urgency == nil || !slot.Urgency.PriorityActive {
+		return 0
+	}
+	return max(slot.Urgency.ReservedPriority-slot.BookedUnitsPriority, 0)
+}
+
+// priceUnits allocates up to `units` units of the slot to pricing tiers. Standard
+// stock is always used first; urgency slots top up from the priority pool.
+// Dynamic slots are priced from the demand signals at now.
+func priceUnits(slot models.TimeSlot, provider models.Provider, units int, now time.Time) ([]models.PriceQuoteLine, int, int, error) {
+	remaining, ok := getRemainingUnits(slot, provider)
+	if !ok {
+		return nil, 0, 0, fmt.Errorf("slot is no longer available")
+	}
+	remaining = max(remaining, 0)
+
+	var (
+		lines         []models.PriceQuoteLine
+		standardUnits = min(units, remaining)
+		priorityUnits int
+	)
+
+	addUnits := func(tier string, rate float64, n int) {
+		if n <= 0 {
+			return
+		}
+		if last := len(lines) - 1; last >= 0 && lines[last].Tier == tier && lines[last].Rate == rate {
+			lines[last].Units += n
+			lines[last].Amount = lines[last].UnitPrice * float64(lines[last].Units)
+			return
+		}
+		unitPrice := slot.BasePrice * (1 + rate)
+		lines = append(lines, models.PriceQuoteLine{
+			Tier:      tier,
+			Units:     n,
+			Rate:      rate,
+			UnitPrice: unitPrice,
+			Amount:    unitPrice * float64(n),
+		})
+	}
+
+	switch model := effectiveSlotModel(slot, provider); model {
+	case "flatrate":
+		addUnits(models.TierStandard, 0, standardUnits)
+
+	case "earlybird":
+		if slot.EarlyBird == nil {
+			return nil, 0, 0, fmt.Errorf("earlybird pricing model missing data")
+		}
+		for i := 0; i < standardUnits; i++ {
+			tier, rate := earlyBirdTier(*slot.EarlyBird, slot.Capacity, slot.BookedUnitsStandard+i)
+			addUnits(tier, rate, 1)
+		}
+
+	case "dynamic":
+		if err := validateDynamicSlot(slot.Dynamic); err != nil {
+			return nil, 0, 0, err
+		}
+		multiplier := DynamicMultiplier(*slot.Dynamic, dynamicSignals(slot, now))
+		addUnits(models.TierDynamic, multiplier-1, standardUnits)
+
+	case "urgency":
+		if slot.Urgency == nil {
+			return nil, 0, 0, fmt.Errorf("urgency pricing model missing data")
+		}
+		addUnits(models.TierStandard, 0, standardUnits)
+		priorityUnits = min(units-standardUnits, priorityRemaining(slot, provider))
+		addUnits(models.TierPriority, slot.Urgency.PrioritySurchargeRate, priorityUnits)
+
+	default:
+		return nil, 0, 0, fmt.Errorf("unknown slot model: %q", model)
+	}
+
+	if standardUnits+priorityUnits <= 0 {
+		return nil, 0, 0, fmt.Errorf("slot is no longer available")
+	}
+	return lines, standardUnits, priorityUnits, nil
+}
+
+// buildQuote applies the custom option to priced units, adds the platform fee
+// and tax of countryCode, locks the currency conversion and signs the result.
+// Prices are in the provider's currency; displayCurrency only adds an
+// informational converted total.
+func buildQuote(slot models.TimeSlot, provider models.Provider, lines []models.PriceQuoteLine, standardUnits, priorityUnits, requested int, option models.CustomOption, countryCode, displayCurrency string, now time.Time) (*models.PriceQuote, error) {
+	currency, chargeCurrency := settlementCurrencies(slot, provider)
+
+	var subtotal float64
+	for _, l := range lines {
+		subtotal += l.Amount
+	}
+
+	q := &models.PriceQuote{
+		ProviderID:       provider.ID,
+		SlotID:           slot.ID,
+		CatalogueID:      slot.Catalogue.ID,
+		Date:             slot.Date,
+		Start:            slot.Start,
+		End:              slot.End,
+		SlotModel:        effectiveSlotModel(slot, provider),
+		RequestedUnits:   requested,
+		StandardUnits:    standardUnits,
+		PriorityUnits:    priorityUnits,
+		Lines:            append([]models.PriceQuoteLine(nil), lines...),
+		Subtotal:         subtotal,
+		Option:           option.Option,
+		OptionMultiplier: option.Multiplier,
+		OptionAmount:     subtotal*option.Multiplier - subtotal,
+		Currency:         currency,
+		CountryCode:      strings.ToUpper(countryCode),
+		ExpiresAt:        now.Add(quoteTTL()).UTC().Truncate(time.Second),
+	}
+	quoteTaxes(q, taxRuleFor(countryCode, provider), math.Max(q.Subtotal+q.OptionAmount, 0))
+	if err := lockQuoteRates(q, chargeCurrency, displayCurrency); err != nil {
+		return nil, fmt.Errorf("cannot quote in %s: %w", chargeCurrency, err)
+	}
+	finalizeQuote(q)
+
+	if err := signQuote(q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// finalizeQuote recomputes the total from its parts, rounded to the nearest
+// whole unit of currency, and the converted totals at the locked rates.
+func finalizeQuote(q *models.PriceQuote) {
+	total := q.Subtotal + q.OptionAmount
+	for _, d := range q.Discounts {
+		total += d.Amount
+	}
+	for _, t := range q.Taxes {
+		total += t.Amount
+	}
+	q.Total = math.Round(math.Max(total, 0))
+	convertQuoteTotals(q)
+}
+
+// QuoteSlot prices `units` of a slot with a custom option, including the fee
+// and tax of countryCode. It is the single pricing path: BuildAvailableSlots
+// displays its quotes and ValidateAndBook enforces them.
+func QuoteSlot(slot models.TimeSlot, provider models.Provider, units int, optionName, countryCode, displayCurrency string, now time.Time) (*models.PriceQuote, error) {
+	if units <= 0 {
+		return nil, fmt.Errorf("units must be a positive integer")
+	}
+	option, ok := findCustomOption(slot.Catalogue.CustomOptions, optionName)
+	if !ok {
+		return nil, fmt.Errorf("invalid custom option: %q", optionName)
+	}
+	lines, standardUnits, priorityUnits, err := priceUnits(slot, provider, units, now)
+	if err != nil {
+		return nil, err
+	}
+	return buildQuote(slot, provider, lines, standardUnits, priorityUnits, units, option, countryCode, displayCurrency, now)
+}
+
+func findCustomOption(options []models.CustomOption, name string) (models.CustomOption, bool) {
+	for _, opt := range options {
+		if opt.Option == name {
+			return opt, true
+		}
+	}
+	return models.CustomOption{}, false
+}
+
+// signQuote sets QuoteID to "<payload>.<signature>", where the payload is the
+// quote itself, so verification needs no server-side state.
+func signQuote(q *models.PriceQuote) error {
+	unsigned := *q
+	unsigned.QuoteID = ""
+	payload, err := json.Marshal(unsigned)
+	if err != nil {
+		return fmt.Errorf("failed to encode quote: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, quoteSigningKey())
+	mac.Write(payload)
+
+	q.QuoteID = base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	return nil
+}
+
+// VerifyQuote checks a quote ID's signature and expiry and returns the quote it encodes.
+func VerifyQuote(quoteID string, now time.Time) (*models.PriceQuote, error) {
+	encodedPayload, encodedSig, ok := strings.Cut(quoteID, ".")
+	if !ok {
+		return nil, ErrQuoteInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrQuoteInvalid
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil {
+		return nil, ErrQuoteInvalid
+	}
+
+	mac := hmac.New(sha256.New, quoteSigningKey())
+	mac.Write(payload)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return nil, ErrQuoteInvalid
+	}
+
+	var q models.PriceQuote
+	if err := json.Unmarshal(payload, &q); err != nil {
+		return nil, ErrQuoteInvalid
+	}
+	q.QuoteID = quoteID
+	if now.After(q.ExpiresAt) {
+		return &q, ErrQuoteExpired
+	}
+	return &q, nil
+}
@@ -0,0 +1,337 @@
+package booking
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"bloomify/models"
+	"bloomify/utils"
+)
+
+var (
+	// ErrReceiptNotFound is returned when the booking does not exist.
+	ErrReceiptNotFound = errors.New("booking not found")
+	// ErrReceiptForbidden is returned when the booking belongs to another user.
+	ErrReceiptForbidden = errors.New("booking belongs to another user")
+)
+
+const receiptURLExpiry = 15 * time.Minute
+
+// GetReceipt renders the receipt of one of the user's bookings as HTML or PDF.
+// With store set, the file is uploaded through the storage service and a
+// short-lived download URL is returned alongside it.
+func (svc *DefaultBookingSessionService) GetReceipt(userID, bookingID, format string, store bool) (*models.ReceiptDocument, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	receipt, err := svc.buildReceipt(ctx, userID, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &models.ReceiptDocument{}
+	switch format {
+	case models.ReceiptFormatHTML:
+		doc.Data, err = RenderReceiptHTML(receipt)
+		doc.ContentType = "text/html; charset=utf-8"
+	case models.ReceiptFormatPDF, "":
+		format = models.ReceiptFormatPDF
+		doc.Data = RenderReceiptPDF(receipt)
+		doc.ContentType = "application/pdf"
+	default:
+		return nil, fmt.Errorf("unsupported receipt format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	doc.Filename = fmt.Sprintf("receipt-%s.%s", receipt.Number, format)
+
+	if store {
+		if doc.URL, err = svc.storeReceipt(ctx, userID, doc); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+func (svc *DefaultBookingSessionService) storeReceipt(ctx context.Context, userID string, doc *models.ReceiptDocument) (string, error) {
+	if svc.Storage == nil {
+		return "", fmt.Errorf("receipt storage is not configured")
+	}
+
+	dir, err := os.MkdirTemp("", "receipt")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, doc.Filename)
+	if err := os.WriteFile(path, doc.Data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write receipt: %w", err)
+	}
+
+	objectPath, err := svc.Storage.UploadFile(ctx, path, "receipts/"+userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to store receipt: %w", err)
+	}
+	return svc.Storage.GetSecureDownloadURL(ctx, objectPath, receiptURLExpiry)
+}
+
+// buildReceipt collects the booking, invoice, provider and customer details.
+func (svc *DefaultBookingSessionService) buildReceipt(ctx context.Context, userID, bookingID string) (*models.Receipt, error) {
+	se := svc.SchedulerEngine
+	b, err := se.Repo.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, ErrReceiptNotFound
+	}
+	if b.UserID != userID {
+		return nil, ErrReceiptForbidden
+	}
+	inv := b.Invoice
+
+	r := &models.Receipt{
+		Number:        receiptNumber(b),
+		IssuedAt:      inv.CreatedAt,
+		BookingID:     b.ID,
+		ServiceType:   b.ServiceType,
+		ServiceDate:   b.Date,
+		ServiceTime:   fmt.Sprintf("%s - %s", formatMinutes(b.Start), formatMinutes(b.End)),
+		Lines:         inv.Lines,
+		Total:         inv.Amount,
+		Currency:      strings.ToUpper(inv.Currency),
+		PaymentMethod: inv.Method,
+		PaymentStatus: b.Status,
+		PaymentID:     inv.PaymentID,
+		Refunds:       inv.Refunds,
+		Provider:      models.ReceiptParty{Name: b.MinimalProviderDTO.ProviderName},
+		Customer:      models.ReceiptParty{Name: b.UserMinimal.Username},
+	}
+	if r.IssuedAt.IsZero() {
+		r.IssuedAt = b.CreatedAt
+	}
+	if r.Total == 0 {
+		r.Total = b.TotalPrice
+	}
+	if r.Currency == "" && b.Quote != nil {
+		r.Currency = strings.ToUpper(b.Quote.Currency)
+	}
+	if addr := b.ServiceAddress; addr.FormattedAddress != "" {
+		r.ServiceAddress = strings.TrimSpace(strings.Join([]string{addr.Apartment, addr.FormattedAddress}, " "))
+	}
+
+	// Bookings made before invoices were itemized carry a single amount.
+	if len(r.Lines) == 0 {
+		r.Lines = []models.InvoiceLine{{
+			Kind:        models.InvoiceLineService,
+			Description: fmt.Sprintf("%s, %d %s (%s)", b.ServiceType, b.Units, b.UnitType, b.CustomOption.Option),
+			Amount:      r.Total,
+		}}
+	}
+
+	for _, refund := range r.Refunds {
+		if refund.Status == "succeeded" {
+			r.AmountRefunded += refund.Amount
+		}
+	}
+	r.NetPaid = r.Total - r.AmountRefunded
+
+	if provider, err := se.ProviderRepo.GetByIDWithProjection(b.ProviderID, nil); err == nil {
+		r.Provider = models.ReceiptParty{
+			Name:    provider.Profile.ProviderName,
+			Email:   provider.Profile.Email,
+			Address: provider.Profile.Address,
+			TaxPIN:  provider.AdvancedVerification.TaxPIN,
+		}
+		// The provider's number is only shown once both sides agreed to share.
+		if contactShared(b) {
+			r.Provider.Phone = provider.Profile.PhoneNumber
+		}
+	}
+	if se.UserService != nil {
+		if user, err := se.UserService.GetUserByID(userID); err == nil {
+			r.Customer.Name = user.Username
+			r.Customer.Email = user.Email
+			r.Customer.Phone = user.PhoneNumber
+		}
+	}
+	return r, nil
+}
+
+func receiptNumber(b *models.Booking) string {
+	id := b.Invoice.InvoiceID
+	if id == "" {
+		id = b.ID
+	}
+	id = strings.ToUpper(strings.ReplaceAll(id, "-", ""))
+	if len(id) > 10 {
+		id = id[:10]
+	}
+	return "BLM-" + id
+}
+
+func formatMinutes(m int) string {
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
+
+func formatAmount(currency string, amount float64) string {
+	return fmt.Sprintf("%s %.2f", currency, amount)
+}
+
+var receiptTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{
+	"amount": formatAmount,
+	"upper":  strings.ToUpper,
+	"date":   func(t time.Time) string { return t.Format("02 Jan 2006 15:04") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Receipt {{.Number}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; color: #222; margin: 0; }
+.brand { background: #2e7d32; color: #fff; padding: 24px 32px; }
+.brand h1 { margin: 0; font-size: 26px; }
+.content { padding: 24px 32px; }
+.parties { display: flex; gap: 48px; margin-bottom: 24px; }
+.parties h3, h2 { font-size: 13px; text-transform: uppercase; color: #2e7d32; margin: 0 0 6px; }
+table { width: 100%; border-collapse: collapse; margin: 12px 0 24px; }
+td, th { padding: 8px 0; border-bottom: 1px solid #e0e0e0; text-align: left; }
+td.amount, th.amount { text-align: right; font-family: Courier, monospace; }
+tr.included td { color: #777; font-style: italic; }
+tr.total td { font-weight: bold; border-bottom: none; }
+.muted { color: #777; font-size: 12px; }
+</style>
+</head>
+<body>
+<div class="brand"><h1>Bloomify</h1><div>Receipt {{.Number}} &middot; {{date .IssuedAt}}</div></div>
+<div class="content">
+<div class="parties">
+<div><h3>Provider</h3>{{.Provider.Name}}{{with .Provider.Address}}<br>{{.}}{{end}}{{with .Provider.Email}}<br>{{.}}{{end}}{{with .Provider.Phone}}<br>{{.}}{{end}}{{with .Provider.TaxPIN}}<br>Tax PIN: {{.}}{{end}}</div>
+<div><h3>Billed to</h3>{{.Customer.Name}}{{with .Customer.Email}}<br>{{.}}{{end}}{{with .Customer.Phone}}<br>{{.}}{{end}}</div>
+</div>
+<h2>Service</h2>
+<div>{{.ServiceType}} on {{.ServiceDate}}, {{.ServiceTime}}{{with .ServiceAddress}}<br>{{.}}{{end}}</div>
+<div class="muted">Booking {{.BookingID}}</div>
+<table>
+<tr><th>Description</th><th class="amount">Amount</th></tr>
+{{range .Lines}}<tr{{if .Included}} class="included"{{end}}><td>{{.Description}}</td><td class="amount">{{amount $.Currency .Amount}}</td></tr>
+{{end}}<tr class="total"><td>Total</td><td class="amount">{{amount .Currency .Total}}</td></tr>
+</table>
+<h2>Payment</h2>
+<div>{{upper .PaymentMethod}} &middot; {{.PaymentStatus}}{{with .PaymentID}} &middot; {{.}}{{end}}</div>
+{{if .Refunds}}<h2 style="margin-top:24px">Refunds</h2>
+<table>
+{{range .Refunds}}<tr><td>{{date .CreatedAt}} &middot; {{.Status}}{{with .Reason}} &middot; {{.}}{{end}}</td><td class="amount">-{{amount $.Currency .Amount}}</td></tr>
+{{end}}<tr class="total"><td>Net paid</td><td class="amount">{{amount .Currency .NetPaid}}</td></tr>
+</table>{{end}}
+<p class="muted">Thank you for booking with Bloomify.</p>
+</div>
+</body>
+</html>
+`))
+
+// RenderReceiptHTML renders a receipt as a standalone HTML page.
+func RenderReceiptHTML(r *models.Receipt) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := receiptTemplate.Execute(&buf, r); err != nil {
+		return nil, fmt.Errorf("failed to render receipt: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderReceiptPDF renders a receipt as an A4 PDF.
+func RenderReceiptPDF(r *models.Receipt) []byte {
+	const (
+		left   = 48.0
+		right  = utils.PDFPageWidth - 48.0
+		bottom = utils.PDFPageHeight - 60.0
+	)
+	doc := utils.NewPDFDocument()
+
+	// Branded header band
+	doc.SetColor(0.18, 0.49, 0.20)
+	doc.Rect(0, 0, utils.PDFPageWidth, 80)
+	doc.SetColor(1, 1, 1)
+	doc.Text(left, 42, utils.PDFFontBold, 24, "Bloomify")
+	doc.Text(left, 62, utils.PDFFontRegular, 10, fmt.Sprintf("Receipt %s  -  %s", r.Number, r.IssuedAt.Format("02 Jan 2006 15:04")))
+	doc.SetColor(0.13, 0.13, 0.13)
+
+	y := 112.0
+	block := func(x float64, title string, lines ...string) float64 {
+		doc.Text(x, y, utils.PDFFontBold, 10, strings.ToUpper(title))
+		ly := y + 16
+		for _, l := range lines {
+			if l == "" {
+				continue
+			}
+			doc.Text(x, ly, utils.PDFFontRegular, 10, l)
+			ly += 14
+		}
+		return ly
+	}
+	taxPIN := ""
+	if r.Provider.TaxPIN != "" {
+		taxPIN = "Tax PIN: " + r.Provider.TaxPIN
+	}
+	end := block(left, "Provider", r.Provider.Name, r.Provider.Address, r.Provider.Email, r.Provider.Phone, taxPIN)
+	end = max(end, block(320, "Billed to", r.Customer.Name, r.Customer.Email, r.Customer.Phone))
+
+	y = end + 16
+	y = block(left, "Service",
+		fmt.Sprintf("%s on %s, %s", r.ServiceType, r.ServiceDate, r.ServiceTime),
+		r.ServiceAddress,
+		"Booking "+r.BookingID,
+	) + 12
+
+	row := func(label, amount string, font string) {
+		if y > bottom {
+			doc.AddPage()
+			doc.SetColor(0.13, 0.13, 0.13)
+			y = 60
+		}
+		doc.Text(left, y, font, 10, label)
+		doc.MonoTextRight(right, y, 10, amount)
+		y += 18
+	}
+
+	doc.Line(left, y-12, right, y-12, 0.5)
+	row("Description", "Amount", utils.PDFFontBold)
+	for _, l := range r.Lines {
+		label := l.Description
+		if l.Included {
+			label += " (included)"
+		}
+		row(label, formatAmount(r.Currency, l.Amount), utils.PDFFontRegular)
+	}
+	doc.Line(left, y-12, right, y-12, 0.5)
+	row("Total", formatAmount(r.Currency, r.Total), utils.PDFFontBold)
+
+	y += 12
+	row("Payment", fmt.Sprintf("%s / %s", strings.ToUpper(r.PaymentMethod), r.PaymentStatus), utils.PDFFontBold)
+	if r.PaymentID != "" {
+		row("Reference", r.PaymentID, utils.PDFFontRegular)
+	}
+
+	if len(r.Refunds) > 0 {
+		y += 12
+		row("Refunds", "", utils.PDFFontBold)
+		for _, refund := range r.Refunds {
+			label := fmt.Sprintf("%s  %s", refund.CreatedAt.Format("02 Jan 2006"), refund.Status)
+			if refund.Reason != "" {
+				label += "  " + refund.Reason
+			}
+			row(label, "-"+formatAmount(r.Currency, refund.Amount), utils.PDFFontRegular)
+		}
+		row("Net paid", formatAmount(r.Currency, r.NetPaid), utils.PDFFontBold)
+	}
+
+	y += 12
+	row("Thank you for booking with Bloomify.", "", utils.PDFFontRegular)
+	return doc.Bytes()
+}
@@ -0,0 +1,164 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"bloomify/models"
+	"bloomify/services/tasks"
+	"bloomify/utils"
+
+	"github.com/hibiken/asynq"
+)
+
+// bookingReminderLeads are how long before a booking starts both the user and
+// the provider are reminded of it.
+var bookingReminderLeads = []time.Duration{24 * time.Hour, time.Hour}
+
+// safetyReminderLabel names the user's pre-arrival reminder, which fires
+// SafetySettings.SafetyReminderMinutes before the booking.
+const safetyReminderLabel = "safety"
+
+// reminderLabels lists every reminder a booking may have per target, so they
+// can be deleted without knowing the user's settings at booking time.
+func reminderLabels(target string) []string {
+	labels := make([]string, 0, len(bookingReminderLeads)+1)
+	for _, lead := range bookingReminderLeads {
+		labels = append(labels, leadLabel(lead))
+	}
+	if target == "user" {
+		labels = append(labels, safetyReminderLabel)
+	}
+	return labels
+}
+
+func leadLabel(lead time.Duration) string {
+	return strconv.Itoa(int(lead.Minutes())) + "m"
+}
+
+// bookingStartsAt returns when a booking starts, in server time like its
+// balance due date.
+func bookingStartsAt(b *models.Booking) (time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", b.Date, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return day.Add(time.Duration(b.Start) * time.Minute), nil
+}
+
+// ScheduleBookingReminders enqueues the reminders of a booking: both sides at
+// each of bookingReminderLeads, and the user again at their safety reminder
+// offset. Reminders that would already be due are skipped, and each has a task
+// ID derived from the booking so it is scheduled once. The provider no-show
+// check is scheduled alongside them.
+func (se *DefaultSchedulingEngine) ScheduleBookingReminders(ctx context.Context, b *models.Booking, provider models.Provider) {
+	if se.AsynqClient == nil || b.Status == "cancelled" {
+		return
+	}
+	startsAt, err := bookingStartsAt(b)
+	if err != nil {
+		log.Printf("[Reminders] Invalid date on booking %s: %v", b.ID, err)
+		return
+	}
+	user, err := se.UserService.GetUserByID(b.UserID)
+	if err != nil {
+		log.Printf("[Reminders] Failed to fetch user %s: %v", b.UserID, err)
+		return
+	}
+
+	now := time.Now()
+	for _, lead := range bookingReminderLeads {
+		hours := int(lead.Hours())
+		se.enqueueBookingReminder(b, "user", b.UserID, leadLabel(lead), startsAt.Add(-lead), now,
+			utils.TemplateBookingReminder, user.PreferredLanguage,
+			utils.TemplateParams{ProviderName: provider.Profile.ProviderName, When: startsAt, Count: hours})
+		se.enqueueBookingReminder(b, "provider", provider.ID, leadLabel(lead), startsAt.Add(-lead), now,
+			utils.TemplateBookingReminder, provider.PreferredLanguage,
+			utils.TemplateParams{CustomerName: user.Username, When: startsAt, Count: hours})
+	}
+
+	if minutes := user.SafetySettings.SafetyReminderMinutes; minutes > 0 {
+		lead := time.Duration(minutes) * time.Minute
+		se.enqueueBookingReminder(b, "user", b.UserID, safetyReminderLabel, startsAt.Add(-lead), now,
+			utils.TemplateSafetyReminder, user.PreferredLanguage,
+			utils.TemplateParams{ProviderName: provider.Profile.ProviderName, When: startsAt, Minutes: minutes, Mode: b.Mode})
+	}
+
+	if b.Status == "confirmed" {
+		se.scheduleNoShowCheck(b, startsAt, user)
+	}
+}
+
+func (se *DefaultSchedulingEngine) enqueueBookingReminder(
+	b *models.Booking,
+	target, recipientID, label string,
+	fireAt, now time.Time,
+	kind, locale string,
+	params utils.TemplateParams,
+) {
+	if !fireAt.After(now) {
+		return
+	}
+	title, body, err := utils.RenderNotification(kind, locale, params)
+	if err != nil {
+		log.Printf("[Reminders] Failed to render %s for booking %s: %v", kind, b.ID, err)
+		return
+	}
+	payload := models.ReminderPayload{
+		ID:         recipientID,
+		ReminderID: tasks.BookingReminderTaskID(b.ID, target, label),
+		Title:      title,
+		Body:       body,
+		FireDate:   fireAt.Format(time.RFC3339),
+		Target:     target,
+		Type:       kind,
+		BookingID:  b.ID,
+	}
+	task, opts, err := tasks.NewBookingReminderTask(payload, fireAt, label)
+	if err != nil {
+		log.Printf("[Reminders] Failed to create reminder for booking %s: %v", b.ID, err)
+		return
+	}
+	if _, err := se.AsynqClient.Enqueue(task, opts...); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		log.Printf("[Reminders] Failed to schedule %s reminder of booking %s: %v", label, b.ID, err)
+	}
+}
+
+// CancelBookingReminders deletes the reminders and the no-show check of a
+// booking that have not fired yet.
+func (se *DefaultSchedulingEngine) CancelBookingReminders(bookingID string) {
+	se.cancelReminders(bookingID, true)
+}
+
+// cancelReminders deletes the pending reminders of a booking, and its no-show
+// check if noShowCheck is set. The check cannot delete itself while it runs.
+func (se *DefaultSchedulingEngine) cancelReminders(bookingID string, noShowCheck bool) {
+	if se.ReminderInspector == nil {
+		return
+	}
+	var ids []string
+	if noShowCheck {
+		ids = append(ids, tasks.NoShowCheckTaskID(bookingID))
+	}
+	for _, target := range []string{"user", "provider"} {
+		for _, label := range reminderLabels(target) {
+			ids = append(ids, tasks.BookingReminderTaskID(bookingID, target, label))
+		}
+	}
+	for _, id := range ids {
+		err := se.ReminderInspector.DeleteTask("default", id)
+		if err != nil && !errors.Is(err, asynq.ErrTaskNotFound) && !errors.Is(err, asynq.ErrQueueNotFound) {
+			log.Printf("[Reminders] Failed to cancel task %s: %v", id, err)
+		}
+	}
+}
+
+// RescheduleBookingReminders moves a booking's reminders to its current date
+// and start time.
+func (se *DefaultSchedulingEngine) RescheduleBookingReminders(ctx context.Context, b *models.Booking, provider models.Provider) {
+	se.CancelBookingReminders(b.ID)
+	se.ScheduleBookingReminders(ctx, b, provider)
+}
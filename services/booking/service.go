@@ -3,17 +3,13 @@ package booking
 import (
 	"bloomify/models"
 	"bloomify/utils"
+	"context"
 	"fmt"
 	"math"
 	"strings"
 )
 
-type PriceRange struct {
-	Min       float64 `json:"min"`
-	Max       float64 `json:"max"`
-	Suggested float64 `json:"suggested,omitempty"`
-	Currency  string  `json:"currency"`
-}
+type PriceRange = models.ServicePriceRange
 
 type ServiceDetails struct {
 	Metadata      models.ServiceMetadata `json:"metadata"`
@@ -31,6 +27,9 @@ var globalRegions = []string{
 	"South Asia",
 	"North America",
 }
+
+// servicesMap is the seed for the persisted service taxonomy and the fallback
+// when the taxonomy store is unavailable.
 var servicesMap = map[string]ServiceDetails{
 	"Cleaning": {
 		Metadata: models.ServiceMetadata{
@@ -258,16 +257,18 @@ func GetServicesMap() map[string]ServiceDetails {
 }
 
 // GetAvailableServices returns all services metadata available for the specified region.
-// If region == "", it returns all services.
+// If region == "", it returns all services. Region overrides can hide a service or
+// narrow its modes for the matching region.
 func (svc *DefaultBookingSessionService) GetAvailableServices(region string) ([]models.ServiceMetadata, error) {
-	services := make([]models.ServiceMetadata, 0, len(servicesMap))
+	taxonomy := svc.loadServiceTaxonomy()
+	services := make([]models.ServiceMetadata, 0, len(taxonomy))
 
-	for _, details := range servicesMap {
+	for _, def := range taxonomy {
 		// If a region filter is provided, check availability
 		if region != "" && region != "global" {
 			found := false
 			regionLower := strings.ToLower(region)
-			for _, avail := range details.Availability {
+			for _, avail := range def.Availability {
 				if strings.Contains(strings.ToLower(avail), regionLower) {
 					found = true
 					break
@@ -278,6 +279,11 @@ func (svc *DefaultBookingSessionService) GetAvailableServices(region string) ([]
 			}
 		}
 
+		details, ok := applyRegionOverride(def, region)
+		if !ok {
+			continue
+		}
+
 		// Append service metadata (no filtering or region matched)
 		svcMeta := details.Metadata
 		services = append(services, models.ServiceMetadata{
@@ -294,11 +300,25 @@ func (svc *DefaultBookingSessionService) GetAvailableServices(region string) ([]
 }
 
 func (svc *DefaultBookingSessionService) GetServiceByID(serviceID string, countryCode string, currency string) (*ServiceDetails, error) {
-	origDetails, exists := servicesMap[serviceID]
-	if !exists {
+	var (
+		def   models.ServiceDefinition
+		found bool
+	)
+	for _, d := range svc.loadServiceTaxonomy() {
+		if d.ID == serviceID {
+			def, found = d, true
+			break
+		}
+	}
+	if !found {
 		return nil, fmt.Errorf("service with ID %s not found", serviceID)
 	}
 
+	origDetails, ok := applyRegionOverride(def, countryCode)
+	if !ok {
+		return nil, fmt.Errorf("service %s is not available in %s", serviceID, countryCode)
+	}
+
 	details := origDetails
 	if origDetails.CustomOptions != nil {
 		details.CustomOptions = make([]models.CustomOption, len(origDetails.CustomOptions))
@@ -329,29 +349,23 @@ func (svc *DefaultBookingSessionService) GetServiceByID(serviceID string, countr
 		biasedMax := details.PriceRange.Max * geoBias
 		biasedSuggested := biasedMin + bias*(biasedMax-biasedMin)
 
-		convertedMin, err := utils.ConvertCurrency(biasedMin, "USD", currency)
-		if err != nil {
-			return nil, fmt.Errorf("currency conversion failed: %v", err)
-		}
-		convertedMax, err := utils.ConvertCurrency(biasedMax, "USD", currency)
-		if err != nil {
-			return nil, fmt.Errorf("currency conversion failed: %v", err)
-		}
-		convertedSuggested, err := utils.ConvertCurrency(biasedSuggested, "USD", currency)
+		// One snapshot for the whole range so min, max and suggested share a rate
+		snap, err := utils.GetExchangeRates().Snapshot(context.Background(), "USD", currency)
 		if err != nil {
 			return nil, fmt.Errorf("currency conversion failed: %v", err)
 		}
 
 		// Round all prices to nearest whole number
-		roundedMin := math.Round(convertedMin)
-		roundedMax := math.Round(convertedMax)
-		roundedSuggested := math.Round(convertedSuggested)
+		roundedMin := math.Round(biasedMin * snap.Rate)
+		roundedMax := math.Round(biasedMax * snap.Rate)
+		roundedSuggested := math.Round(biasedSuggested * snap.Rate)
 
 		details.PriceRange = &PriceRange{
-			Min:       roundedMin,
-			Max:       roundedMax,
-			Suggested: roundedSuggested,
-			Currency:  currency,
+			Min:          roundedMin,
+			Max:          roundedMax,
+			Suggested:    roundedSuggested,
+			Currency:     currency,
+			RateSnapshot: &snap,
 		}
 	}
 
@@ -13,8 +13,10 @@ import (
 	"go.uber.org/zap"
 )
 
+// EnrichTimeslots merges the given catalogue entry into each slot. Slots tied to a
+// different catalogue entry are dropped; untagged slots serve every entry.
 func EnrichTimeslots(rawSlots []models.TimeSlot, catalogue models.ServiceCatalogue, logger *zap.Logger) []models.TimeSlot {
-	enriched := make([]models.TimeSlot, len(rawSlots))
+	enriched := make([]models.TimeSlot, 0, len(rawSlots))
 
 	for i := range rawSlots {
 		ts := &rawSlots[i]
@@ -23,8 +25,12 @@ func EnrichTimeslots(rawSlots []models.TimeSlot, catalogue models.ServiceCatalog
 			logger.Warn("skipping empty ID slot", zap.Int("index", i))
 			continue
 		}
+		if ts.CatalogueID != "" && catalogue.ID != "" && ts.CatalogueID != catalogue.ID {
+			continue
+		}
 
 		// Merge provider catalogue into timeslot
+		ts.Catalogue.ID = catalogue.ID
 		ts.Catalogue.Service.ID = catalogue.Service.ID
 		ts.Catalogue.Mode = catalogue.Mode
 		ts.Catalogue.CustomOptions = append(
@@ -33,7 +39,7 @@ func EnrichTimeslots(rawSlots []models.TimeSlot, catalogue models.ServiceCatalog
 		)
 		ts.Catalogue.Currency = catalogue.Currency
 
-		enriched[i] = *ts
+		enriched = append(enriched, *ts)
 	}
 	return enriched
 }
@@ -68,7 +74,7 @@ func getRemainingUnits(ts models.TimeSlot, provider models.Provider) (int, bool)
 			}
 			normal := ts.Capacity - ts.Urgency.ReservedPriority
 			return normal - ts.BookedUnitsStandard, true
-		case "earlybird", "flatrate":
+		case "earlybird", "flatrate", "dynamic":
 			return ts.Capacity - ts.BookedUnitsStandard, true
 		}
 	}
@@ -79,7 +85,7 @@ func getRemainingUnits(ts models.TimeSlot, provider models.Provider) (int, bool)
 func BuildAvailableSlots(
 	enrichedSlots []models.TimeSlot,
 	weekStart, weekEnd, now time.Time,
-	currency string, units int,
+	currency, displayCurrency, countryCode string, units int,
 	provider models.Provider,
 ) ([]models.AvailableSlot, error) {
 	var availableSlots []models.AvailableSlot
@@ -106,146 +112,58 @@ func BuildAvailableSlots(
 				}
 
 				remaining, ok := getRemainingUnits(ts, provider)
-				if !ok || remaining <= 0 {
+				remaining = max(remaining, 0)
+				priorityLeft := priorityRemaining(ts, provider)
+				if !ok || remaining+priorityLeft <= 0 {
+					return
+				}
+
+				lines, standardUnits, priorityUnits, err := priceUnits(ts, provider, units, now)
+				if err != nil {
+					logger.Warn("failed to price timeslot", zap.String("slotID", ts.ID), zap.Error(err))
 					return
 				}
 
 				slot := models.AvailableSlot{
-					ID:                       ts.ID,
-					Start:                    ts.Start,
-					End:                      ts.End,
-					UnitType:                 ts.UnitType,
-					Date:                     dayStr,
-					Catalogue:                ts.Catalogue,
-					OptionPricing:            map[string]float64{},
-					CapacityMode:             ts.CapacityMode,
-					RegularCapacityRemaining: remaining,
+					ID:                        ts.ID,
+					Start:                     ts.Start,
+					End:                       ts.End,
+					UnitType:                  ts.UnitType,
+					Date:                      dayStr,
+					Catalogue:                 ts.Catalogue,
+					OptionPricing:             map[string]float64{},
+					Quotes:                    map[string]models.PriceQuote{},
+					CapacityMode:              ts.CapacityMode,
+					RegularCapacityRemaining:  remaining,
+					PriorityCapacityRemaining: priorityLeft,
 				}
 
 				if slot.Catalogue.Currency == "" {
 					slot.Catalogue.Currency = currency
 				}
 
-				actualUnits := min(units, remaining)
-
-				switch ts.SlotModel {
-
-				case "urgency":
-					if ts.Urgency != nil {
-						priorityRemaining := ts.Urgency.ReservedPriority - ts.BookedUnitsPriority
-						priorityPrice := ts.BasePrice * (1 + ts.Urgency.PrioritySurchargeRate)
-
-						switch {
-						case remaining >= units:
-							// All units can be fulfilled with normal stock
-							slot.RegularPricePerUnit = math.Round(ts.BasePrice)
-							for _, opt := range ts.Catalogue.CustomOptions {
-								slot.OptionPricing[opt.Option] = math.Round(ts.BasePrice * opt.Multiplier * float64(units))
-							}
-
-						case priorityRemaining >= units:
-							// All units available from priority pool
-							slot.PriorityPricePerUnit = math.Round(priorityPrice)
-							for _, opt := range ts.Catalogue.CustomOptions {
-								slot.OptionPricing[opt.Option] = math.Round(priorityPrice * opt.Multiplier * float64(units))
-							}
-							slot.Message = fmt.Sprintf("You're booking from urgent-use stock — a %.0f%% surcharge applies.", ts.Urgency.PrioritySurchargeRate*100)
-
-						case remaining > 0 && priorityRemaining > 0 && (remaining+priorityRemaining) >= units:
-							// Mix of regular and priority
-							regularCount := remaining
-							priorityCount := units - remaining
-							regularTotal := float64(regularCount) * ts.BasePrice
-							priorityTotal := float64(priorityCount) * priorityPrice
-							slot.RegularPricePerUnit = math.Round((regularTotal + priorityTotal) / float64(units))
-							for _, opt := range ts.Catalogue.CustomOptions {
-								total := regularTotal*opt.Multiplier + priorityTotal*opt.Multiplier
-								slot.OptionPricing[opt.Option] = math.Round(total)
-							}
-							slot.Message = fmt.Sprintf("Mix of %d normal and %d urgent-use %s — a surcharge applies for the urgent part.", regularCount, priorityCount, slot.UnitType)
-
-						case remaining > 0:
-							// Not enough, only partial regular available
-							slot.RegularPricePerUnit = math.Round(ts.BasePrice)
-							for _, opt := range ts.Catalogue.CustomOptions {
-								slot.OptionPricing[opt.Option] = math.Round(ts.BasePrice * opt.Multiplier * float64(remaining))
-							}
-							slot.Message = fmt.Sprintf("Only %d of %d %s available at normal price — rest are unavailable.", remaining, units, slot.UnitType)
-
-						case priorityRemaining > 0:
-							// Not enough, only partial priority available
-							slot.PriorityPricePerUnit = math.Round(priorityPrice)
-							for _, opt := range ts.Catalogue.CustomOptions {
-								slot.OptionPricing[opt.Option] = math.Round(priorityPrice * opt.Multiplier * float64(priorityRemaining))
-							}
-							slot.Message = fmt.Sprintf("Only %d of %d %s available from urgent-use pool — surcharge applies.", priorityRemaining, units, slot.UnitType)
-
-						default:
-							logger.Warn("urgency capacity full", zap.String("slotID", ts.ID))
-							return
-						}
-					}
-
-				case "earlybird":
-					if ts.EarlyBird != nil {
-						earlyThreshold := int(math.Ceil(float64(ts.Capacity) * 0.25))
-						standardThreshold := int(math.Ceil(float64(ts.Capacity) * 0.75))
-
-						earlyCount := 0
-						standardCount := 0
-						lateCount := 0
-						total := 0.0
-
-						for i := 1; i <= actualUnits; i++ {
-							unitIndex := ts.BookedUnitsStandard + i
-							unitPrice := GetEarlyBirdNextUnitPrice(ts.BasePrice, *ts.EarlyBird, ts.Capacity, ts.BookedUnitsStandard+(i-1))
-							total += unitPrice
-
-							if unitIndex <= earlyThreshold {
-								earlyCount++
-							} else if unitIndex <= standardThreshold {
-								standardCount++
-							} else {
-								lateCount++
-							}
-						}
-
-						slot.RegularPricePerUnit = math.Round(total / float64(actualUnits))
-						for _, opt := range ts.Catalogue.CustomOptions {
-							slot.OptionPricing[opt.Option] = math.Round(total * opt.Multiplier)
-						}
-
-						var parts []string
-						if earlyCount == actualUnits {
-							slot.Message = fmt.Sprintf("All %d %s at early-bird discount — save up to %.0f%%", actualUnits, slot.UnitType, ts.EarlyBird.EarlyBirdDiscountRate*100)
-						} else {
-							if earlyCount > 0 {
-								parts = append(parts, fmt.Sprintf("%d at early-bird rate (%.0f%% off)", earlyCount, ts.EarlyBird.EarlyBirdDiscountRate*100))
-							}
-							if standardCount > 0 {
-								parts = append(parts, fmt.Sprintf("%d at standard rate", standardCount))
-							}
-							if lateCount > 0 {
-								parts = append(parts, fmt.Sprintf("%d with late fee (+%.0f%%)", lateCount, ts.EarlyBird.LateSurchargeRate*100))
-							}
-							slot.Message = fmt.Sprintf("You're booking %d %s: %s", actualUnits, slot.UnitType, strings.Join(parts, ", "))
-						}
-
-						if remaining < units {
-							slot.Message += fmt.Sprintf(" | Only %d of %d %s available", remaining, units, slot.UnitType)
-						}
+				for _, opt := range ts.Catalogue.CustomOptions {
+					q, err := buildQuote(ts, provider, lines, standardUnits, priorityUnits, units, opt, countryCode, displayCurrency, now)
+					if err != nil {
+						logger.Warn("failed to build quote", zap.String("slotID", ts.ID), zap.String("option", opt.Option), zap.Error(err))
+						continue
 					}
+					slot.OptionPricing[opt.Option] = q.Total
+					slot.Quotes[opt.Option] = *q
+				}
 
-				case "flatrate":
-					price := math.Round(ts.BasePrice)
-					slot.RegularPricePerUnit = price
-					for _, opt := range ts.Catalogue.CustomOptions {
-						slot.OptionPricing[opt.Option] = math.Round(price * opt.Multiplier * float64(actualUnits))
-					}
-					if remaining < units {
-						slot.Message = fmt.Sprintf("Only %d of %d %s available", remaining, units, slot.UnitType)
+				var standardAmount float64
+				for _, l := range lines {
+					if l.Tier == models.TierPriority {
+						slot.PriorityPricePerUnit = math.Round(l.UnitPrice)
+						continue
 					}
+					standardAmount += l.Amount
 				}
+				if standardUnits > 0 {
+					slot.RegularPricePerUnit = math.Round(standardAmount / float64(standardUnits))
+				}
+				slot.Message = quoteMessage(ts, effectiveSlotModel(ts, provider), lines, standardUnits, priorityUnits, units)
 
 				if ts.CapacityMode == models.CapacityByUnit &&
 					ts.Capacity > 0 &&
@@ -269,6 +187,86 @@ func BuildAvailableSlots(
 	return availableSlots, nil
 }
 
+// quoteMessage explains to the user how the quoted units were priced.
+func quoteMessage(ts models.TimeSlot, model string, lines []models.PriceQuoteLine, standardUnits, priorityUnits, units int) string {
+	quoted := standardUnits + priorityUnits
+
+	switch model {
+	case "urgency":
+		surcharge := ts.Urgency.PrioritySurchargeRate * 100
+		switch {
+		case priorityUnits == 0 && quoted == units:
+			return ""
+		case standardUnits == 0 && quoted == units:
+			return fmt.Sprintf("You're booking from urgent-use stock — a %.0f%% surcharge applies.", surcharge)
+		case quoted == units:
+			return fmt.Sprintf("Mix of %d normal and %d urgent-use %s — a surcharge applies for the urgent part.", standardUnits, priorityUnits, ts.UnitType)
+		case priorityUnits == 0:
+			return fmt.Sprintf("Only %d of %d %s available at normal price — rest are unavailable.", standardUnits, units, ts.UnitType)
+		case standardUnits == 0:
+			return fmt.Sprintf("Only %d of %d %s available from urgent-use pool — surcharge applies.", priorityUnits, units, ts.UnitType)
+		default:
+			return fmt.Sprintf("Only %d of %d %s available (%d normal, %d urgent-use) — a surcharge applies for the urgent part.", quoted, units, ts.UnitType, standardUnits, priorityUnits)
+		}
+
+	case "earlybird":
+		var (
+			msg          string
+			parts        []string
+			earlyUnits   int
+			bestDiscount float64
+		)
+		for _, l := range lines {
+			switch l.Tier {
+			case models.TierEarlyBird:
+				earlyUnits += l.Units
+				bestDiscount = max(bestDiscount, -l.Rate)
+				parts = append(parts, fmt.Sprintf("%d at early-bird rate (%.0f%% off)", l.Units, -l.Rate*100))
+			case models.TierStandard:
+				parts = append(parts, fmt.Sprintf("%d at standard rate", l.Units))
+			case models.TierLate:
+				parts = append(parts, fmt.Sprintf("%d with late fee (+%.0f%%)", l.Units, l.Rate*100))
+			}
+		}
+		if earlyUnits == quoted {
+			msg = fmt.Sprintf("All %d %s at early-bird discount — save up to %.0f%%", quoted, ts.UnitType, bestDiscount*100)
+		} else {
+			msg = fmt.Sprintf("You're booking %d %s: %s", quoted, ts.UnitType, strings.Join(parts, ", "))
+		}
+		if quoted < units {
+			msg += fmt.Sprintf(" | Only %d of %d %s available", quoted, units, ts.UnitType)
+		}
+		return msg
+
+	case "dynamic":
+		var multiplier float64
+		for _, l := range lines {
+			if l.Tier == models.TierDynamic {
+				multiplier = 1 + l.Rate
+			}
+		}
+		var msg string
+		switch {
+		case multiplier > 1:
+			msg = fmt.Sprintf("High demand: prices are %.2fx the usual rate", multiplier)
+		case multiplier > 0 && multiplier < 1:
+			msg = fmt.Sprintf("Low demand: prices are %.2fx the usual rate", multiplier)
+		default:
+			msg = "Prices are at the usual rate (1.00x)"
+		}
+		if quoted < units {
+			msg += fmt.Sprintf(" | Only %d of %d %s available", quoted, units, ts.UnitType)
+		}
+		return msg
+
+	default:
+		if quoted < units {
+			return fmt.Sprintf("Only %d of %d %s available", quoted, units, ts.UnitType)
+		}
+		return ""
+	}
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
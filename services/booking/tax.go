@@ -0,0 +1,157 @@
+package booking
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"bloomify/config"
+	"bloomify/models"
+)
+
+// Quote adjustment codes for charges added at confirmation.
+const (
+	adjustmentPlatformFee = "platform_fee"
+	adjustmentTax         = "tax"
+)
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// taxRule is the tax and platform fee charged on a booking.
+type taxRule struct {
+	name      string
+	rate      float64
+	feeRate   float64
+	inclusive bool // the provider's prices already include the tax
+}
+
+func taxRuleFor(countryCode string, provider models.Provider) taxRule {
+	name, rate, feeRate := config.TaxRuleFor(strings.ToUpper(countryCode))
+	if name == "" {
+		name = "Tax"
+	}
+	return taxRule{name: name, rate: rate, feeRate: feeRate, inclusive: provider.PaymentDetails.TaxInclusive}
+}
+
+// quoteTaxes sets the platform fee and tax of a quote, both charged on net, the
+// price after discounts. For tax-inclusive providers only the fee is taxed on
+// top.
+func quoteTaxes(q *models.PriceQuote, rule taxRule, net float64) {
+	q.Taxes = feeAndTax(rule, net)
+}
+
+// feeAndTax returns the platform fee and the tax charged on top of net.
+func feeAndTax(rule taxRule, net float64) []models.PriceAdjustment {
+	var taxes []models.PriceAdjustment
+	fee := roundCents(net * rule.feeRate)
+	if fee > 0 {
+		taxes = append(taxes, models.PriceAdjustment{Code: adjustmentPlatformFee, Label: "Platform service fee", Amount: fee})
+	}
+
+	if rule.rate > 0 {
+		taxable := net + fee
+		if rule.inclusive {
+			taxable = fee
+		}
+		if tax := roundCents(taxable * rule.rate); tax > 0 {
+			taxes = append(taxes, models.PriceAdjustment{Code: adjustmentTax, Label: rule.name, Amount: tax})
+		}
+	}
+	return taxes
+}
+
+// includedTax is the tax inside net for tax-inclusive providers.
+func includedTax(rule taxRule, net float64) float64 {
+	if !rule.inclusive || rule.rate <= 0 {
+		return 0
+	}
+	return roundCents(net - net/(1+rule.rate))
+}
+
+// applyTaxes returns the itemized invoice lines of a booking's quote. Quotes
+// state their fee and tax when issued, so their ChargeTotal is what the client
+// authorizes; only discounts redeemed at checkout change the base, and then the
+// fee and tax are charged again on the discounted price and the quote re-signed.
+// The booking's TotalPrice is the discounted service price. Lines are in the
+// quote's charge currency.
+func applyTaxes(b *models.Booking, provider models.Provider) ([]models.InvoiceLine, error) {
+	q := b.Quote
+	if q == nil {
+		return nil, fmt.Errorf("booking has not been priced")
+	}
+	rule := taxRuleFor(b.CountryCode, provider)
+
+	net := discountBase(b)
+	if len(q.Discounts) > 0 {
+		quoteTaxes(q, rule, net)
+		finalizeQuote(q)
+		if err := signQuote(q); err != nil {
+			return nil, err
+		}
+	}
+	b.TotalPrice = math.Round(net)
+
+	lines := []models.InvoiceLine{{
+		Kind:        models.InvoiceLineService,
+		Description: fmt.Sprintf("%s, %d %s (%s)", b.ServiceType, b.Units, b.UnitType, q.Option),
+		Amount:      roundCents(q.Subtotal + q.OptionAmount),
+	}}
+	for _, d := range q.Discounts {
+		lines = append(lines, models.InvoiceLine{Kind: models.InvoiceLineDiscount, Description: d.Label, Amount: roundCents(d.Amount)})
+	}
+	lines = append(lines, taxLines(rule, q.Taxes, net)...)
+
+	// The total is rounded to whole units; record the difference so lines reconcile.
+	var sum float64
+	for _, l := range lines {
+		if !l.Included {
+			sum += l.Amount
+		}
+	}
+	if diff := roundCents(q.Total - sum); diff != 0 {
+		lines = append(lines, models.InvoiceLine{Kind: models.InvoiceLineRounding, Description: "Rounding", Amount: diff})
+	}
+	return chargeLines(q, lines), nil
+}
+
+// taxLines itemizes the fee and tax charged on top of net, followed by the tax
+// already inside net for tax-inclusive providers.
+func taxLines(rule taxRule, taxes []models.PriceAdjustment, net float64) []models.InvoiceLine {
+	var lines []models.InvoiceLine
+	for _, t := range taxes {
+		line := models.InvoiceLine{Kind: models.InvoiceLineTax, Description: t.Label, Rate: rule.rate, Amount: t.Amount}
+		if t.Code == adjustmentPlatformFee {
+			line.Kind, line.Rate = models.InvoiceLinePlatformFee, rule.feeRate
+		}
+		lines = append(lines, line)
+	}
+	if included := includedTax(rule, net); included > 0 {
+		lines = append(lines, models.InvoiceLine{
+			Kind:        models.InvoiceLineTax,
+			Description: rule.name + " included in price",
+			Rate:        rule.rate,
+			Amount:      included,
+			Included:    true,
+		})
+	}
+	return lines
+}
+
+// adjustmentTaxes charges the platform fee and tax of the booking's country on
+// an adjustment, as applyTaxes does for the booking, and sets what the user
+// pays. Tips go to the provider untaxed.
+func adjustmentTaxes(adj *models.BookingAdjustment, rule taxRule) {
+	adj.Taxes = nil
+	if adj.Kind != models.AdjustmentTip {
+		adj.Taxes = taxLines(rule, feeAndTax(rule, adj.Amount), adj.Amount)
+	}
+	total := adj.Amount
+	for _, l := range adj.Taxes {
+		if !l.Included {
+			total += l.Amount
+		}
+	}
+	adj.Total = roundCents(total)
+}
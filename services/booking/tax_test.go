@@ -0,0 +1,215 @@
+package booking
+
+import (
+	"testing"
+
+	"bloomify/models"
+)
+
+// quotedBooking prices a booking the way buildQuote does, then redeems the
+// given discount the way reserveDiscounts does.
+func quotedBooking(country string, provider models.Provider, subtotal, option, discount float64) *models.Booking {
+	q := &models.PriceQuote{
+		Subtotal:       subtotal,
+		OptionAmount:   option,
+		Currency:       "KES",
+		ChargeCurrency: "KES",
+		CountryCode:    country,
+	}
+	quoteTaxes(q, taxRuleFor(country, provider), subtotal+option)
+	finalizeQuote(q)
+	if discount != 0 {
+		q.Discounts = append(q.Discounts, models.PriceAdjustment{Code: "promo", Label: "Promo", Amount: discount})
+		finalizeQuote(q)
+	}
+	return &models.Booking{CountryCode: country, Quote: q}
+}
+
+func TestApplyTaxes(t *testing.T) {
+	exclusive := models.Provider{}
+	inclusive := models.Provider{PaymentDetails: models.PaymentDetails{TaxInclusive: true}}
+
+	type line struct {
+		kind     string
+		amount   float64
+		included bool
+	}
+	tests := []struct {
+		name      string
+		country   string
+		provider  models.Provider
+		subtotal  float64
+		option    float64
+		discount  float64
+		wantLines []line
+		wantTotal float64
+		wantPrice float64
+	}{
+		{
+			name: "tax on top of price and fee", country: "KE", provider: exclusive, subtotal: 1000,
+			wantLines: []line{
+				{models.InvoiceLineService, 1000, false},
+				{models.InvoiceLinePlatformFee, 50, false},
+				{models.InvoiceLineTax, 168, false},
+			},
+			wantTotal: 1218, wantPrice: 1000,
+		},
+		{
+			name: "option is part of the taxed price", country: "ke", provider: exclusive, subtotal: 1000, option: 500,
+			wantLines: []line{
+				{models.InvoiceLineService, 1500, false},
+				{models.InvoiceLinePlatformFee, 75, false},
+				{models.InvoiceLineTax, 252, false},
+			},
+			wantTotal: 1827, wantPrice: 1500,
+		},
+		{
+			name: "tax-inclusive price only taxes the fee", country: "KE", provider: inclusive, subtotal: 1000,
+			wantLines: []line{
+				{models.InvoiceLineService, 1000, false},
+				{models.InvoiceLinePlatformFee, 50, false},
+				{models.InvoiceLineTax, 8, false},
+				{models.InvoiceLineTax, 137.93, true},
+			},
+			wantTotal: 1058, wantPrice: 1000,
+		},
+		{
+			name: "country without a rule pays the default fee and a rounding line", country: "FR", provider: exclusive, subtotal: 1234.56,
+			wantLines: []line{
+				{models.InvoiceLineService, 1234.56, false},
+				{models.InvoiceLinePlatformFee, 61.73, false},
+				{models.InvoiceLineRounding, -0.29, false},
+			},
+			wantTotal: 1296, wantPrice: 1235,
+		},
+		{
+			name: "discount lowers the fee and tax", country: "KE", provider: exclusive, subtotal: 1000, discount: -200,
+			wantLines: []line{
+				{models.InvoiceLineService, 1000, false},
+				{models.InvoiceLineDiscount, -200, false},
+				{models.InvoiceLinePlatformFee, 40, false},
+				{models.InvoiceLineTax, 134.4, false},
+				{models.InvoiceLineRounding, -0.4, false},
+			},
+			wantTotal: 974, wantPrice: 800,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := quotedBooking(tt.country, tt.provider, tt.subtotal, tt.option, tt.discount)
+			lines, err := applyTaxes(b, tt.provider)
+			if err != nil {
+				t.Fatalf("applyTaxes: %v", err)
+			}
+
+			if len(lines) != len(tt.wantLines) {
+				t.Fatalf("got %d lines %+v, want %d", len(lines), lines, len(tt.wantLines))
+			}
+			for i, want := range tt.wantLines {
+				got := line{lines[i].Kind, lines[i].Amount, lines[i].Included}
+				if got != want {
+					t.Errorf("line %d = %+v, want %+v", i, got, want)
+				}
+			}
+			if b.Quote.Total != tt.wantTotal || b.Quote.ChargeTotal != tt.wantTotal {
+				t.Errorf("total = %v, charge total = %v, want %v", b.Quote.Total, b.Quote.ChargeTotal, tt.wantTotal)
+			}
+			if b.TotalPrice != tt.wantPrice {
+				t.Errorf("TotalPrice = %v, want %v", b.TotalPrice, tt.wantPrice)
+			}
+			if tt.discount != 0 && b.Quote.QuoteID == "" {
+				t.Errorf("discounted quote was not re-signed")
+			}
+		})
+	}
+}
+
+func TestApplyTaxesKeepsIssuedCharge(t *testing.T) {
+	// Without a discount the quote the client authorized is charged as issued.
+	b := quotedBooking("KE", models.Provider{}, 1000, 0, 0)
+	issued := b.Quote.ChargeTotal
+	if _, err := applyTaxes(b, models.Provider{}); err != nil {
+		t.Fatalf("applyTaxes: %v", err)
+	}
+	if b.Quote.ChargeTotal != issued {
+		t.Errorf("ChargeTotal = %v, want the issued %v", b.Quote.ChargeTotal, issued)
+	}
+	if _, err := applyTaxes(&models.Booking{}, models.Provider{}); err == nil {
+		t.Errorf("applyTaxes on an unpriced booking succeeded")
+	}
+}
+
+func TestFinalizeQuote(t *testing.T) {
+	tests := []struct {
+		name  string
+		quote models.PriceQuote
+		want  float64
+	}{
+		{"subtotal only", models.PriceQuote{Subtotal: 1000}, 1000},
+		{"option adds to subtotal", models.PriceQuote{Subtotal: 1000, OptionAmount: 250}, 1250},
+		{"discounts and taxes", models.PriceQuote{
+			Subtotal:  1000,
+			Discounts: []models.PriceAdjustment{{Amount: -100}},
+			Taxes:     []models.PriceAdjustment{{Amount: 45}, {Amount: 151.2}},
+		}, 1096},
+		{"rounded to whole units", models.PriceQuote{Subtotal: 99.5}, 100},
+		{"never below zero", models.PriceQuote{Subtotal: 100, Discounts: []models.PriceAdjustment{{Amount: -150}}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.quote
+			finalizeQuote(&q)
+			if q.Total != tt.want || q.ChargeTotal != tt.want {
+				t.Errorf("Total = %v, ChargeTotal = %v, want %v", q.Total, q.ChargeTotal, tt.want)
+			}
+		})
+	}
+
+	q := models.PriceQuote{Subtotal: 1000, ChargeRate: &models.RateSnapshot{Rate: 0.0077}}
+	finalizeQuote(&q)
+	if q.Total != 1000 || q.ChargeTotal != 8 {
+		t.Errorf("converted quote Total = %v, ChargeTotal = %v, want 1000 and 8", q.Total, q.ChargeTotal)
+	}
+}
+
+func TestAdjustmentTaxes(t *testing.T) {
+	tests := []struct {
+		name      string
+		kind      string
+		country   string
+		provider  models.Provider
+		amount    float64
+		wantKinds []string
+		wantTotal float64
+	}{
+		{"charge pays fee and tax", models.AdjustmentCharge, "KE", models.Provider{}, 500,
+			[]string{models.InvoiceLinePlatformFee, models.InvoiceLineTax}, 609},
+		{"tax-inclusive provider only taxes the fee", models.AdjustmentExtraUnits, "KE",
+			models.Provider{PaymentDetails: models.PaymentDetails{TaxInclusive: true}}, 500,
+			[]string{models.InvoiceLinePlatformFee, models.InvoiceLineTax, models.InvoiceLineTax}, 529},
+		{"country without a rule pays the default fee", models.AdjustmentCharge, "FR", models.Provider{}, 500,
+			[]string{models.InvoiceLinePlatformFee}, 525},
+		{"tips are not taxed", models.AdjustmentTip, "KE", models.Provider{}, 200, nil, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adj := models.BookingAdjustment{Kind: tt.kind, Amount: tt.amount}
+			adjustmentTaxes(&adj, taxRuleFor(tt.country, tt.provider))
+
+			if len(adj.Taxes) != len(tt.wantKinds) {
+				t.Fatalf("got %d tax lines %+v, want %d", len(adj.Taxes), adj.Taxes, len(tt.wantKinds))
+			}
+			for i, kind := range tt.wantKinds {
+				if adj.Taxes[i].Kind != kind {
+					t.Errorf("line %d kind = %q, want %q", i, adj.Taxes[i].Kind, kind)
+				}
+			}
+			if adj.Total != tt.wantTotal {
+				t.Errorf("Total = %v, want %v", adj.Total, tt.wantTotal)
+			}
+		})
+	}
+}
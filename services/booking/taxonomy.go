@@ -0,0 +1,138 @@
+package booking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	serviceRepo "bloomify/database/repository/service"
+	"bloomify/models"
+	"bloomify/utils"
+
+	"go.uber.org/zap"
+)
+
+const serviceTaxonomyCacheTTL = 10 * time.Minute
+
+// SeedServiceDefinitions converts the compiled-in services map into taxonomy
+// definitions, sorted by ID.
+func SeedServiceDefinitions() []models.ServiceDefinition {
+	defs := make([]models.ServiceDefinition, 0, len(servicesMap))
+	for id, details := range servicesMap {
+		defs = append(defs, serviceDefinitionFromDetails(id, details))
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].ID < defs[j].ID })
+	return defs
+}
+
+// SeedServiceTaxonomy populates an empty taxonomy store from the services map.
+// It is a no-op once any definition exists, so admin edits are never overwritten.
+func SeedServiceTaxonomy(repo serviceRepo.ServiceRepository) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	count, err := repo.Count(ctx)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	for _, def := range SeedServiceDefinitions() {
+		def.UpdatedBy = "seed"
+		if err := repo.Create(ctx, &def); err != nil {
+			return fmt.Errorf("failed to seed service %s: %w", def.ID, err)
+		}
+	}
+	return nil
+}
+
+func serviceDefinitionFromDetails(id string, details ServiceDetails) models.ServiceDefinition {
+	def := models.ServiceDefinition{
+		ID:            id,
+		Metadata:      details.Metadata,
+		CustomOptions: append([]models.CustomOption(nil), details.CustomOptions...),
+		Availability:  append([]string(nil), details.Availability...),
+		Active:        true,
+		Version:       1,
+	}
+	if details.PriceRange != nil {
+		pr := *details.PriceRange
+		def.PriceRange = &pr
+	}
+	return def
+}
+
+// loadServiceTaxonomy returns the active service definitions, read through the
+// booking cache. It falls back to the seed map when the store is unavailable.
+func (svc *DefaultBookingSessionService) loadServiceTaxonomy() []models.ServiceDefinition {
+	if svc.ServiceRepo == nil {
+		return SeedServiceDefinitions()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	logger := utils.GetLogger()
+	cache := utils.GetBookingCacheClient()
+
+	if raw, err := cache.Get(ctx, utils.ServiceTaxonomyCacheKey).Bytes(); err == nil {
+		var defs []models.ServiceDefinition
+		if err := json.Unmarshal(raw, &defs); err == nil {
+			return defs
+		}
+	}
+
+	defs, err := svc.ServiceRepo.GetAll(ctx, false)
+	if err != nil || len(defs) == 0 {
+		logger.Warn("Service taxonomy unavailable, using seed map", zap.Error(err))
+		return SeedServiceDefinitions()
+	}
+
+	if raw, err := json.Marshal(defs); err == nil {
+		if err := cache.Set(ctx, utils.ServiceTaxonomyCacheKey, raw, serviceTaxonomyCacheTTL).Err(); err != nil {
+			logger.Warn("Failed to cache service taxonomy", zap.Error(err))
+		}
+	}
+	return defs
+}
+
+// applyRegionOverride resolves a definition for a region name or country code.
+// It returns false when the override disables the service there.
+func applyRegionOverride(def models.ServiceDefinition, region string) (ServiceDetails, bool) {
+	details := ServiceDetails{
+		Metadata:      def.Metadata,
+		PriceRange:    def.PriceRange,
+		CustomOptions: def.CustomOptions,
+		Availability:  def.Availability,
+	}
+
+	region = strings.TrimSpace(region)
+	if region == "" {
+		return details, true
+	}
+
+	for _, override := range def.RegionOverrides {
+		if !strings.EqualFold(override.Region, region) {
+			continue
+		}
+		if override.Disabled {
+			return details, false
+		}
+		if len(override.Modes) > 0 {
+			details.Metadata.Modes = override.Modes
+		}
+		if override.PriceRange != nil {
+			details.PriceRange = override.PriceRange
+		}
+		if len(override.CustomOptions) > 0 {
+			details.CustomOptions = override.CustomOptions
+		}
+		break
+	}
+	return details, true
+}
@@ -0,0 +1,309 @@
+package booking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"bloomify/config"
+	"bloomify/models"
+	"bloomify/utils"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Live trips live on the booking cache: the trip itself, the provider's latest
+// fix, a throttle key per trip, and a pub/sub channel subscribers listen on.
+const (
+	tripCachePrefix    = "trip:"
+	tripFixSuffix      = ":fix"
+	tripThrottleSuffix = ":throttle"
+	tripChannelPrefix  = "trip:events:"
+)
+
+var (
+	// ErrTrackingUnavailable is returned when setting off for a booking that is not
+	// performed at the customer's location.
+	ErrTrackingUnavailable = errors.New("live location is only shared for in-home bookings")
+	// ErrTripTooEarly is returned when a provider sets off longer than
+	// TRACKING_LEAD_MINUTES before the booking starts.
+	ErrTripTooEarly = errors.New("it is too early to set off for this booking")
+	// ErrTripNotActive is returned outside the tracking window, which runs from the
+	// provider setting off until the job is completed.
+	ErrTripNotActive = errors.New("the provider is not on the way to this booking")
+	// ErrLocationRateLimited is returned when a provider reports locations faster
+	// than TRACKING_MIN_INTERVAL_SECONDS.
+	ErrLocationRateLimited = errors.New("location updates are too frequent")
+	// ErrCheckInOutOfOrder is returned when a check-in skips a step, e.g. completing
+	// a job that was never started.
+	ErrCheckInOutOfOrder = errors.New("check-ins must follow on_the_way, arrived, started, completed")
+)
+
+// checkTripStart validates an on_the_way check-in.
+func checkTripStart(b *models.Booking, now time.Time) error {
+	if b.Mode != models.ModeInHome {
+		return ErrTrackingUnavailable
+	}
+	if findCheckIn(b, models.CheckInArrived) != nil {
+		return ErrCheckInOutOfOrder
+	}
+	startsAt, err := bookingStartsAt(b)
+	if err != nil {
+		return fmt.Errorf("invalid booking date: %w", err)
+	}
+	lead := time.Duration(config.AppConfig.TrackingLeadMinutes) * time.Minute
+	if lead > 0 && now.Before(startsAt.Add(-lead)) {
+		return ErrTripTooEarly
+	}
+	return nil
+}
+
+// tripDestination is where the provider is heading: the service location of
+// the booking, or its address when only that was set.
+func tripDestination(b *models.Booking) models.GeoPoint {
+	if len(b.ServiceLocation.Coordinates) == 2 {
+		return b.ServiceLocation
+	}
+	return b.ServiceAddress.Location
+}
+
+// startTrip opens the live tracking window of a booking. It closes when the
+// job is completed, or two hours after the booking should have ended.
+func (se *DefaultSchedulingEngine) startTrip(ctx context.Context, b *models.Booking, at *models.GeoPoint) {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	if day, err := time.ParseInLocation("2006-01-02", b.Date, time.Local); err == nil {
+		if end := day.Add(time.Duration(b.End)*time.Minute + 2*time.Hour); end.After(expiresAt) {
+			expiresAt = end
+		}
+	}
+	trip := models.LiveTrip{
+		BookingID:   b.ID,
+		ProviderID:  b.ProviderID,
+		UserID:      b.UserID,
+		Destination: tripDestination(b),
+		StartedAt:   now,
+		ExpiresAt:   expiresAt,
+	}
+	data, err := json.Marshal(trip)
+	if err != nil {
+		log.Printf("[Tracking] Failed to encode trip of booking %s: %v", b.ID, err)
+		return
+	}
+	if err := utils.GetBookingCacheClient().Set(ctx, tripCachePrefix+b.ID, data, time.Until(expiresAt)).Err(); err != nil {
+		log.Printf("[Tracking] Failed to open trip of booking %s: %v", b.ID, err)
+		return
+	}
+
+	params := utils.TemplateParams{ProviderName: b.MinimalProviderDTO.ProviderName}
+	if at != nil && len(at.Coordinates) == 2 {
+		loc := liveLocation(&trip, models.LocationFix{Lng: at.Coordinates[0], Lat: at.Coordinates[1]}, now)
+		se.publishLocation(ctx, &trip, loc)
+		params.Minutes = int(math.Ceil(float64(loc.ETASeconds) / 60))
+	}
+	user, _ := se.bookingParties(b)
+	se.notifyBooking("user", b.UserID, user.PreferredLanguage, utils.TemplateProviderOnTheWay, b, params)
+}
+
+// endTrip closes the tracking window of a booking and tells subscribers why.
+func (se *DefaultSchedulingEngine) endTrip(ctx context.Context, bookingID, reason string) {
+	cache := utils.GetBookingCacheClient()
+	key := tripCachePrefix + bookingID
+	n, err := cache.Del(ctx, key, key+tripFixSuffix, key+tripThrottleSuffix).Result()
+	if err != nil {
+		log.Printf("[Tracking] Failed to close trip of booking %s: %v", bookingID, err)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	msg, _ := json.Marshal(models.LiveLocation{BookingID: bookingID, RecordedAt: time.Now(), Ended: true, Reason: reason})
+	if err := cache.Publish(ctx, tripChannelPrefix+bookingID, msg).Err(); err != nil {
+		log.Printf("[Tracking] Failed to publish end of trip %s: %v", bookingID, err)
+	}
+}
+
+// loadTrip returns the open trip of a booking, or ErrTripNotActive.
+func loadTrip(ctx context.Context, bookingID string) (*models.LiveTrip, error) {
+	data, err := utils.GetBookingCacheClient().Get(ctx, tripCachePrefix+bookingID).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrTripNotActive
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trip: %w", err)
+	}
+	var trip models.LiveTrip
+	if err := json.Unmarshal(data, &trip); err != nil {
+		return nil, fmt.Errorf("failed to decode trip: %w", err)
+	}
+	return &trip, nil
+}
+
+// liveLocation adds the straight-line distance and ETA to a fix.
+func liveLocation(trip *models.LiveTrip, fix models.LocationFix, at time.Time) models.LiveLocation {
+	loc := models.LiveLocation{
+		BookingID:  trip.BookingID,
+		Lat:        fix.Lat,
+		Lng:        fix.Lng,
+		Heading:    fix.Heading,
+		Speed:      fix.Speed,
+		Accuracy:   fix.Accuracy,
+		RecordedAt: at,
+	}
+	if len(trip.Destination.Coordinates) == 2 {
+		km := haversine(fix.Lat, fix.Lng, trip.Destination.Coordinates[1], trip.Destination.Coordinates[0])
+		loc.DistanceMeters = math.Round(km * 1000)
+		if speed := config.AppConfig.TrackingSpeedKmh; speed > 0 {
+			loc.ETASeconds = int(math.Round(km / speed * 3600))
+		}
+	}
+	return loc
+}
+
+// publishLocation stores a fix as the trip's latest and sends it to subscribers.
+func (se *DefaultSchedulingEngine) publishLocation(ctx context.Context, trip *models.LiveTrip, loc models.LiveLocation) {
+	data, err := json.Marshal(loc)
+	if err != nil {
+		log.Printf("[Tracking] Failed to encode location of trip %s: %v", trip.BookingID, err)
+		return
+	}
+	cache := utils.GetBookingCacheClient()
+	if err := cache.Set(ctx, tripCachePrefix+trip.BookingID+tripFixSuffix, data, time.Until(trip.ExpiresAt)).Err(); err != nil {
+		log.Printf("[Tracking] Failed to store location of trip %s: %v", trip.BookingID, err)
+	}
+	if err := cache.Publish(ctx, tripChannelPrefix+trip.BookingID, data).Err(); err != nil {
+		log.Printf("[Tracking] Failed to publish location of trip %s: %v", trip.BookingID, err)
+	}
+}
+
+// ShareLocation records the provider's position on an open trip. Fixes closer
+// together than TRACKING_MIN_INTERVAL_SECONDS are rejected.
+func (svc *DefaultBookingSessionService) ShareLocation(providerID, bookingID string, fix models.LocationFix) (*models.LiveLocation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	trip, err := loadTrip(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if trip.ProviderID != providerID {
+		return nil, ErrNotBookingParticipant
+	}
+
+	if interval := config.AppConfig.TrackingMinIntervalSeconds; interval > 0 {
+		key := tripCachePrefix + bookingID + tripThrottleSuffix
+		ok, err := utils.GetBookingCacheClient().SetNX(ctx, key, 1, time.Duration(interval)*time.Second).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rate-limit location: %w", err)
+		}
+		if !ok {
+			return nil, ErrLocationRateLimited
+		}
+	}
+
+	loc := liveLocation(trip, fix, time.Now())
+	svc.SchedulerEngine.publishLocation(ctx, trip, loc)
+	return &loc, nil
+}
+
+// GetLiveLocation returns the latest position of the provider on their way to
+// the user's booking; it is nil until the provider sends one.
+func (svc *DefaultBookingSessionService) GetLiveLocation(userID, bookingID string) (*models.LiveLocation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	trip, err := loadTrip(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if trip.UserID != userID {
+		return nil, ErrNotBookingParticipant
+	}
+	return latestLocation(ctx, bookingID)
+}
+
+func latestLocation(ctx context.Context, bookingID string) (*models.LiveLocation, error) {
+	data, err := utils.GetBookingCacheClient().Get(ctx, tripCachePrefix+bookingID+tripFixSuffix).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load location: %w", err)
+	}
+	var loc models.LiveLocation
+	if err := json.Unmarshal(data, &loc); err != nil {
+		return nil, fmt.Errorf("failed to decode location: %w", err)
+	}
+	return &loc, nil
+}
+
+// SubscribeLiveLocation streams the provider's positions on their way to the
+// user's booking, starting with the latest one. The channel closes when ctx is
+// done, after the message that ends the trip, or when the trip expires.
+func (svc *DefaultBookingSessionService) SubscribeLiveLocation(ctx context.Context, userID, bookingID string) (<-chan models.LiveLocation, error) {
+	trip, err := loadTrip(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if trip.UserID != userID {
+		return nil, ErrNotBookingParticipant
+	}
+
+	// Subscribe before reading the latest fix so nothing is missed in between.
+	pubsub := utils.GetBookingCacheClient().Subscribe(ctx, tripChannelPrefix+bookingID)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to trip: %w", err)
+	}
+	latest, err := latestLocation(ctx, bookingID)
+	if err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	out := make(chan models.LiveLocation, 8)
+	go func() {
+		defer close(out)
+		defer pubsub.Close()
+		if latest != nil {
+			out <- *latest
+		}
+		expired := time.NewTimer(time.Until(trip.ExpiresAt))
+		defer expired.Stop()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-expired.C:
+				select {
+				case out <- models.LiveLocation{BookingID: bookingID, RecordedAt: time.Now(), Ended: true, Reason: "expired"}:
+				case <-ctx.Done():
+				}
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				var loc models.LiveLocation
+				if err := json.Unmarshal([]byte(msg.Payload), &loc); err != nil {
+					log.Printf("[Tracking] Dropping malformed location on trip %s: %v", bookingID, err)
+					continue
+				}
+				select {
+				case out <- loc:
+				case <-ctx.Done():
+					return
+				}
+				if loc.Ended {
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
@@ -5,6 +5,7 @@ import (
 	"bloomify/utils"
 	"context"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -46,24 +47,32 @@ func (s *DefaultBookingSessionService) CancelSession(sessionID string) error {
 	return nil
 }
 
-func (se *DefaultSchedulingEngine) enrichSingleTimeSlot(slot models.TimeSlot, provider models.Provider) models.TimeSlot {
+// enrichSingleTimeSlot merges the catalogue entry the slot serves into the slot.
+// The provider's ServiceCatalogue is the entry selected during matching.
+func (se *DefaultSchedulingEngine) enrichSingleTimeSlot(slot models.TimeSlot, provider models.Provider) (models.TimeSlot, error) {
 	// Create a copy to avoid mutation
 	enriched := slot
 	logger := utils.GetLogger()
 
+	catalogue := provider.ServiceCatalogue
+	if slot.CatalogueID != "" && catalogue.ID != "" && slot.CatalogueID != catalogue.ID {
+		return enriched, fmt.Errorf("timeslot %s does not serve %s (%s)", slot.ID, catalogue.Service.ID, catalogue.Mode)
+	}
+
 	// Merge basic catalogue properties
-	enriched.Catalogue.Service.ID = provider.ServiceCatalogue.Service.ID
-	enriched.Catalogue.Mode = provider.ServiceCatalogue.Mode
-	enriched.Catalogue.Currency = provider.ServiceCatalogue.Currency
+	enriched.Catalogue.ID = catalogue.ID
+	enriched.Catalogue.Service.ID = catalogue.Service.ID
+	enriched.Catalogue.Mode = catalogue.Mode
+	enriched.Catalogue.Currency = catalogue.Currency
 
 	// Create a new slice for merged options
-	mergedOptions := make([]models.CustomOption, 0, len(slot.Catalogue.CustomOptions)+len(provider.ServiceCatalogue.CustomOptions))
+	mergedOptions := make([]models.CustomOption, 0, len(slot.Catalogue.CustomOptions)+len(catalogue.CustomOptions))
 
 	// Add existing slot options first
 	mergedOptions = append(mergedOptions, slot.Catalogue.CustomOptions...)
 
 	// Merge provider options, overriding existing ones
-	for _, providerOpt := range provider.ServiceCatalogue.CustomOptions {
+	for _, providerOpt := range catalogue.CustomOptions {
 		exists := false
 		// Check if option already exists in slot
 		for i, slotOpt := range mergedOptions {
@@ -86,5 +95,7 @@ func (se *DefaultSchedulingEngine) enrichSingleTimeSlot(slot models.TimeSlot, pr
 		zap.String("slotID", enriched.ID),
 		zap.Any("customOptions", enriched.Catalogue.CustomOptions))
 
-	return enriched
+	slots := []models.TimeSlot{enriched}
+	attachDemand(slots, provider, time.Now())
+	return slots[0], nil
 }
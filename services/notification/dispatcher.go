@@ -0,0 +1,197 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"bloomify/config"
+	"bloomify/models"
+	"bloomify/utils"
+
+	"github.com/google/uuid"
+)
+
+// Recipient is a user or provider with their addresses and channel preferences.
+type Recipient struct {
+	ID        string
+	Role      string // "user" or "provider"
+	Name      string
+	FCMTokens []string // one per signed-in device
+	Phone     string
+	Email     string
+	// AlertChannel is "push", "sms", "whatsapp" or "both"; empty means push.
+	AlertChannel string
+	// EmailUpdates also emails the notification types in Dispatcher.EmailTypes.
+	EmailUpdates bool
+}
+
+// Message is one notification, rendered for every channel.
+type Message struct {
+	Type  string
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// DeliveryStore persists per-channel delivery results.
+type DeliveryStore interface {
+	RecordDeliveries(ctx context.Context, deliveries []models.NotificationDelivery) error
+}
+
+// DefaultEmailTypes are the notification types worth an email: booking and
+// payment events the user may want on record.
+var DefaultEmailTypes = map[string]bool{
+	"booking_confirmed":  true,
+	"payment_required":   true,
+	"booking_adjustment": true,
+	"balance_payment":    true,
+	"new_booking":        true,
+}
+
+// Dispatcher routes notifications to the channels a recipient chose. A push
+// the recipient cannot receive, because no FCM token is registered, falls back
+// to SMS.
+type Dispatcher struct {
+	Drivers    map[string]Driver // keyed by channel
+	Store      DeliveryStore     // optional
+	EmailTypes map[string]bool
+}
+
+// NewDispatcherFromConfig returns a dispatcher with every channel configured
+// in the environment. With NOTIFICATION_TRANSPORT=memory all channels are
+// in-memory drivers. Push tokens FCM rejects as unregistered go to pruner.
+func NewDispatcherFromConfig(store DeliveryStore, pruner TokenPruner) *Dispatcher {
+	d := &Dispatcher{
+		Drivers:    map[string]Driver{},
+		Store:      store,
+		EmailTypes: DefaultEmailTypes,
+	}
+	cfg := config.AppConfig
+
+	if cfg.NotificationTransport == "memory" {
+		for _, ch := range []string{models.ChannelPush, models.ChannelSMS, models.ChannelWhatsApp, models.ChannelEmail} {
+			d.Drivers[ch] = &MemoryDriver{Channel: ch}
+		}
+		return d
+	}
+
+	d.Drivers[models.ChannelPush] = FCMDriver{Pruner: pruner}
+	if gateway := utils.GetMessagingGateway(); gateway != nil {
+		d.Drivers[models.ChannelSMS] = GatewayDriver{Gateway: gateway, Channel: models.ChannelSMS}
+		d.Drivers[models.ChannelWhatsApp] = GatewayDriver{Gateway: gateway, Channel: models.ChannelWhatsApp}
+	}
+	if cfg.SMTPHost != "" {
+		d.Drivers[models.ChannelEmail] = SMTPDriver{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		}
+	}
+	return d
+}
+
+// Channels returns the channels a notification type goes to for a recipient.
+func (d *Dispatcher) Channels(to Recipient, msgType string) []string {
+	var channels []string
+	switch to.AlertChannel {
+	case models.ChannelSMS:
+		channels = []string{models.ChannelSMS}
+	case models.ChannelWhatsApp:
+		channels = []string{models.ChannelWhatsApp}
+	case "both":
+		channels = []string{models.ChannelPush, models.ChannelSMS}
+	default:
+		channels = []string{models.ChannelPush}
+	}
+	if to.EmailUpdates && d.EmailTypes[msgType] {
+		channels = append(channels, models.ChannelEmail)
+	}
+	return channels
+}
+
+// Dispatch sends msg on each of the recipient's channels and records the
+// results. It fails only when no channel delivered the message.
+func (d *Dispatcher) Dispatch(ctx context.Context, to Recipient, msg Message) ([]models.NotificationDelivery, error) {
+	dispatchID := uuid.New().String()
+	channels := d.Channels(to, msg.Type)
+
+	var deliveries []models.NotificationDelivery
+	for _, ch := range channels {
+		delivery := d.send(ctx, dispatchID, ch, to, msg)
+		deliveries = append(deliveries, delivery)
+
+		if ch == models.ChannelPush && delivery.Status == models.DeliverySkipped && !slices.Contains(channels, models.ChannelSMS) {
+			fallback := d.send(ctx, dispatchID, models.ChannelSMS, to, msg)
+			fallback.Fallback = true
+			deliveries = append(deliveries, fallback)
+		}
+	}
+
+	if d.Store != nil {
+		if err := d.Store.RecordDeliveries(ctx, deliveries); err != nil {
+			log.Printf("[Dispatcher] Failed to record deliveries of %s: %v", dispatchID, err)
+		}
+	}
+
+	var errs []error
+	for _, del := range deliveries {
+		if del.Status == models.DeliverySent {
+			return deliveries, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %s", del.Channel, del.Error))
+	}
+	return deliveries, fmt.Errorf("notification %q to %s %s not delivered: %w", msg.Type, to.Role, to.ID, errors.Join(errs...))
+}
+
+func (d *Dispatcher) send(ctx context.Context, dispatchID, channel string, to Recipient, msg Message) models.NotificationDelivery {
+	delivery := models.NotificationDelivery{
+		ID:          uuid.New().String(),
+		DispatchID:  dispatchID,
+		RecipientID: to.ID,
+		Role:        to.Role,
+		Type:        msg.Type,
+		Channel:     channel,
+		CreatedAt:   time.Now(),
+	}
+
+	driver, ok := d.Drivers[channel]
+	if !ok {
+		delivery.Status = models.DeliverySkipped
+		delivery.Error = "channel not configured"
+		return delivery
+	}
+
+	id, err := driver.Send(ctx, to, msg)
+	switch {
+	case errors.Is(err, ErrUnreachable):
+		delivery.Status = models.DeliverySkipped
+		delivery.Error = err.Error()
+	case err != nil:
+		delivery.Status = models.DeliveryFailed
+		delivery.Error = err.Error()
+	default:
+		delivery.Status = models.DeliverySent
+		delivery.ExternalID = id
+	}
+	return delivery
+}
+
+// addressOn returns the recipient's address on a channel.
+func addressOn(channel string, to Recipient) string {
+	switch channel {
+	case models.ChannelPush:
+		return strings.Join(to.FCMTokens, ",")
+	case models.ChannelSMS, models.ChannelWhatsApp:
+		return to.Phone
+	case models.ChannelEmail:
+		return to.Email
+	}
+	return ""
+}
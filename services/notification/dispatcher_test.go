@@ -0,0 +1,145 @@
+package notification
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"bloomify/models"
+)
+
+// memoryStore keeps recorded deliveries in memory.
+type memoryStore struct {
+	mu         sync.Mutex
+	deliveries []models.NotificationDelivery
+}
+
+func (s *memoryStore) RecordDeliveries(ctx context.Context, deliveries []models.NotificationDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries = append(s.deliveries, deliveries...)
+	return nil
+}
+
+func newMemoryDispatcher() (*Dispatcher, map[string]*MemoryDriver, *memoryStore) {
+	store := &memoryStore{}
+	d := &Dispatcher{Drivers: map[string]Driver{}, Store: store, EmailTypes: DefaultEmailTypes}
+	drivers := map[string]*MemoryDriver{}
+	for _, ch := range []string{models.ChannelPush, models.ChannelSMS, models.ChannelWhatsApp, models.ChannelEmail} {
+		drivers[ch] = &MemoryDriver{Channel: ch}
+		d.Drivers[ch] = drivers[ch]
+	}
+	return d, drivers, store
+}
+
+func TestDispatch(t *testing.T) {
+	reachable := Recipient{ID: "u1", Role: "user", FCMTokens: []string{"token"}, Phone: "+254700000000", Email: "u1@example.com"}
+	noPush := reachable
+	noPush.FCMTokens = nil
+
+	type delivery struct {
+		channel  string
+		status   string
+		fallback bool
+	}
+	tests := []struct {
+		name    string
+		to      Recipient
+		alert   string
+		msgType string
+		want    []delivery
+		wantErr bool
+	}{
+		{
+			name: "push by default", to: reachable,
+			want: []delivery{{models.ChannelPush, models.DeliverySent, false}},
+		},
+		{
+			name: "push falls back to sms", to: noPush,
+			want: []delivery{
+				{models.ChannelPush, models.DeliverySkipped, false},
+				{models.ChannelSMS, models.DeliverySent, true},
+			},
+		},
+		{
+			name: "sms only", to: reachable, alert: models.ChannelSMS,
+			want: []delivery{{models.ChannelSMS, models.DeliverySent, false}},
+		},
+		{
+			name: "whatsapp only", to: reachable, alert: models.ChannelWhatsApp,
+			want: []delivery{{models.ChannelWhatsApp, models.DeliverySent, false}},
+		},
+		{
+			name: "both does not fall back twice", to: noPush, alert: "both",
+			want: []delivery{
+				{models.ChannelPush, models.DeliverySkipped, false},
+				{models.ChannelSMS, models.DeliverySent, false},
+			},
+		},
+		{
+			name: "email for types worth one", to: reachable, msgType: "booking_confirmed",
+			want: []delivery{
+				{models.ChannelPush, models.DeliverySent, false},
+				{models.ChannelEmail, models.DeliverySent, false},
+			},
+		},
+		{
+			name: "unreachable everywhere", to: Recipient{ID: "u2", Role: "user"},
+			want: []delivery{
+				{models.ChannelPush, models.DeliverySkipped, false},
+				{models.ChannelSMS, models.DeliverySkipped, true},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, drivers, store := newMemoryDispatcher()
+			to := tt.to
+			to.AlertChannel = tt.alert
+			to.EmailUpdates = tt.msgType != ""
+
+			got, err := d.Dispatch(context.Background(), to, Message{Type: tt.msgType, Title: "Hello", Body: "World"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Dispatch error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d deliveries %+v, want %d", len(got), got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				del := got[i]
+				if (delivery{del.Channel, del.Status, del.Fallback}) != want {
+					t.Errorf("delivery %d = %s/%s fallback=%v, want %+v", i, del.Channel, del.Status, del.Fallback, want)
+				}
+				if del.RecipientID != to.ID || del.DispatchID != got[0].DispatchID {
+					t.Errorf("delivery %d = %+v, want recipient %s in dispatch %s", i, del, to.ID, got[0].DispatchID)
+				}
+				if del.Status == models.DeliverySent {
+					if del.ExternalID == "" {
+						t.Errorf("delivery %d has no external ID", i)
+					}
+					if n := len(drivers[del.Channel].Messages()); n != 1 {
+						t.Errorf("%s driver sent %d messages, want 1", del.Channel, n)
+					}
+				}
+			}
+			if len(store.deliveries) != len(got) {
+				t.Errorf("recorded %d deliveries, want %d", len(store.deliveries), len(got))
+			}
+		})
+	}
+}
+
+func TestDispatchUnconfiguredChannel(t *testing.T) {
+	d, _, _ := newMemoryDispatcher()
+	delete(d.Drivers, models.ChannelWhatsApp)
+
+	got, err := d.Dispatch(context.Background(), Recipient{ID: "u1", Phone: "+254700000000", AlertChannel: models.ChannelWhatsApp}, Message{Title: "Hello"})
+	if err == nil {
+		t.Fatal("Dispatch succeeded without a whatsapp driver")
+	}
+	if len(got) != 1 || got[0].Status != models.DeliverySkipped || got[0].Error != "channel not configured" {
+		t.Errorf("got %+v, want one skipped whatsapp delivery", got)
+	}
+}
@@ -0,0 +1,274 @@
+package notification
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"bloomify/utils"
+
+	"firebase.google.com/go/v4/messaging"
+)
+
+// ErrUnreachable is returned by a driver when the recipient has no address on
+// its channel, e.g. no FCM token or no phone number.
+var ErrUnreachable = errors.New("recipient has no address on this channel")
+
+// Driver sends notifications on one channel and returns the message ID the
+// channel assigned, if any.
+type Driver interface {
+	Send(ctx context.Context, to Recipient, msg Message) (string, error)
+}
+
+// TokenPruner forgets FCM tokens that FCM reported as no longer registered,
+// e.g. because the app was uninstalled.
+type TokenPruner interface {
+	PruneFCMTokens(ctx context.Context, role, recipientID string, tokens []string)
+}
+
+// FCMDriver sends push notifications through Firebase Cloud Messaging to every
+// device of the recipient.
+type FCMDriver struct {
+	Pruner TokenPruner // optional
+}
+
+func (d FCMDriver) Send(ctx context.Context, to Recipient, msg Message) (string, error) {
+	if len(to.FCMTokens) == 0 {
+		return "", ErrUnreachable
+	}
+	ids, err := sendPush(ctx, d.Pruner, to.Role, to.ID, to.FCMTokens, msg.Title, msg.Body, msg.Data)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(ids, ","), nil
+}
+
+// sendPush multicasts a push to tokens and returns the message IDs of the
+// devices that accepted it. Tokens FCM reports as unregistered are handed to
+// pruner; when no token is left it returns ErrUnreachable.
+func sendPush(ctx context.Context, pruner TokenPruner, role, recipientID string, tokens []string, title, body string, data map[string]string) ([]string, error) {
+	if utils.FCMClient == nil {
+		return nil, errors.New("FCM client not initialized")
+	}
+	resp, err := utils.FCMClient.SendEachForMulticast(ctx, fcmMessage(role, tokens, title, body, data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to send FCM message: %w", err)
+	}
+
+	var ids, stale []string
+	var errs []error
+	for i, r := range resp.Responses {
+		switch {
+		case r.Success:
+			ids = append(ids, r.MessageID)
+		case messaging.IsUnregistered(r.Error):
+			stale = append(stale, tokens[i])
+		default:
+			errs = append(errs, r.Error)
+		}
+	}
+	if len(stale) > 0 && pruner != nil {
+		pruner.PruneFCMTokens(ctx, role, recipientID, stale)
+	}
+	if len(ids) == 0 {
+		if len(errs) == 0 {
+			return nil, ErrUnreachable
+		}
+		return nil, fmt.Errorf("failed to send FCM message: %w", errors.Join(errs...))
+	}
+	return ids, nil
+}
+
+// fcmMessage builds a push to every token. Provider pushes are high priority
+// so new bookings ring through on locked phones.
+func fcmMessage(role string, tokens []string, title, body string, data map[string]string) *messaging.MulticastMessage {
+	msg := &messaging.MulticastMessage{
+		Tokens: tokens,
+		Notification: &messaging.Notification{
+			Title: title,
+			Body:  body,
+		},
+		Data: data,
+	}
+	if role == "provider" {
+		msg.Android = &messaging.AndroidConfig{
+			Priority: "high",
+			Notification: &messaging.AndroidNotification{
+				ChannelID: "high_priority",
+				Sound:     "default",
+			},
+		}
+		msg.APNS = &messaging.APNSConfig{
+			Headers: map[string]string{
+				"apns-priority":  "10",
+				"apns-push-type": "alert",
+			},
+			Payload: &messaging.APNSPayload{
+				Aps: &messaging.Aps{
+					Sound: "default",
+				},
+			},
+		}
+	}
+	return msg
+}
+
+// GatewayDriver sends SMS or WhatsApp messages through the HTTP messaging gateway.
+type GatewayDriver struct {
+	Gateway *utils.MessagingGateway
+	Channel string // models.ChannelSMS or models.ChannelWhatsApp
+}
+
+func (d GatewayDriver) Send(ctx context.Context, to Recipient, msg Message) (string, error) {
+	if to.Phone == "" {
+		return "", ErrUnreachable
+	}
+	return d.Gateway.Send(ctx, d.Channel, to.Phone, textBody(msg))
+}
+
+// smtpTimeout bounds a whole SMTP exchange when the context has no earlier
+// deadline.
+const smtpTimeout = 30 * time.Second
+
+// SMTPDriver sends plain-text email.
+type SMTPDriver struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+func (d SMTPDriver) Send(ctx context.Context, to Recipient, msg Message) (string, error) {
+	if to.Email == "" {
+		return "", ErrUnreachable
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", d.From)
+	fmt.Fprintf(&b, "To: %s\r\n", to.Email)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Title))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	if err := d.sendMail(ctx, to.Email, []byte(b.String())); err != nil {
+		return "", fmt.Errorf("failed to send email: %w", err)
+	}
+	return "", nil
+}
+
+// sendMail does what smtp.SendMail does, over a connection dialed with ctx and
+// bounded by its deadline, so a stalled server cannot hold up the dispatch.
+func (d SMTPDriver) sendMail(ctx context.Context, rcpt string, body []byte) error {
+	deadline := time.Now().Add(smtpTimeout)
+	if dl, ok := ctx.Deadline(); ok && dl.Before(deadline) {
+		deadline = dl
+	}
+	dialer := net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(d.Host, strconv.Itoa(d.Port)))
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, d.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: d.Host}); err != nil {
+			return err
+		}
+	}
+	if d.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := c.Auth(smtp.PlainAuth("", d.Username, d.Password, d.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(d.From); err != nil {
+		return err
+	}
+	if err := c.Rcpt(rcpt); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// SentMessage is a message kept by a MemoryDriver.
+type SentMessage struct {
+	Channel string
+	To      Recipient
+	Message Message
+	SentAt  time.Time
+}
+
+// MemoryDriver keeps messages in memory instead of sending them, for tests
+// and local development.
+type MemoryDriver struct {
+	Channel string
+
+	mu   sync.Mutex
+	sent []SentMessage
+}
+
+func (d *MemoryDriver) Send(ctx context.Context, to Recipient, msg Message) (string, error) {
+	if addressOn(d.Channel, to) == "" {
+		return "", ErrUnreachable
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sent = append(d.sent, SentMessage{Channel: d.Channel, To: to, Message: msg, SentAt: time.Now()})
+	return fmt.Sprintf("mem-%s-%d", d.Channel, len(d.sent)), nil
+}
+
+// Messages returns a copy of the messages sent so far.
+func (d *MemoryDriver) Messages() []SentMessage {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]SentMessage(nil), d.sent...)
+}
+
+// Reset forgets every sent message.
+func (d *MemoryDriver) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sent = nil
+}
+
+// textBody joins title and body for channels without a separate subject.
+func textBody(msg Message) string {
+	if msg.Title == "" {
+		return msg.Body
+	}
+	return msg.Title + "\n" + msg.Body
+}
@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"bloomify/models"
+)
+
+// InboxStore keeps the inboxes of users and providers and the notification
+// types they muted.
+type InboxStore interface {
+	AddNotification(ctx context.Context, n models.Notification) error
+	GetPreferences(ctx context.Context, recipientID string) (*models.NotificationPreferences, error)
+}
+
+// AddToInbox stores a notification in the inbox of a user or provider.
+func (s *DefaultNotificationService) AddToInbox(ctx context.Context, role, recipientID string, n models.Notification) error {
+	if s.Inbox == nil {
+		return fmt.Errorf("AddToInbox: no inbox store configured")
+	}
+	n.RecipientID = recipientID
+	n.Role = role
+	return s.Inbox.AddNotification(ctx, n)
+}
+
+// muted reports whether a recipient muted notifications of the given type.
+// Lookup failures are logged and treated as not muted.
+func (s *DefaultNotificationService) muted(ctx context.Context, recipientID, kind string) bool {
+	if s.Inbox == nil || kind == "" {
+		return false
+	}
+	prefs, err := s.Inbox.GetPreferences(ctx, recipientID)
+	if err != nil {
+		log.Printf("[Notification] Failed to load preferences of %s: %v", recipientID, err)
+		return false
+	}
+	return slices.Contains(prefs.MutedTypes, kind)
+}
@@ -3,27 +3,40 @@ package notification
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
 
 	"bloomify/models"
 	"bloomify/services/provider"
 	"bloomify/services/user"
 	"bloomify/utils"
-
-	"firebase.google.com/go/v4/messaging"
 )
 
-// NotificationService defines methods for sending FCM pushes.
+// NotificationService defines methods for sending FCM pushes and for
+// notifying users and providers on the channels they chose.
 type NotificationService interface {
 	SendUserPushNotification(ctx context.Context, userID, title, body string, data map[string]string) error
 	SendProviderPushNotification(ctx context.Context, providerID, title, body string, data map[string]string) error
 	NotifyScheduleUpdate(ctx context.Context, providerID string, req models.SetupTimeslotsRequest) error
+
+	// NotifyUser and NotifyProvider route data["type"] through the dispatcher.
+	NotifyUser(ctx context.Context, userID, title, body string, data map[string]string) error
+	NotifyProvider(ctx context.Context, providerID, title, body string, data map[string]string) error
+
+	// AddToInbox stores a notification in a user's or provider's inbox.
+	AddToInbox(ctx context.Context, role, recipientID string, n models.Notification) error
 }
 
 // DefaultNotificationService is the production implementation.
 type DefaultNotificationService struct {
 	user     user.UserService
 	provider provider.ProviderService
+	// Dispatcher sends on every channel; without it NotifyUser and
+	// NotifyProvider fall back to push only.
+	Dispatcher *Dispatcher
+	// Inbox stores inbox notifications and muting; without it nothing is
+	// muted and AddToInbox fails.
+	Inbox InboxStore
 }
 
 func NewDefaultNotificationService(
@@ -39,7 +52,7 @@ func NewDefaultNotificationService(
 	}, nil
 }
 
-// SendUserPushNotification looks up a user’s FCM token and sends a push.
+// SendUserPushNotification sends a push to every device of a user.
 func (s *DefaultNotificationService) SendUserPushNotification(
 	ctx context.Context,
 	userID, title, body string,
@@ -49,8 +62,8 @@ func (s *DefaultNotificationService) SendUserPushNotification(
 	if err != nil {
 		return fmt.Errorf("SendUserPushNotification: could not find user %s: %w", userID, err)
 	}
-	token := u.FCMToken
-	if token == "" {
+	tokens := u.PushTokens()
+	if len(tokens) == 0 {
 		return fmt.Errorf("SendUserPushNotification: user %s has no FCM token", userID)
 	}
 
@@ -60,21 +73,12 @@ func (s *DefaultNotificationService) SendUserPushNotification(
 		fmt.Printf("⚠️ [SendUserPushNotification] 'role' not set, defaulting to 'user'\n")
 	}
 
-	msg := &messaging.Message{
-		Token: token,
-		Notification: &messaging.Notification{
-			Title: title,
-			Body:  body,
-		},
-		Data: data,
-	}
-
-	response, err := utils.FCMClient.Send(ctx, msg)
+	ids, err := sendPush(ctx, s, "user", userID, tokens, title, body, data)
 	if err != nil {
-		return fmt.Errorf("SendUserPushNotification: failed to send FCM message: %w", err)
+		return fmt.Errorf("SendUserPushNotification: %w", err)
 	}
 
-	fmt.Printf("SendUserPushNotification: successfully sent message: %s\n", response)
+	fmt.Printf("SendUserPushNotification: successfully sent messages: %s\n", strings.Join(ids, ", "))
 	return nil
 }
 
@@ -87,8 +91,8 @@ func (s *DefaultNotificationService) SendProviderPushNotification(
 	if err != nil {
 		return fmt.Errorf("SendProviderPushNotification: could not find provider %s: %w", providerID, err)
 	}
-	token := p.Security.FCMToken
-	if token == "" {
+	tokens := p.PushTokens()
+	if len(tokens) == 0 {
 		return fmt.Errorf("SendProviderPushNotification: provider %s has no FCM token", providerID)
 	}
 
@@ -98,49 +102,113 @@ func (s *DefaultNotificationService) SendProviderPushNotification(
 		fmt.Printf("⚠️ [SendProviderPushNotification] 'role' not set, defaulting to 'provider'\n")
 	}
 
-	msg := &messaging.Message{
-		Token: token,
-		Notification: &messaging.Notification{
-			Title: title,
-			Body:  body,
-		},
-		Data: data,
-		Android: &messaging.AndroidConfig{
-			Priority: "high",
-			Notification: &messaging.AndroidNotification{
-				ChannelID: "high_priority",
-				Sound:     "default",
-			},
-		},
-		APNS: &messaging.APNSConfig{
-			Headers: map[string]string{
-				"apns-priority":  "10",
-				"apns-push-type": "alert",
-			},
-			Payload: &messaging.APNSPayload{
-				Aps: &messaging.Aps{
-					Sound: "default",
-				},
-			},
-		},
-	}
-
-	if _, err := utils.FCMClient.Send(ctx, msg); err != nil {
-		return fmt.Errorf("SendProviderPushNotification: failed to send FCM message: %w", err)
+	if _, err := sendPush(ctx, s, "provider", providerID, tokens, title, body, data); err != nil {
+		return fmt.Errorf("SendProviderPushNotification: %w", err)
 	}
 
 	return nil
 }
 
+// NotifyUser sends a notification to a user on the channels of their safety
+// settings, plus email when they opted into email updates. Types the user
+// muted are not sent.
+func (s *DefaultNotificationService) NotifyUser(
+	ctx context.Context,
+	userID, title, body string,
+	data map[string]string,
+) error {
+	if s.muted(ctx, userID, data["type"]) {
+		return nil
+	}
+	if s.Dispatcher == nil {
+		return s.SendUserPushNotification(ctx, userID, title, body, data)
+	}
+	u, err := s.user.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("NotifyUser: could not find user %s: %w", userID, err)
+	}
+	if data == nil {
+		data = map[string]string{}
+	}
+	if _, ok := data["role"]; !ok {
+		data["role"] = "user"
+	}
+
+	to := Recipient{
+		ID:           u.ID,
+		Role:         "user",
+		Name:         u.Username,
+		FCMTokens:    u.PushTokens(),
+		Phone:        u.PhoneNumber,
+		Email:        u.Email,
+		AlertChannel: u.SafetySettings.AlertChannel,
+		EmailUpdates: u.SafetySettings.EmailUpdates,
+	}
+	_, err = s.Dispatcher.Dispatch(ctx, to, Message{Type: data["type"], Title: title, Body: body, Data: data})
+	return err
+}
+
+// NotifyProvider sends a notification to a provider by push, or by SMS when
+// the provider has no push token. Types the provider muted are not sent.
+func (s *DefaultNotificationService) NotifyProvider(
+	ctx context.Context,
+	providerID, title, body string,
+	data map[string]string,
+) error {
+	if s.muted(ctx, providerID, data["type"]) {
+		return nil
+	}
+	if s.Dispatcher == nil {
+		return s.SendProviderPushNotification(ctx, providerID, title, body, data)
+	}
+	p, err := s.provider.GetProviderByID(ctx, providerID, true)
+	if err != nil {
+		return fmt.Errorf("NotifyProvider: could not find provider %s: %w", providerID, err)
+	}
+	if data == nil {
+		data = map[string]string{}
+	}
+	if _, ok := data["role"]; !ok {
+		data["role"] = "provider"
+	}
+
+	to := Recipient{
+		ID:        p.ID,
+		Role:      "provider",
+		Name:      p.Profile.ProviderName,
+		FCMTokens: p.PushTokens(),
+		Phone:     p.Profile.PhoneNumber,
+		Email:     p.Profile.Email,
+	}
+	_, err = s.Dispatcher.Dispatch(ctx, to, Message{Type: data["type"], Title: title, Body: body, Data: data})
+	return err
+}
+
+// PruneFCMTokens removes push tokens FCM no longer accepts from the user's or
+// provider's devices.
+func (s *DefaultNotificationService) PruneFCMTokens(ctx context.Context, role, recipientID string, tokens []string) {
+	var err error
+	if role == "provider" {
+		err = s.provider.RemoveFCMTokens(recipientID, tokens)
+	} else {
+		err = s.user.RemoveFCMTokens(recipientID, tokens)
+	}
+	if err != nil {
+		log.Printf("[Notification] Failed to prune %d FCM tokens of %s %s: %v", len(tokens), role, recipientID, err)
+		return
+	}
+	log.Printf("[Notification] Pruned %d unregistered FCM tokens of %s %s", len(tokens), role, recipientID)
+}
+
 func (s *DefaultNotificationService) NotifyScheduleUpdate(
 	ctx context.Context,
 	providerID string,
 	req models.SetupTimeslotsRequest,
 ) error {
-	// fetch provider (we need this for token anyway)
+	// fetch provider for its language; the dispatcher picks the channels
 	prov, err := s.provider.GetProviderByID(ctx, providerID, true)
-	if err != nil || prov.Security.FCMToken == "" {
-		return nil // fail silently if no push target
+	if err != nil {
+		return nil // fail silently if the provider is gone
 	}
 
 	daySet := map[string]bool{}
@@ -164,25 +232,17 @@ func (s *DefaultNotificationService) NotifyScheduleUpdate(
 		}
 	}
 
-	title := "You’ve updated your work schedule 🗓️"
-	body := fmt.Sprintf(
-		"Your service is now scheduled across %d week%s. Active days include %s — starting %s. We’ll remind you as your days approach!",
-		totalWeeks,
-		plural(totalWeeks),
-		strings.Join(daysList, ", "),
-		startDate,
-	)
+	title, body, err := utils.RenderNotification(utils.TemplateScheduleUpdate, prov.PreferredLanguage, utils.TemplateParams{
+		Count:     totalWeeks,
+		Days:      daysList,
+		StartDate: startDate,
+	})
+	if err != nil {
+		return err
+	}
 
-	return s.SendProviderPushNotification(ctx, providerID, title, body, map[string]string{
-		"type": "schedule_update",
+	return s.NotifyProvider(ctx, providerID, title, body, map[string]string{
+		"type": utils.TemplateScheduleUpdate,
 		"role": "provider",
 	})
 }
-
-// plural returns "s" if n is not 1, otherwise returns an empty string.
-func plural(n int) string {
-	if n == 1 {
-		return ""
-	}
-	return "s"
-}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bloomify/models"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// validateCatalogueEntry checks the minimum a catalogue entry needs to be bookable.
+func validateCatalogueEntry(entry models.ServiceCatalogue) error {
+	if entry.Service.ID == "" || entry.Mode == "" {
+		return fmt.Errorf("service type and mode are required")
+	}
+	if entry.Price < 0 {
+		return fmt.Errorf("price must not be negative")
+	}
+	return nil
+}
+
+// normalizeCatalogues validates the entries, assigns IDs to new ones and rejects
+// duplicate service/mode pairs so booking can always resolve a single entry.
+func normalizeCatalogues(entries []models.ServiceCatalogue) ([]models.ServiceCatalogue, error) {
+	seen := make(map[string]bool, len(entries))
+	out := make([]models.ServiceCatalogue, 0, len(entries))
+	for i, entry := range entries {
+		if err := validateCatalogueEntry(entry); err != nil {
+			return nil, fmt.Errorf("catalogue entry %d: %w", i+1, err)
+		}
+		key := entry.Service.ID + "|" + entry.Mode
+		if seen[key] {
+			return nil, fmt.Errorf("catalogue entry %d: %s is already offered in mode %s", i+1, entry.Service.ID, entry.Mode)
+		}
+		seen[key] = true
+		if entry.ID == "" {
+			entry.ID = uuid.New().String()
+		}
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+// saveCatalogues persists the entries and keeps the primary serviceCatalogue in sync.
+func (s *DefaultProviderService) saveCatalogues(prov *models.Provider, entries []models.ServiceCatalogue) error {
+	normalized, err := normalizeCatalogues(entries)
+	if err != nil {
+		return err
+	}
+	if len(normalized) == 0 {
+		return fmt.Errorf("a provider must offer at least one catalogue entry")
+	}
+
+	prov.ServiceCatalogues = normalized
+	prov.ServiceCatalogue = normalized[0]
+	prov.UpdatedAt = time.Now()
+
+	updateDoc := bson.M{
+		"serviceCatalogues": prov.ServiceCatalogues,
+		"serviceCatalogue":  prov.ServiceCatalogue,
+		"updatedAt":         prov.UpdatedAt,
+	}
+	if err := s.Repo.UpdateSetDocument(prov.ID, updateDoc); err != nil {
+		return fmt.Errorf("failed to update service catalogue: %w", err)
+	}
+	return nil
+}
+
+// AddCatalogueEntry adds a new service offering to the provider.
+func (s *DefaultProviderService) AddCatalogueEntry(c context.Context, providerID string, entry models.ServiceCatalogue) (*models.Provider, error) {
+	prov, err := s.Repo.GetByIDWithProjection(providerID, nil)
+	if err != nil || prov == nil {
+		return nil, fmt.Errorf("provider not found")
+	}
+
+	entry.ID = ""
+	entries := append(prov.Catalogues(), entry)
+	if err := s.saveCatalogues(prov, entries); err != nil {
+		return nil, err
+	}
+	return prov, nil
+}
+
+// UpdateCatalogueEntry replaces the catalogue entry identified by catalogueID.
+func (s *DefaultProviderService) UpdateCatalogueEntry(c context.Context, providerID, catalogueID string, entry models.ServiceCatalogue) (*models.Provider, error) {
+	prov, err := s.Repo.GetByIDWithProjection(providerID, nil)
+	if err != nil || prov == nil {
+		return nil, fmt.Errorf("provider not found")
+	}
+
+	entries := prov.Catalogues()
+	found := false
+	for i := range entries {
+		if entries[i].ID == catalogueID {
+			entry.ID = catalogueID
+			entries[i] = entry
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("catalogue entry %s not found", catalogueID)
+	}
+
+	if err := s.saveCatalogues(prov, entries); err != nil {
+		return nil, err
+	}
+	return prov, nil
+}
+
+// RemoveCatalogueEntry removes a catalogue entry. Timeslots still pointing at it
+// stop being offered, since booking can no longer resolve their entry.
+func (s *DefaultProviderService) RemoveCatalogueEntry(c context.Context, providerID, catalogueID string) (*models.Provider, error) {
+	prov, err := s.Repo.GetByIDWithProjection(providerID, nil)
+	if err != nil || prov == nil {
+		return nil, fmt.Errorf("provider not found")
+	}
+
+	existing := prov.Catalogues()
+	entries := make([]models.ServiceCatalogue, 0, len(existing))
+	for _, entry := range existing {
+		if entry.ID != catalogueID {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == len(existing) {
+		return nil, fmt.Errorf("catalogue entry %s not found", catalogueID)
+	}
+
+	if err := s.saveCatalogues(prov, entries); err != nil {
+		return nil, err
+	}
+	return prov, nil
+}
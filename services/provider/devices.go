@@ -1,9 +1,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"bloomify/models"
+
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 // GetProviderDevices retrieves the list of devices associated with a provider.
@@ -19,6 +23,8 @@ func (s *DefaultProviderService) GetProviderDevices(providerID string) ([]models
 }
 
 // SignOutOtherDevices retains only the device matching the currentDeviceID for the provider.
+// The push tokens of the other devices go with them, as does the account-wide
+// token, which may belong to one.
 func (s *DefaultProviderService) SignOutOtherDevices(providerID, currentDeviceID string) error {
 	provider, err := s.Repo.GetByIDWithProjection(providerID, nil)
 	if err != nil {
@@ -35,11 +41,32 @@ func (s *DefaultProviderService) SignOutOtherDevices(providerID, currentDeviceID
 			filteredDevices = append(filteredDevices, device)
 		}
 	}
-	provider.Devices = filteredDevices
 
-	// Update the provider document in the repository.
-	if err := s.Repo.Update(provider); err != nil {
+	// Patch only the devices, so fields left out of the read are kept.
+	updateDoc := bson.M{
+		"devices":           filteredDevices,
+		"security.fcmToken": "",
+		"updatedAt":         time.Now(),
+	}
+	if err := s.Repo.UpdateSetDocument(providerID, updateDoc); err != nil {
 		return fmt.Errorf("failed to update provider devices: %w", err)
 	}
 	return nil
 }
+
+// RegisterFCMToken stores the push token of one of the provider's devices. A
+// token that moved from another device is removed there.
+func (s *DefaultProviderService) RegisterFCMToken(c context.Context, providerID, deviceID, token string) (*models.Provider, error) {
+	if err := s.Repo.SetDeviceFCMToken(providerID, deviceID, token); err != nil {
+		return nil, fmt.Errorf("failed to update provider devices: %w", err)
+	}
+	return s.GetProviderByID(c, providerID, true)
+}
+
+// RemoveFCMTokens forgets push tokens FCM no longer accepts.
+func (s *DefaultProviderService) RemoveFCMTokens(providerID string, tokens []string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	return s.Repo.ClearFCMTokens(providerID, tokens)
+}
@@ -62,7 +62,7 @@ func (s *DefaultProviderService) ResetPassword(email, providedOTP, newPassword,
 		_, err := sessionClient.Get(ctx, otpCacheKey).Result()
 		if err != nil {
 			// OTP not set; initiate OTP for password reset.
-			if err := utils.InitiateDeviceOTP(provider.ID, "reset_password", provider.Profile.PhoneNumber); err != nil {
+			if err := utils.InitiateDeviceOTP(provider.ID, "reset_password", provider.Profile.PhoneNumber, provider.PreferredLanguage); err != nil {
 				return fmt.Errorf("failed to initiate OTP: %w", err)
 			}
 			authSession.Status = "pending_otp"
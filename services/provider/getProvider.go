@@ -37,6 +37,7 @@ func (s *DefaultProviderService) GetProviderByID(c context.Context, providerID s
 			"profile.profileImage":     1,
 			"profile.rating":           1,
 			"serviceCatalogue":         1,
+			"serviceCatalogues":        1,
 		}
 	}
 
@@ -67,6 +68,7 @@ func (s *DefaultProviderService) GetProviderByEmail(c context.Context, email str
 			"profile.profileImage":     1,
 			"profile.rating":           1,
 			"serviceCatalogue":         1,
+			"serviceCatalogues":        1,
 		}
 	}
 
@@ -89,6 +91,7 @@ func (s *DefaultProviderService) GetAllProviders() ([]models.Provider, error) {
 		"profile.profileImage":     1,
 		"profile.rating":           1,
 		"serviceCatalogue":         1,
+		"serviceCatalogues":        1,
 	}
 	providers, err := s.Repo.GetAllWithProjection(projection)
 	if err != nil {
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	notificationRepo "bloomify/database/repository/notification"
 	providerRepo "bloomify/database/repository/provider"
 	recordsRepo "bloomify/database/repository/records"
 	schedulerRepo "bloomify/database/repository/scheduler"
@@ -19,6 +20,7 @@ type DefaultProviderService struct {
 	RecordsRepo   recordsRepo.HistoricalRecordRepository
 	AsynqClient   *asynq.Client
 	SchedulerRepo schedulerRepo.SchedulerRepository
+	Inbox         notificationRepo.NotificationRepository // notifications and reminders
 }
 
 func NewDefaultProviderService(
@@ -46,7 +48,7 @@ type ProviderService interface {
 	RegisterBasic(basicReq models.ProviderBasicRegistrationData, device models.Device) (sessionID string, status int, err error)
 	VerifyOTP(sessionID string, deviceID string, providedOTP string) (status int, err error)
 	VerifyKYP(sessionID string, kypData models.KYPVerificationData) (status int, err error)
-	FinalizeRegistration(sessionID string, catalogueData models.ServiceCatalogue) (*models.ProviderAuthResponse, error)
+	FinalizeRegistration(sessionID string, catalogues []models.ServiceCatalogue) (*models.ProviderAuthResponse, error)
 
 	// Authentication
 	InitiateProviderAuthentication(email, method, password string, currentDevice models.Device) (*models.ProviderAuthResponse, string, int, error)
@@ -62,6 +64,11 @@ type ProviderService interface {
 	DeleteProvider(id string) error
 	AdvanceVerifyProvider(c context.Context, id string, advReq AdvanceVerifyRequest, fullAccess bool) (*models.Provider, error)
 
+	// Service Catalogue Management
+	AddCatalogueEntry(c context.Context, providerID string, entry models.ServiceCatalogue) (*models.Provider, error)
+	UpdateCatalogueEntry(c context.Context, providerID, catalogueID string, entry models.ServiceCatalogue) (*models.Provider, error)
+	RemoveCatalogueEntry(c context.Context, providerID, catalogueID string) (*models.Provider, error)
+
 	// Timeslot Management
 	SetupTimeslots(c context.Context, providerID string, req models.SetupTimeslotsRequest) (*models.ProviderTimeslotDTO, error)
 	GetTimeslots(c context.Context, providerID, date string) ([]models.TimeSlot, error)
@@ -73,6 +80,8 @@ type ProviderService interface {
 	GetAllProviders() ([]models.Provider, error)
 	GetProviderDevices(providerID string) ([]models.Device, error)
 	SignOutOtherDevices(providerID, currentDeviceID string) error
+	RegisterFCMToken(c context.Context, providerID, deviceID, token string) (*models.Provider, error)
+	RemoveFCMTokens(providerID string, tokens []string) error
 	ResetPassword(email, providedOTP, newPassword, providedSessionID string) error
 
 	// Subscription Management
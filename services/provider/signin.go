@@ -122,7 +122,7 @@ func (s *DefaultProviderService) InitiateProviderAuthentication(email, method, p
 		return nil, "", 0, fmt.Errorf("failed to create auth session: %w", err)
 	}
 
-	if err := utils.InitiateDeviceOTP(provider.ID, currentDevice.DeviceID, provider.Profile.PhoneNumber); err != nil {
+	if err := utils.InitiateDeviceOTP(provider.ID, currentDevice.DeviceID, provider.Profile.PhoneNumber, provider.PreferredLanguage); err != nil {
 		return nil, "", 0, fmt.Errorf("failed to initiate OTP: %w", err)
 	}
 
@@ -236,10 +236,11 @@ func (s *DefaultProviderService) completeProviderAuthentication(providerId strin
 	_ = utils.DeleteAuthSession(sessionClient, sessionID)
 
 	return &models.ProviderAuthResponse{
-		ID:               provider.ID,
-		Token:            token,
-		Profile:          provider.Profile,
-		CreatedAt:        provider.CreatedAt,
-		ServiceCatalogue: provider.ServiceCatalogue,
+		ID:                provider.ID,
+		Token:             token,
+		Profile:           provider.Profile,
+		CreatedAt:         provider.CreatedAt,
+		ServiceCatalogue:  provider.ServiceCatalogue,
+		ServiceCatalogues: provider.ServiceCatalogues,
 	}, nil
 }
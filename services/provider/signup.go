@@ -30,7 +30,7 @@ func (s *DefaultProviderService) RegisterBasic(basicReq models.ProviderBasicRegi
 	sessionID := GenerateSessionID()
 	now := time.Now()
 
-	if err := utils.InitiateDeviceOTP(sessionID, device.DeviceID, basicReq.PhoneNumber); err != nil {
+	if err := utils.InitiateDeviceOTP(sessionID, device.DeviceID, basicReq.PhoneNumber, utils.DefaultLocale); err != nil {
 		return "", 0, fmt.Errorf("failed to initiate OTP: %w", err)
 	}
 
@@ -114,7 +114,8 @@ func (s *DefaultProviderService) VerifyKYP(sessionID string, kypData models.KYPV
 // It retrieves the registration session, updates it with service catalogue details,
 // converts it into a full Provider model, generates a JWT token (using your utils functions),
 // updates the device's token hash, persists the Provider record, and clears the session.
-func (s *DefaultProviderService) FinalizeRegistration(sessionID string, catalogueData models.ServiceCatalogue) (*models.ProviderAuthResponse, error) {
+// The first catalogue entry becomes the provider's primary entry.
+func (s *DefaultProviderService) FinalizeRegistration(sessionID string, catalogues []models.ServiceCatalogue) (*models.ProviderAuthResponse, error) {
 	authCacheClient := utils.GetProviderAuthCacheClient()
 
 	session, err := GetRegistrationSession(authCacheClient, sessionID)
@@ -122,12 +123,17 @@ func (s *DefaultProviderService) FinalizeRegistration(sessionID string, catalogu
 		return nil, fmt.Errorf("failed to retrieve registration session: %w", err)
 	}
 
-	if catalogueData.Service.ID == "" || catalogueData.Mode == "" {
-		return nil, fmt.Errorf("service type and mode are required")
+	if len(catalogues) == 0 {
+		return nil, fmt.Errorf("at least one service catalogue entry is required")
+	}
+	catalogues, err = normalizeCatalogues(catalogues)
+	if err != nil {
+		return nil, err
 	}
 
 	// Update session with the service catalogue details.
-	session.ServiceCatalogue = catalogueData
+	session.ServiceCatalogue = catalogues[0]
+	session.ServiceCatalogues = catalogues
 	session.LastUpdatedAt = time.Now()
 
 	// Directly build the Provider model from the session.
@@ -145,7 +151,8 @@ func (s *DefaultProviderService) FinalizeRegistration(sessionID string, catalogu
 			Rating:       3.0,
 			Description:  session.BasicData.Description,
 		},
-		ServiceCatalogue: session.ServiceCatalogue,
+		ServiceCatalogue:  session.ServiceCatalogue,
+		ServiceCatalogues: session.ServiceCatalogues,
 		BasicVerification: models.BasicVerification{
 			LegalName:          session.KYPData.LegalName,
 			KYPDocument:        session.KYPData.DocumentURL,
@@ -199,11 +206,12 @@ func (s *DefaultProviderService) FinalizeRegistration(sessionID string, catalogu
 
 	// Build and return the authentication response.
 	resp := &models.ProviderAuthResponse{
-		ID:               provider.ID,
-		Token:            token,
-		Profile:          provider.Profile,
-		CreatedAt:        provider.CreatedAt,
-		ServiceCatalogue: provider.ServiceCatalogue,
+		ID:                provider.ID,
+		Token:             token,
+		Profile:           provider.Profile,
+		CreatedAt:         provider.CreatedAt,
+		ServiceCatalogue:  provider.ServiceCatalogue,
+		ServiceCatalogues: provider.ServiceCatalogues,
 	}
 	return resp, nil
 }
@@ -113,6 +113,18 @@ func validateSlotStructure(slot models.TimeSlot, provider models.Provider, weekI
 		return fmt.Errorf("week %d, slot %d: CapacityByUnit requires capacity >= 1", weekIdx+1, slotIdx+1)
 	}
 
+	if slot.CatalogueID != "" {
+		if _, ok := provider.ResolveCatalogue(slot.CatalogueID, "", ""); !ok {
+			return fmt.Errorf("week %d, slot %d: unknown catalogue entry %q", weekIdx+1, slotIdx+1, slot.CatalogueID)
+		}
+	}
+
+	if slot.SlotModel == "earlybird" && slot.EarlyBird != nil {
+		if err := slot.EarlyBird.Validate(); err != nil {
+			return fmt.Errorf("week %d, slot %d: %w", weekIdx+1, slotIdx+1, err)
+		}
+	}
+
 	if _, ok := getRemainingUnits(slot, provider); !ok {
 		return fmt.Errorf("week %d, slot %d: invalid slot configuration", weekIdx+1, slotIdx+1)
 	}
@@ -145,6 +157,12 @@ func getRemainingUnits(ts models.TimeSlot, provider models.Provider) (int, bool)
 			return normal, true
 		case "earlybird", "flatrate":
 			return ts.Capacity, true
+		case "dynamic":
+			d := ts.Dynamic
+			if d == nil || d.MinMultiplier <= 0 || d.MaxMultiplier < d.MinMultiplier {
+				return 0, false
+			}
+			return ts.Capacity, true
 		}
 	}
 	return 0, false
@@ -24,12 +24,16 @@ func (s *DefaultProviderService) UpdateProvider(c context.Context, id string, up
 	updateFields := bson.M{}
 	hasNotificationUpdates := false
 
-	// Handle notification updates first if present
-	if markRead, ok := updates["markNotificationsRead"]; ok {
+	// Handle inbox updates first if present
+	for _, key := range []string{"markNotificationsRead", "removeNotifications"} {
+		raw, ok := updates[key]
+		if !ok {
+			continue
+		}
 		var notificationIDs []string
 
 		// Handle both []string and []interface{} input types
-		switch v := markRead.(type) {
+		switch v := raw.(type) {
 		case []string:
 			notificationIDs = v
 		case []interface{}:
@@ -41,12 +45,17 @@ func (s *DefaultProviderService) UpdateProvider(c context.Context, id string, up
 		}
 
 		if len(notificationIDs) > 0 {
-			err := s.Repo.MarkNotificationsAsRead(id, notificationIDs)
+			var err error
+			if key == "markNotificationsRead" {
+				_, err = s.Inbox.MarkNotificationsAsRead(c, id, notificationIDs)
+			} else {
+				_, err = s.Inbox.RemoveNotifications(c, id, notificationIDs)
+			}
 			if err != nil {
-				return nil, fmt.Errorf("failed to mark notifications as read: %w", err)
+				return nil, fmt.Errorf("failed to update notifications: %w", err)
 			}
 			hasNotificationUpdates = true
-			delete(updates, "markNotificationsRead")
+			delete(updates, key)
 		}
 	}
 
@@ -70,13 +79,41 @@ func (s *DefaultProviderService) UpdateProvider(c context.Context, id string, up
 		updateFields["profile.status"] = v
 		existing.Profile.Status = v
 	}
+	// Deposit plans: share of card bookings taken at checkout, and how many hours
+	// before the service the balance is charged. A zero percent turns deposits off.
+	if v, ok := updates["depositPercent"].(float64); ok {
+		if v < 0 || v >= 100 {
+			return nil, fmt.Errorf("depositPercent must be between 0 and 100")
+		}
+		updateFields["paymentDetails.depositPercent"] = v
+		existing.PaymentDetails.DepositPercent = v
+	}
+	if v, ok := updates["balanceDueHours"].(float64); ok {
+		if v < 0 {
+			return nil, fmt.Errorf("balanceDueHours must not be negative")
+		}
+		updateFields["paymentDetails.balanceDueHours"] = int(v)
+		existing.PaymentDetails.BalanceDueHours = int(v)
+	}
+	if v, ok := updates["preferredLanguage"].(string); ok {
+		if _, supported := utils.Locales[v]; !supported {
+			return nil, fmt.Errorf("unsupported preferredLanguage %q", v)
+		}
+		updateFields["preferredLanguage"] = v
+		existing.PreferredLanguage = v
+	}
+	// serviceType, mode and customOptions edit the primary catalogue entry;
+	// additional entries are managed through the catalogue endpoints.
+	catalogueChanged := false
 	if v, ok := updates["serviceType"].(string); ok && v != "" {
-		updateFields["serviceCatalogue.serviceType"] = v
+		updateFields["serviceCatalogue.service.id"] = v
 		existing.ServiceCatalogue.Service.ID = v
+		catalogueChanged = true
 	}
 	if v, ok := updates["mode"].(string); ok && v != "" {
 		updateFields["serviceCatalogue.mode"] = v
 		existing.ServiceCatalogue.Mode = v
+		catalogueChanged = true
 	}
 	if v, ok := updates["customOptions"]; ok {
 		if opts, ok := v.(map[string]interface{}); ok {
@@ -98,8 +135,13 @@ func (s *DefaultProviderService) UpdateProvider(c context.Context, id string, up
 			}
 			updateFields["serviceCatalogue.customOptions"] = newOpts
 			existing.ServiceCatalogue.CustomOptions = newOpts
+			catalogueChanged = true
 		}
 	}
+	if catalogueChanged && len(existing.ServiceCatalogues) > 0 {
+		existing.ServiceCatalogues[0] = existing.ServiceCatalogue
+		updateFields["serviceCatalogues.0"] = existing.ServiceCatalogue
+	}
 
 	if geo, ok := updates["locationGeo"].(map[string]any); ok {
 		if t, ok := geo["type"].(string); ok && t == "Point" {
@@ -179,18 +221,15 @@ func (s *DefaultProviderService) UpdateProvider(c context.Context, id string, up
 				reminders = append(reminders, reminder)
 			}
 
-			updateFields["reminders"] = reminders
-			existing.Reminders = reminders
+			if err := s.Inbox.SaveReminders(c, "provider", id, reminders); err != nil {
+				return nil, fmt.Errorf("failed to save reminders: %w", err)
+			}
+			hasNotificationUpdates = true
 		} else {
 			return nil, fmt.Errorf("expected reminders to be []any, got: %T", v)
 		}
 	}
 
-	if v, ok := updates["fcmToken"].(string); ok && v != "" {
-		updateFields["security.fcmToken"] = v
-		existing.Security.FCMToken = v
-	}
-
 	updateFields["updatedAt"] = time.Now()
 	existing.UpdatedAt = time.Now()
 
@@ -84,11 +84,12 @@ func (s *DefaultProviderService) RevokeProviderAuthToken(providerID, deviceID st
 		return fmt.Errorf("provider not found")
 	}
 
-	// Clear the token hash for the specified device.
+	// Clear the token hash and push token for the specified device.
 	deviceFound := false
 	for i, d := range provider.Devices {
 		if d.DeviceID == deviceID {
 			provider.Devices[i].TokenHash = ""
+			provider.Devices[i].FCMToken = ""
 			deviceFound = true
 			break
 		}
@@ -0,0 +1,169 @@
+package relay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"bloomify/config"
+	"bloomify/models"
+)
+
+// SessionRequest asks for a pair of proxy numbers connecting a booking's user
+// and provider until ExpiresAt.
+type SessionRequest struct {
+	BookingID      string
+	UserNumber     string
+	ProviderNumber string
+	ExpiresAt      time.Time
+}
+
+// NumberProxy leases temporary numbers that forward calls between a booking's
+// two parties, so neither sees the other's personal number.
+type NumberProxy interface {
+	OpenSession(ctx context.Context, req SessionRequest) (*models.CallRelay, error)
+}
+
+// NewRelayFromConfig returns the relay configured in the environment:
+// CALL_RELAY_TRANSPORT=fake gives a FakeRelay, CALL_RELAY_API_URL an
+// HTTPRelay. It returns nil when neither is set.
+func NewRelayFromConfig() NumberProxy {
+	cfg := config.AppConfig
+	if cfg.CallRelayTransport == "fake" {
+		return NewFakeRelay()
+	}
+	if cfg.CallRelayAPIURL == "" {
+		return nil
+	}
+	return &HTTPRelay{
+		Endpoint: cfg.CallRelayAPIURL,
+		APIKey:   cfg.CallRelayAPIKey,
+		Client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// HTTPRelay opens sessions with a call-masking provider over HTTP. It posts
+// {"reference", "userNumber", "providerNumber", "expiresAt"} as JSON with a
+// bearer API key and expects {"id", "userProxy", "providerProxy"} back.
+type HTTPRelay struct {
+	Endpoint string
+	APIKey   string
+	Client   *http.Client
+}
+
+func (r *HTTPRelay) OpenSession(ctx context.Context, sr SessionRequest) (*models.CallRelay, error) {
+	payload, err := json.Marshal(map[string]string{
+		"reference":      sr.BookingID,
+		"userNumber":     sr.UserNumber,
+		"providerNumber": sr.ProviderNumber,
+		"expiresAt":      sr.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode relay session: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build relay request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+r.APIKey)
+	}
+
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("call relay request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("call relay returned %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	var result struct {
+		ID            string `json:"id"`
+		UserProxy     string `json:"userProxy"`
+		ProviderProxy string `json:"providerProxy"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode relay session: %w", err)
+	}
+	if result.UserProxy == "" || result.ProviderProxy == "" {
+		return nil, errors.New("call relay returned no proxy numbers")
+	}
+	return &models.CallRelay{
+		SessionID:     result.ID,
+		UserProxy:     result.UserProxy,
+		ProviderProxy: result.ProviderProxy,
+		ExpiresAt:     sr.ExpiresAt,
+	}, nil
+}
+
+// FakeRelay hands out made-up proxy numbers and remembers where they lead, for
+// tests and local development. Nothing is dialled.
+type FakeRelay struct {
+	mu       sync.Mutex
+	sessions []FakeSession
+}
+
+// FakeSession is a session opened on a FakeRelay.
+type FakeSession struct {
+	Request SessionRequest
+	Relay   models.CallRelay
+}
+
+func NewFakeRelay() *FakeRelay {
+	return &FakeRelay{}
+}
+
+func (r *FakeRelay) OpenSession(ctx context.Context, sr SessionRequest) (*models.CallRelay, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := len(r.sessions) + 1
+	cr := models.CallRelay{
+		SessionID:     fmt.Sprintf("fake-%d", n),
+		UserProxy:     fmt.Sprintf("+15550%05d", 2*n-1),
+		ProviderProxy: fmt.Sprintf("+15550%05d", 2*n),
+		ExpiresAt:     sr.ExpiresAt,
+	}
+	r.sessions = append(r.sessions, FakeSession{Request: sr, Relay: cr})
+	return &cr, nil
+}
+
+// Sessions returns a copy of the sessions opened so far.
+func (r *FakeRelay) Sessions() []FakeSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]FakeSession(nil), r.sessions...)
+}
+
+// Resolve returns the personal number a call to proxy would ring, if the
+// session is still open.
+func (r *FakeRelay) Resolve(proxy string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, s := range r.sessions {
+		if now.After(s.Relay.ExpiresAt) {
+			continue
+		}
+		switch proxy {
+		case s.Relay.UserProxy:
+			return s.Request.ProviderNumber, true
+		case s.Relay.ProviderProxy:
+			return s.Request.UserNumber, true
+		}
+	}
+	return "", false
+}
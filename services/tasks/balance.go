@@ -0,0 +1,32 @@
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+const TypeChargeBalance = "payment:balance"
+
+// BalanceChargePayload identifies the deposit booking whose balance is due.
+type BalanceChargePayload struct {
+	BookingID string `json:"bookingId"`
+}
+
+// NewBalanceChargeTask schedules the balance charge of a booking at dueAt. The
+// task ID is derived from the booking, so a booking is only scheduled once.
+func NewBalanceChargeTask(bookingID string, dueAt time.Time, maxRetry int) (*asynq.Task, []asynq.Option, error) {
+	b, err := json.Marshal(BalanceChargePayload{BookingID: bookingID})
+	if err != nil {
+		return nil, nil, err
+	}
+	task := asynq.NewTask(TypeChargeBalance, b)
+	opts := []asynq.Option{
+		asynq.ProcessAt(dueAt),
+		asynq.MaxRetry(maxRetry),
+		asynq.TaskID("balance:" + bookingID),
+	}
+
+	return task, opts, nil
+}
@@ -0,0 +1,57 @@
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TypeNoShowCheck  = "booking:noshow_check"
+	TypeNoShowRefund = "booking:noshow_refund"
+)
+
+// NoShowPayload identifies the booking a no-show task acts on.
+type NoShowPayload struct {
+	BookingID string `json:"bookingId"`
+}
+
+// NoShowCheckTaskID identifies the no-show check of a booking, so it can be
+// deleted when the booking moves or is cancelled.
+func NoShowCheckTaskID(bookingID string) string {
+	return "noshow:" + bookingID
+}
+
+// NewNoShowCheckTask schedules the provider no-show check of a booking at
+// checkAt, once per booking.
+func NewNoShowCheckTask(bookingID string, checkAt time.Time) (*asynq.Task, []asynq.Option, error) {
+	b, err := json.Marshal(NoShowPayload{BookingID: bookingID})
+	if err != nil {
+		return nil, nil, err
+	}
+	task := asynq.NewTask(TypeNoShowCheck, b)
+	opts := []asynq.Option{
+		asynq.ProcessAt(checkAt),
+		asynq.TaskID(NoShowCheckTaskID(bookingID)),
+	}
+
+	return task, opts, nil
+}
+
+// NewNoShowRefundTask schedules the automatic refund of a provider no-show the
+// user has not resolved by refundAt.
+func NewNoShowRefundTask(bookingID string, refundAt time.Time) (*asynq.Task, []asynq.Option, error) {
+	b, err := json.Marshal(NoShowPayload{BookingID: bookingID})
+	if err != nil {
+		return nil, nil, err
+	}
+	task := asynq.NewTask(TypeNoShowRefund, b)
+	opts := []asynq.Option{
+		asynq.ProcessAt(refundAt),
+		asynq.MaxRetry(5),
+		asynq.TaskID("noshow-refund:" + bookingID),
+	}
+
+	return task, opts, nil
+}
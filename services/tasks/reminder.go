@@ -20,3 +20,19 @@ func NewReminderTask(payload models.ReminderPayload, fireAt time.Time) (*asynq.T
 
 	return task, opts, nil
 }
+
+// BookingReminderTaskID identifies one reminder of a booking, so it can be
+// replaced or deleted when the booking moves or is cancelled.
+func BookingReminderTaskID(bookingID, target, label string) string {
+	return "reminder:" + bookingID + ":" + target + ":" + label
+}
+
+// NewBookingReminderTask schedules a booking reminder under its task ID.
+func NewBookingReminderTask(payload models.ReminderPayload, fireAt time.Time, label string) (*asynq.Task, []asynq.Option, error) {
+	task, opts, err := NewReminderTask(payload, fireAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts = append(opts, asynq.TaskID(BookingReminderTaskID(payload.BookingID, payload.Target, label)))
+	return task, opts, nil
+}
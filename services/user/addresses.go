@@ -0,0 +1,144 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"bloomify/models"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const maxSavedAddresses = 20
+
+func validateSavedAddress(addr models.SavedAddress) error {
+	if strings.TrimSpace(addr.Label) == "" {
+		return fmt.Errorf("address label is required")
+	}
+	if addr.Location.Type != "Point" || len(addr.Location.Coordinates) != 2 {
+		return fmt.Errorf("address location must be a Point with 2 coordinates")
+	}
+	return nil
+}
+
+// saveAddresses persists the address book, keeping exactly one default entry.
+func (s *DefaultUserService) saveAddresses(userID string, addresses []models.SavedAddress, defaultID string) error {
+	if defaultID == "" {
+		for _, a := range addresses {
+			if a.IsDefault {
+				defaultID = a.ID
+				break
+			}
+		}
+	}
+	if defaultID == "" && len(addresses) > 0 {
+		defaultID = addresses[0].ID
+	}
+	for i := range addresses {
+		addresses[i].IsDefault = addresses[i].ID == defaultID
+	}
+
+	updateDoc := bson.M{
+		"addresses": addresses,
+		"updatedAt": time.Now(),
+	}
+	if err := s.Repo.UpdateSetDocument(userID, updateDoc); err != nil {
+		return fmt.Errorf("failed to update addresses: %w", err)
+	}
+	return nil
+}
+
+// GetAddresses returns the user's address book.
+func (s *DefaultUserService) GetAddresses(userID string) ([]models.SavedAddress, error) {
+	user, err := s.Repo.GetByIDWithProjection(userID, bson.M{"addresses": 1})
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	if user.Addresses == nil {
+		return []models.SavedAddress{}, nil
+	}
+	return user.Addresses, nil
+}
+
+// AddAddress saves a new address. The first address becomes the default.
+func (s *DefaultUserService) AddAddress(userID string, addr models.SavedAddress) (*models.SavedAddress, error) {
+	if err := validateSavedAddress(addr); err != nil {
+		return nil, err
+	}
+	addresses, err := s.GetAddresses(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(addresses) >= maxSavedAddresses {
+		return nil, fmt.Errorf("you can save at most %d addresses", maxSavedAddresses)
+	}
+
+	now := time.Now()
+	addr.ID = uuid.New().String()
+	addr.CreatedAt = now
+	addr.UpdatedAt = now
+
+	defaultID := ""
+	if addr.IsDefault {
+		defaultID = addr.ID
+	}
+	addresses = append(addresses, addr)
+	if err := s.saveAddresses(userID, addresses, defaultID); err != nil {
+		return nil, err
+	}
+	return &addresses[len(addresses)-1], nil
+}
+
+// UpdateAddress replaces a saved address. Bookings already made keep their snapshot.
+func (s *DefaultUserService) UpdateAddress(userID, addressID string, addr models.SavedAddress) (*models.SavedAddress, error) {
+	if err := validateSavedAddress(addr); err != nil {
+		return nil, err
+	}
+	addresses, err := s.GetAddresses(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range addresses {
+		if addresses[i].ID != addressID {
+			continue
+		}
+		addr.ID = addressID
+		addr.CreatedAt = addresses[i].CreatedAt
+		addr.UpdatedAt = time.Now()
+		defaultID := ""
+		if addr.IsDefault {
+			defaultID = addressID
+		} else if addresses[i].IsDefault {
+			// Keep the current default unless another entry is chosen explicitly.
+			addr.IsDefault = true
+		}
+		addresses[i] = addr
+		if err := s.saveAddresses(userID, addresses, defaultID); err != nil {
+			return nil, err
+		}
+		return &addresses[i], nil
+	}
+	return nil, fmt.Errorf("address %s not found", addressID)
+}
+
+// RemoveAddress deletes a saved address.
+func (s *DefaultUserService) RemoveAddress(userID, addressID string) error {
+	addresses, err := s.GetAddresses(userID)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]models.SavedAddress, 0, len(addresses))
+	for _, a := range addresses {
+		if a.ID != addressID {
+			remaining = append(remaining, a)
+		}
+	}
+	if len(remaining) == len(addresses) {
+		return fmt.Errorf("address %s not found", addressID)
+	}
+	return s.saveAddresses(userID, remaining, "")
+}
@@ -51,11 +51,12 @@ func (s *DefaultUserService) RevokeUserAuthToken(userID, deviceID string) error
 		return fmt.Errorf("user not found")
 	}
 
-	// Clear the token hash for the specified device.
+	// Clear the token hash and push token for the specified device.
 	deviceFound := false
 	for i, d := range user.Devices {
 		if d.DeviceID == deviceID {
 			user.Devices[i].TokenHash = ""
+			user.Devices[i].FCMToken = ""
 			deviceFound = true
 			break
 		}
@@ -3,6 +3,9 @@ package user
 import (
 	"bloomify/models"
 	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func (s *DefaultUserService) GetUserDevices(userID string) ([]models.Device, error) {
@@ -16,6 +19,8 @@ func (s *DefaultUserService) GetUserDevices(userID string) ([]models.Device, err
 	return user.Devices, nil
 }
 
+// SignOutOtherDevices retains only the current device. The push tokens of the
+// others go with them, as does the account-wide token, which may belong to one.
 func (s *DefaultUserService) SignOutOtherDevices(userID, currentDeviceID string) error {
 	user, err := s.Repo.GetByIDWithProjection(userID, nil)
 	if err != nil {
@@ -31,11 +36,39 @@ func (s *DefaultUserService) SignOutOtherDevices(userID, currentDeviceID string)
 			filteredDevices = append(filteredDevices, device)
 		}
 	}
-	user.Devices = filteredDevices
 
-	if err := s.Repo.Update(user); err != nil {
+	updateDoc := bson.M{
+		"devices":   filteredDevices,
+		"fcmToken":  "",
+		"updatedAt": time.Now(),
+	}
+	if err := s.Repo.UpdateSetDocument(userID, updateDoc); err != nil {
 		return fmt.Errorf("failed to update user devices: %w", err)
 	}
 
 	return nil
 }
+
+// RegisterFCMToken stores the push token of one of the user's devices. A token
+// that moved from another device, e.g. after a restore, is removed there.
+func (s *DefaultUserService) RegisterFCMToken(userID, deviceID, token string) (*models.User, error) {
+	if err := s.Repo.SetDeviceFCMToken(userID, deviceID, token); err != nil {
+		return nil, fmt.Errorf("failed to update user devices: %w", err)
+	}
+	user, err := s.Repo.GetByIDWithProjection(userID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	return user, nil
+}
+
+// RemoveFCMTokens forgets push tokens FCM no longer accepts.
+func (s *DefaultUserService) RemoveFCMTokens(userID string, tokens []string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	return s.Repo.ClearFCMTokens(userID, tokens)
+}
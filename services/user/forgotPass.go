@@ -59,7 +59,7 @@ func (s *DefaultUserService) ResetPassword(email, providedOTP, newPassword, prov
 		otpCacheKey := fmt.Sprintf("otp:%s", sessionID)
 		_, err := sessionClient.Get(ctx, otpCacheKey).Result()
 		if err != nil {
-			if err := utils.InitiateDeviceOTP(userRec.ID, "reset_password", userRec.PhoneNumber); err != nil {
+			if err := utils.InitiateDeviceOTP(userRec.ID, "reset_password", userRec.PhoneNumber, userRec.PreferredLanguage); err != nil {
 				utils.GetLogger().Error("ResetPassword: Failed to initiate OTP", zap.Error(err))
 				return fmt.Errorf("failed to initiate OTP: %w", err)
 			}
@@ -0,0 +1,120 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"bloomify/models"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const maxHouseholdMembers = 10
+
+func validateHouseholdMember(member models.HouseholdMember) error {
+	if strings.TrimSpace(member.Name) == "" {
+		return fmt.Errorf("member name is required")
+	}
+	if strings.TrimSpace(member.Relationship) == "" {
+		return fmt.Errorf("member relationship is required")
+	}
+	if len(member.Location.Coordinates) > 0 && (member.Location.Type != "Point" || len(member.Location.Coordinates) != 2) {
+		return fmt.Errorf("member location must be a Point with 2 coordinates")
+	}
+	return nil
+}
+
+func (s *DefaultUserService) saveHousehold(userID string, household []models.HouseholdMember) error {
+	updateDoc := bson.M{
+		"household": household,
+		"updatedAt": time.Now(),
+	}
+	if err := s.Repo.UpdateSetDocument(userID, updateDoc); err != nil {
+		return fmt.Errorf("failed to update household: %w", err)
+	}
+	return nil
+}
+
+// GetHousehold returns the user's saved household members.
+func (s *DefaultUserService) GetHousehold(userID string) ([]models.HouseholdMember, error) {
+	user, err := s.Repo.GetByIDWithProjection(userID, bson.M{"household": 1})
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	if user.Household == nil {
+		return []models.HouseholdMember{}, nil
+	}
+	return user.Household, nil
+}
+
+// AddHouseholdMember saves a new person the user can book on behalf of.
+func (s *DefaultUserService) AddHouseholdMember(userID string, member models.HouseholdMember) (*models.HouseholdMember, error) {
+	if err := validateHouseholdMember(member); err != nil {
+		return nil, err
+	}
+	household, err := s.GetHousehold(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(household) >= maxHouseholdMembers {
+		return nil, fmt.Errorf("a household can have at most %d members", maxHouseholdMembers)
+	}
+
+	now := time.Now()
+	member.ID = uuid.New().String()
+	member.CreatedAt = now
+	member.UpdatedAt = now
+
+	if err := s.saveHousehold(userID, append(household, member)); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// UpdateHouseholdMember replaces the details of a saved household member.
+func (s *DefaultUserService) UpdateHouseholdMember(userID, memberID string, member models.HouseholdMember) (*models.HouseholdMember, error) {
+	if err := validateHouseholdMember(member); err != nil {
+		return nil, err
+	}
+	household, err := s.GetHousehold(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range household {
+		if household[i].ID != memberID {
+			continue
+		}
+		member.ID = memberID
+		member.CreatedAt = household[i].CreatedAt
+		member.UpdatedAt = time.Now()
+		household[i] = member
+		if err := s.saveHousehold(userID, household); err != nil {
+			return nil, err
+		}
+		return &member, nil
+	}
+	return nil, fmt.Errorf("household member %s not found", memberID)
+}
+
+// RemoveHouseholdMember deletes a saved household member. Existing bookings keep
+// their beneficiary snapshot.
+func (s *DefaultUserService) RemoveHouseholdMember(userID, memberID string) error {
+	household, err := s.GetHousehold(userID)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]models.HouseholdMember, 0, len(household))
+	for _, m := range household {
+		if m.ID != memberID {
+			remaining = append(remaining, m)
+		}
+	}
+	if len(remaining) == len(household) {
+		return fmt.Errorf("household member %s not found", memberID)
+	}
+	return s.saveHousehold(userID, remaining)
+}
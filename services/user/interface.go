@@ -1,6 +1,8 @@
 package user
 
 import (
+	notificationRepo "bloomify/database/repository/notification"
+	promotionRepo "bloomify/database/repository/promotion"
 	userRepo "bloomify/database/repository/user"
 	"bloomify/models"
 	"fmt"
@@ -27,9 +29,26 @@ type UserService interface {
 	RevokeUserAuthToken(userID, deviceID string) error
 	UpdateUserPassword(userID, currentPassword, newPassword, currentDeviceID string) (*models.User, error)
 
+	// Household
+	GetHousehold(userID string) ([]models.HouseholdMember, error)
+	AddHouseholdMember(userID string, member models.HouseholdMember) (*models.HouseholdMember, error)
+	UpdateHouseholdMember(userID, memberID string, member models.HouseholdMember) (*models.HouseholdMember, error)
+	RemoveHouseholdMember(userID, memberID string) error
+
+	// Address Book
+	GetAddresses(userID string) ([]models.SavedAddress, error)
+	AddAddress(userID string, addr models.SavedAddress) (*models.SavedAddress, error)
+	UpdateAddress(userID, addressID string, addr models.SavedAddress) (*models.SavedAddress, error)
+	RemoveAddress(userID, addressID string) error
+
+	// Referrals
+	GetReferral(userID string) (*models.ReferralSummary, error)
+
 	// Device Management
 	GetUserDevices(userID string) ([]models.Device, error)
 	SignOutOtherDevices(userID, currentDeviceID string) error
+	RegisterFCMToken(userID, deviceID, token string) (*models.User, error)
+	RemoveFCMTokens(userID string, tokens []string) error
 
 	// Admin / Utility
 	GetAllUsers() ([]models.User, error)
@@ -39,8 +58,10 @@ type UserService interface {
 
 // DefaultUserService is the production implementation.
 type DefaultUserService struct {
-	Repo        userRepo.UserRepository
-	AsynqClient *asynq.Client
+	Repo          userRepo.UserRepository
+	AsynqClient   *asynq.Client
+	PromotionRepo promotionRepo.PromotionRepository
+	Inbox         notificationRepo.NotificationRepository // notifications and reminders
 }
 
 func NewDefaultUserService(
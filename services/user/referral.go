@@ -0,0 +1,64 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"bloomify/config"
+	promotionRepo "bloomify/database/repository/promotion"
+	"bloomify/models"
+
+	"github.com/google/uuid"
+)
+
+const referralCodeAttempts = 3
+
+// GetReferral returns the user's referral code, issuing one on first use, and
+// the credits they have earned from it.
+func (s *DefaultUserService) GetReferral(userID string) (*models.ReferralSummary, error) {
+	if s.PromotionRepo == nil {
+		return nil, fmt.Errorf("referrals are not available")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	code, err := s.PromotionRepo.GetReferralCodeByOwner(ctx, userID)
+	if errors.Is(err, promotionRepo.ErrPromoNotFound) {
+		code, err = s.issueReferralCode(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	credits, err := s.PromotionRepo.ListReferralCredits(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReferralSummary{Code: code.Code, Credits: credits}, nil
+}
+
+// issueReferralCode creates the user's referral code. The reward is fixed when the
+// code is issued: new users get it off their first booking, and the owner is
+// credited the same amount once that booking goes through.
+func (s *DefaultUserService) issueReferralCode(ctx context.Context, userID string) (*models.PromoCode, error) {
+	var lastErr error
+	for range referralCodeAttempts {
+		code := &models.PromoCode{
+			Code:        "BLOOM" + strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", "")[:6]),
+			Description: "Referral code",
+			Kind:        models.PromoReferral,
+			Value:       config.AppConfig.ReferralRewardAmount,
+			Currency:    strings.ToUpper(config.AppConfig.ReferralRewardCurrency),
+			OwnerID:     userID,
+			Active:      config.AppConfig.ReferralRewardAmount > 0,
+			CreatedBy:   userID,
+		}
+		if lastErr = s.PromotionRepo.CreatePromo(ctx, code); lastErr == nil {
+			return code, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to issue referral code: %w", lastErr)
+}
@@ -112,7 +112,7 @@ func (s *DefaultUserService) InitiateAuthentication(email, method, password stri
 		return nil, "", 0, fmt.Errorf("failed to create auth session: %w", err)
 	}
 
-	if err := utils.InitiateDeviceOTP(userRec.ID, currentDevice.DeviceID, userRec.PhoneNumber); err != nil {
+	if err := utils.InitiateDeviceOTP(userRec.ID, currentDevice.DeviceID, userRec.PhoneNumber, userRec.PreferredLanguage); err != nil {
 		return nil, "", 0, fmt.Errorf("failed to initiate OTP: %w", err)
 	}
 
@@ -61,7 +61,7 @@ func (s *DefaultUserService) InitiateRegistration(basicReq models.UserBasicRegis
 		Devices:       []models.Device{device},
 	}
 
-	if err := utils.InitiateDeviceOTP(basicReq.Email, device.DeviceID, basicReq.PhoneNumber); err != nil {
+	if err := utils.InitiateDeviceOTP(basicReq.Email, device.DeviceID, basicReq.PhoneNumber, utils.DefaultLocale); err != nil {
 		return "", 0, fmt.Errorf("failed to initiate OTP: %w", err)
 	}
 
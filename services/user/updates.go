@@ -38,9 +38,6 @@ func (s *DefaultUserService) UpdateUser(req models.UserUpdateRequest) (*models.U
 	if req.PhoneNumber != nil {
 		setFields["phoneNumber"] = *req.PhoneNumber
 	}
-	if req.FCMToken != nil {
-		setFields["fcmToken"] = *req.FCMToken
-	}
 	if req.ProfileImage != nil {
 		setFields["profileImage"] = *req.ProfileImage
 	}
@@ -59,9 +56,6 @@ func (s *DefaultUserService) UpdateUser(req models.UserUpdateRequest) (*models.U
 	if req.BookingHistory != nil {
 		setFields["bookingHistory"] = *req.BookingHistory
 	}
-	if req.Notifications != nil {
-		setFields["notifications"] = *req.Notifications
-	}
 	if req.LastBookingTime != nil && !req.LastBookingTime.IsZero() {
 		setFields["lastBookingTime"] = *req.LastBookingTime
 	}
@@ -71,6 +65,15 @@ func (s *DefaultUserService) UpdateUser(req models.UserUpdateRequest) (*models.U
 	if req.SafetySettings != nil {
 		setFields["safetySettings"] = *req.SafetySettings
 	}
+	if req.StripeCustomerID != nil {
+		setFields["stripeCustomerId"] = *req.StripeCustomerID
+	}
+	if req.PreferredLanguage != nil {
+		if _, ok := utils.Locales[*req.PreferredLanguage]; !ok {
+			return nil, fmt.Errorf("unsupported preferredLanguage %q", *req.PreferredLanguage)
+		}
+		setFields["preferredLanguage"] = *req.PreferredLanguage
+	}
 
 	// Handle $push / $addToSet
 	if req.TrustedProviders != nil && len(*req.TrustedProviders) > 0 {
@@ -79,7 +82,11 @@ func (s *DefaultUserService) UpdateUser(req models.UserUpdateRequest) (*models.U
 
 	// Handle reminders + scheduling
 	if req.Reminders != nil && len(*req.Reminders) > 0 {
-		pushFields["reminders"] = bson.M{"$each": *req.Reminders}
+		if err := s.Inbox.SaveReminders(context.Background(), "user", *userID, *req.Reminders); err != nil {
+			logger.Error("Failed to save reminders", zap.String("userID", *userID), zap.Error(err))
+			return nil, fmt.Errorf("failed to save reminders: %w", err)
+		}
+		hasNotificationUpdates = true
 
 		for _, reminder := range *req.Reminders {
 			if !reminder.FireDate.IsZero() {
@@ -113,9 +120,9 @@ func (s *DefaultUserService) UpdateUser(req models.UserUpdateRequest) (*models.U
 		}
 	}
 
-	// Handle notification updates first (mark as read)
+	// Handle inbox updates (mark as read, remove)
 	if req.MarkNotificationsRead != nil && len(*req.MarkNotificationsRead) > 0 {
-		err := s.Repo.MarkNotificationsAsRead(*userID, *req.MarkNotificationsRead)
+		_, err := s.Inbox.MarkNotificationsAsRead(context.Background(), *userID, *req.MarkNotificationsRead)
 		if err != nil {
 			logger.Error("Failed to mark notifications as read",
 				zap.String("userID", *userID),
@@ -125,6 +132,16 @@ func (s *DefaultUserService) UpdateUser(req models.UserUpdateRequest) (*models.U
 		}
 		hasNotificationUpdates = true
 	}
+	if req.RemoveNotifications != nil && len(*req.RemoveNotifications) > 0 {
+		if _, err := s.Inbox.RemoveNotifications(context.Background(), *userID, *req.RemoveNotifications); err != nil {
+			logger.Error("Failed to remove notifications",
+				zap.String("userID", *userID),
+				zap.Any("notificationIDs", *req.RemoveNotifications),
+				zap.Error(err))
+			return nil, fmt.Errorf("failed to remove notifications: %w", err)
+		}
+		hasNotificationUpdates = true
+	}
 
 	// Validate
 	if userID == nil || *userID == "" {
@@ -20,8 +20,12 @@ var (
 	AIContextCacheClient    *redis.Client
 	FeedCacheClient         *redis.Client
 	ReminderQueueClient     *asynq.Client
+	ReminderQueueInspector  *asynq.Inspector
 )
 
+// ServiceTaxonomyCacheKey holds the cached service taxonomy on the booking cache.
+const ServiceTaxonomyCacheKey = "services:taxonomy"
+
 // --- Booking Cache ---
 func InitBookingCache() {
 	log.Printf("Attempting to connect to Redis (Booking Cache) at %s using DB %d", config.AppConfig.RedisAddr, config.AppConfig.RedisBookingCacheDB)
@@ -207,6 +211,19 @@ func GetReminderQueueClient() *asynq.Client {
 	return ReminderQueueClient
 }
 
+// GetReminderQueueInspector returns an inspector of the reminder queue, used to
+// delete scheduled tasks by ID.
+func GetReminderQueueInspector() *asynq.Inspector {
+	if ReminderQueueInspector == nil {
+		ReminderQueueInspector = asynq.NewInspector(asynq.RedisClientOpt{
+			Addr:     config.AppConfig.RedisAddr,
+			Password: config.AppConfig.RedisPassword,
+			DB:       config.AppConfig.RedisReminderQueueDB,
+		})
+	}
+	return ReminderQueueInspector
+}
+
 // --- Redis Initialization ---
 func InitRedis() {
 	InitBookingCache()
@@ -1,73 +1,29 @@
 package utils
 
 import (
-	"bloomify/config"
-	"encoding/json"
+	"context"
 	"errors"
-	"fmt"
-	"io"
 	"math"
-	"net/http"
 	"strings"
-	"sync"
-	"time"
-)
-
-type ExchangeRateAPIResponse struct {
-	Result string             `json:"result"`
-	Base   string             `json:"base_code"`
-	Rates  map[string]float64 `json:"conversion_rates"`
-}
-
-var exchangeRateCache sync.Map // key: "FROM->TO", value: float64
-
-const exchangeRateAPIKey = "7b320b62a306046a3c202b48"
-
-func fetchExchangeRate(from, to string) (float64, error) {
-	cacheKey := fmt.Sprintf("%s->%s", from, to)
-
-	if val, ok := exchangeRateCache.Load(cacheKey); ok {
-		return val.(float64), nil
-	}
-
-	url := fmt.Sprintf("https://v6.exchangerate-api.com/v6/%s/latest/%s", exchangeRateAPIKey, from)
-	client := http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Get(url)
-	if err != nil {
-		return 0, fmt.Errorf("HTTP request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var rateResp ExchangeRateAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&rateResp); err != nil {
-		return 0, fmt.Errorf("decoding response failed: %w", err)
-	}
 
-	if rateResp.Result != "success" {
-		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("exchange API returned failure result: status %d, body: %s", resp.StatusCode, string(body))
-	}
-
-	rate, ok := rateResp.Rates[to]
-	if !ok {
-		return 0, fmt.Errorf("exchange rate for %s not found", to)
-	}
+	"bloomify/config"
+	"bloomify/models"
+)
 
-	exchangeRateCache.Store(cacheKey, rate)
-	return rate, nil
+// ConvertCurrency converts amount between currencies using the shared rate service.
+func ConvertCurrency(amount float64, fromCurrency, toCurrency string) (float64, error) {
+	converted, _, err := ConvertCurrencyWithSnapshot(context.Background(), amount, fromCurrency, toCurrency)
+	return converted, err
 }
 
-// ConvertCurrency converts amount between currencies using live rates.
-func ConvertCurrency(amount float64, fromCurrency, toCurrency string) (float64, error) {
-	if fromCurrency == toCurrency {
-		return math.Round(amount*100) / 100, nil
-	}
-	rate, err := fetchExchangeRate(fromCurrency, toCurrency)
+// ConvertCurrencyWithSnapshot converts amount and returns the rate it used,
+// so callers can store it next to the converted price.
+func ConvertCurrencyWithSnapshot(ctx context.Context, amount float64, fromCurrency, toCurrency string) (float64, models.RateSnapshot, error) {
+	snap, err := GetExchangeRates().Snapshot(ctx, fromCurrency, toCurrency)
 	if err != nil {
-		return 0, err
+		return 0, models.RateSnapshot{}, err
 	}
-	converted := amount * rate
-	return math.Round(converted*100) / 100, nil
+	return math.Round(amount*snap.Rate*100) / 100, snap, nil
 }
 
 var TierMultipliers = map[string]float64{
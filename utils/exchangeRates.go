@@ -0,0 +1,380 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"bloomify/config"
+	"bloomify/models"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrRateUnavailable is returned when no current or last-known rate exists for a currency pair.
+var ErrRateUnavailable = errors.New("exchange rate unavailable")
+
+// RateProvider fetches a fresh rate table for a base currency.
+type RateProvider interface {
+	Name() string
+	FetchRates(ctx context.Context, base string) (*models.RateTable, error)
+}
+
+// RateStore persists the last fetched rate table per base currency so that
+// restarts and provider outages fall back to last-known rates.
+type RateStore interface {
+	LoadRates(ctx context.Context, base string) (*models.RateTable, error)
+	SaveRates(ctx context.Context, table *models.RateTable) error
+}
+
+// --- exchangerate-api.com provider ---
+
+type ExchangeRateAPIResponse struct {
+	Result string             `json:"result"`
+	Base   string             `json:"base_code"`
+	Rates  map[string]float64 `json:"conversion_rates"`
+}
+
+// ExchangeRateAPIProvider fetches live rates from exchangerate-api.com.
+type ExchangeRateAPIProvider struct {
+	APIKey string
+	Client *http.Client
+}
+
+func (p *ExchangeRateAPIProvider) Name() string { return "exchangerate-api" }
+
+func (p *ExchangeRateAPIProvider) FetchRates(ctx context.Context, base string) (*models.RateTable, error) {
+	if p.APIKey == "" {
+		return nil, fmt.Errorf("EXCHANGE_RATE_API_KEY is not set")
+	}
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+
+	url := fmt.Sprintf("https://v6.exchangerate-api.com/v6/%s/latest/%s", p.APIKey, base)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response failed: %w", err)
+	}
+	var rateResp ExchangeRateAPIResponse
+	if err := json.Unmarshal(body, &rateResp); err != nil {
+		return nil, fmt.Errorf("decoding response failed: %w", err)
+	}
+	if rateResp.Result != "success" {
+		return nil, fmt.Errorf("exchange API returned failure result: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return &models.RateTable{
+		Base:      base,
+		Rates:     rateResp.Rates,
+		Provider:  p.Name(),
+		FetchedAt: time.Now(),
+	}, nil
+}
+
+// --- static provider ---
+
+// StaticRateProvider serves rates from a fixed table, typically loaded from a
+// JSON file such as {"base": "USD", "rates": {"KES": 129.5, "EUR": 0.92}}.
+// Tables for other bases are derived by cross rates.
+type StaticRateProvider struct {
+	Table models.RateTable
+}
+
+// NewStaticRateProviderFromFile loads a static rate table from path.
+func NewStaticRateProviderFromFile(path string) (*StaticRateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate file: %w", err)
+	}
+	var table models.RateTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("failed to parse rate file: %w", err)
+	}
+	table.Base = strings.ToUpper(table.Base)
+	if table.Base == "" || len(table.Rates) == 0 {
+		return nil, fmt.Errorf("rate file %s needs a base and rates", path)
+	}
+	table.Rates[table.Base] = 1
+	if table.FetchedAt.IsZero() {
+		if info, err := os.Stat(path); err == nil {
+			table.FetchedAt = info.ModTime()
+		}
+	}
+	return &StaticRateProvider{Table: table}, nil
+}
+
+func (p *StaticRateProvider) Name() string { return "static" }
+
+func (p *StaticRateProvider) FetchRates(_ context.Context, base string) (*models.RateTable, error) {
+	pivot, ok := p.Table.Rates[base]
+	if !ok || pivot == 0 {
+		return nil, fmt.Errorf("%w: no static rate for %s", ErrRateUnavailable, base)
+	}
+	rates := make(map[string]float64, len(p.Table.Rates))
+	for code, r := range p.Table.Rates {
+		rates[code] = r / pivot
+	}
+	return &models.RateTable{
+		Base:      base,
+		Rates:     rates,
+		Provider:  p.Name(),
+		FetchedAt: p.Table.FetchedAt,
+	}, nil
+}
+
+// --- Redis store ---
+
+const exchangeRateCacheKeyPrefix = "fx:rates:"
+
+// RedisRateStore keeps rate tables in Redis without expiry; freshness is judged
+// by FetchedAt against the configured TTL.
+type RedisRateStore struct {
+	Client *redis.Client
+}
+
+func (s *RedisRateStore) LoadRates(ctx context.Context, base string) (*models.RateTable, error) {
+	raw, err := s.Client.Get(ctx, exchangeRateCacheKeyPrefix+base).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var table models.RateTable
+	if err := json.Unmarshal(raw, &table); err != nil {
+		return nil, fmt.Errorf("failed to decode stored rates: %w", err)
+	}
+	return &table, nil
+}
+
+func (s *RedisRateStore) SaveRates(ctx context.Context, table *models.RateTable) error {
+	raw, err := json.Marshal(table)
+	if err != nil {
+		return fmt.Errorf("failed to encode rates: %w", err)
+	}
+	return s.Client.Set(ctx, exchangeRateCacheKeyPrefix+table.Base, raw, 0).Err()
+}
+
+// --- rate service ---
+
+// ExchangeRates resolves conversion rates through a provider, keeping tables
+// in memory and in an optional store. Tables older than TTL are refreshed on
+// use; if the refresh fails the last-known table is used and marked stale, and
+// the refresh is not tried again until RetryBackoff has passed.
+type ExchangeRates struct {
+	Provider     RateProvider
+	Store        RateStore // optional
+	TTL          time.Duration
+	RetryBackoff time.Duration
+
+	mu       sync.RWMutex
+	tables   map[string]*models.RateTable
+	failedAt map[string]time.Time // last failed refresh per base
+}
+
+// NewExchangeRates returns a rate service backed by provider and store.
+func NewExchangeRates(provider RateProvider, store RateStore, ttl time.Duration) *ExchangeRates {
+	return &ExchangeRates{
+		Provider:     provider,
+		Store:        store,
+		TTL:          ttl,
+		RetryBackoff: time.Minute,
+		tables:       make(map[string]*models.RateTable),
+		failedAt:     make(map[string]time.Time),
+	}
+}
+
+// Snapshot returns the rate for converting from into to.
+func (e *ExchangeRates) Snapshot(ctx context.Context, from, to string) (models.RateSnapshot, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return models.RateSnapshot{From: from, To: to, Rate: 1}, nil
+	}
+
+	table, stale, err := e.table(ctx, from)
+	if err != nil {
+		return models.RateSnapshot{}, err
+	}
+	rate, ok := table.Rates[to]
+	if !ok || rate <= 0 {
+		return models.RateSnapshot{}, fmt.Errorf("%w: %s->%s", ErrRateUnavailable, from, to)
+	}
+	return models.RateSnapshot{
+		From:      from,
+		To:        to,
+		Rate:      rate,
+		Provider:  table.Provider,
+		FetchedAt: table.FetchedAt,
+		Stale:     stale,
+	}, nil
+}
+
+// table returns a fresh table for base when possible, else the last-known one.
+func (e *ExchangeRates) table(ctx context.Context, base string) (*models.RateTable, bool, error) {
+	e.mu.RLock()
+	known, failedAt := e.tables[base], e.failedAt[base]
+	e.mu.RUnlock()
+
+	if known == nil && e.Store != nil {
+		if stored, err := e.Store.LoadRates(ctx, base); err == nil {
+			known = stored
+			e.remember(stored)
+		}
+	}
+	if known != nil && !e.expired(known) {
+		return known, false, nil
+	}
+	// While the provider is down, serve the stale table instead of asking again on every call.
+	if known != nil && time.Since(failedAt) < e.RetryBackoff {
+		return known, true, nil
+	}
+
+	fresh, err := e.Refresh(ctx, base)
+	if err == nil {
+		return fresh, false, nil
+	}
+	e.mu.Lock()
+	e.failedAt[base] = time.Now()
+	e.mu.Unlock()
+	if known != nil {
+		log.Printf("[ExchangeRates] refresh of %s failed, using rates from %s: %v", base, known.FetchedAt.Format(time.RFC3339), err)
+		return known, true, nil
+	}
+	return nil, false, fmt.Errorf("%w: %s: %v", ErrRateUnavailable, base, err)
+}
+
+func (e *ExchangeRates) expired(t *models.RateTable) bool {
+	return e.TTL > 0 && time.Since(t.FetchedAt) > e.TTL
+}
+
+func (e *ExchangeRates) remember(t *models.RateTable) {
+	e.mu.Lock()
+	e.tables[t.Base] = t
+	delete(e.failedAt, t.Base)
+	e.mu.Unlock()
+}
+
+// Refresh fetches base from the provider and persists it.
+func (e *ExchangeRates) Refresh(ctx context.Context, base string) (*models.RateTable, error) {
+	base = strings.ToUpper(base)
+	table, err := e.Provider.FetchRates(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	e.remember(table)
+	if e.Store != nil {
+		if err := e.Store.SaveRates(ctx, table); err != nil {
+			log.Printf("[ExchangeRates] failed to persist %s rates: %v", base, err)
+		}
+	}
+	return table, nil
+}
+
+// StartRefresher refreshes every known base (and the given ones) on interval until ctx is done.
+func (e *ExchangeRates) StartRefresher(ctx context.Context, interval time.Duration, bases ...string) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			e.refreshAll(ctx, bases)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (e *ExchangeRates) refreshAll(ctx context.Context, bases []string) {
+	seen := make(map[string]bool)
+	e.mu.RLock()
+	for base := range e.tables {
+		seen[base] = true
+	}
+	e.mu.RUnlock()
+	for _, base := range bases {
+		seen[strings.ToUpper(base)] = true
+	}
+
+	for base := range seen {
+		rctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		if _, err := e.Refresh(rctx, base); err != nil {
+			log.Printf("[ExchangeRates] scheduled refresh of %s failed: %v", base, err)
+		}
+		cancel()
+	}
+}
+
+var (
+	exchangeRates     *ExchangeRates
+	exchangeRatesOnce sync.Once
+)
+
+// NewRateProviderFromConfig builds the provider selected by EXCHANGE_RATE_PROVIDER.
+func NewRateProviderFromConfig() (RateProvider, error) {
+	switch config.AppConfig.ExchangeRateProvider {
+	case "static":
+		return NewStaticRateProviderFromFile(config.AppConfig.ExchangeRateFile)
+	case "api", "":
+		return &ExchangeRateAPIProvider{APIKey: config.AppConfig.ExchangeRateAPIKey}, nil
+	default:
+		return nil, fmt.Errorf("unknown exchange rate provider %q", config.AppConfig.ExchangeRateProvider)
+	}
+}
+
+// InitExchangeRates sets up the shared rate service from config, persisting
+// tables on the booking cache, and starts the scheduled refresh.
+func InitExchangeRates(ctx context.Context) {
+	provider, err := NewRateProviderFromConfig()
+	if err != nil {
+		log.Fatalf("Failed to set up exchange rates: %v", err)
+	}
+	rates := NewExchangeRates(
+		provider,
+		&RedisRateStore{Client: GetBookingCacheClient()},
+		time.Duration(config.AppConfig.ExchangeRateTTLMinutes)*time.Minute,
+	)
+	SetExchangeRates(rates)
+	rates.StartRefresher(ctx, time.Duration(config.AppConfig.ExchangeRateRefreshMinutes)*time.Minute, "USD")
+}
+
+// SetExchangeRates replaces the shared rate service, e.g. with a static provider in tests.
+func SetExchangeRates(e *ExchangeRates) {
+	exchangeRatesOnce.Do(func() {})
+	exchangeRates = e
+}
+
+// GetExchangeRates returns the shared rate service, building an in-memory one
+// from config when InitExchangeRates has not run.
+func GetExchangeRates() *ExchangeRates {
+	exchangeRatesOnce.Do(func() {
+		provider, err := NewRateProviderFromConfig()
+		if err != nil {
+			log.Printf("[ExchangeRates] %v; falling back to exchangerate-api", err)
+			provider = &ExchangeRateAPIProvider{APIKey: config.AppConfig.ExchangeRateAPIKey}
+		}
+		exchangeRates = NewExchangeRates(provider, nil, time.Duration(config.AppConfig.ExchangeRateTTLMinutes)*time.Minute)
+	})
+	return exchangeRates
+}
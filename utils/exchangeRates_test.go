@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bloomify/models"
+)
+
+func newStaticRates(fetchedAt time.Time, rates map[string]float64) (*StaticRateProvider, *ExchangeRates) {
+	p := &StaticRateProvider{Table: models.RateTable{Base: "USD", Rates: rates, FetchedAt: fetchedAt}}
+	return p, NewExchangeRates(p, nil, time.Hour)
+}
+
+// age makes the remembered table for base look fetchedAt old.
+func age(e *ExchangeRates, base string, fetchedAt time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	aged := *e.tables[base]
+	aged.FetchedAt = fetchedAt
+	e.tables[base] = &aged
+}
+
+func TestExchangeRatesFreshSnapshot(t *testing.T) {
+	now := time.Now()
+	_, e := newStaticRates(now, map[string]float64{"USD": 1, "KES": 130, "EUR": 0.5})
+	ctx := context.Background()
+
+	snap, err := e.Snapshot(ctx, "usd", "kes")
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if snap.From != "USD" || snap.To != "KES" || snap.Rate != 130 {
+		t.Errorf("got %+v, want USD->KES at 130", snap)
+	}
+	if snap.Stale || snap.Provider != "static" || !snap.FetchedAt.Equal(now) {
+		t.Errorf("got %+v, want a fresh static snapshot fetched at %v", snap, now)
+	}
+
+	// Other bases are derived by cross rates.
+	snap, err = e.Snapshot(ctx, "EUR", "KES")
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if snap.Rate != 260 {
+		t.Errorf("EUR->KES rate = %v, want 260", snap.Rate)
+	}
+
+	if snap, err := e.Snapshot(ctx, "KES", "kes"); err != nil || snap.Rate != 1 {
+		t.Errorf("same-currency snapshot = %+v, %v; want rate 1", snap, err)
+	}
+	if _, err := e.Snapshot(ctx, "USD", "GBP"); !errors.Is(err, ErrRateUnavailable) {
+		t.Errorf("unknown currency error = %v, want ErrRateUnavailable", err)
+	}
+}
+
+func TestExchangeRatesRefreshesExpiredTable(t *testing.T) {
+	now := time.Now()
+	p, e := newStaticRates(now, map[string]float64{"USD": 1, "KES": 130})
+	ctx := context.Background()
+
+	if _, err := e.Snapshot(ctx, "USD", "KES"); err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+
+	// A new rate within the TTL is not picked up.
+	p.Table.Rates = map[string]float64{"USD": 1, "KES": 128}
+	if snap, _ := e.Snapshot(ctx, "USD", "KES"); snap.Rate != 130 {
+		t.Errorf("rate within TTL = %v, want cached 130", snap.Rate)
+	}
+
+	// Once the table is older than the TTL it is fetched again.
+	age(e, "USD", now.Add(-2*time.Hour))
+	snap, err := e.Snapshot(ctx, "USD", "KES")
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if snap.Rate != 128 || snap.Stale {
+		t.Errorf("got %+v, want a fresh snapshot at 128", snap)
+	}
+}
+
+func TestExchangeRatesFallsBackToStaleTable(t *testing.T) {
+	old := time.Now().Add(-2 * time.Hour)
+	p, e := newStaticRates(time.Now(), map[string]float64{"USD": 1, "KES": 130})
+	ctx := context.Background()
+
+	if _, err := e.Snapshot(ctx, "USD", "KES"); err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	age(e, "USD", old)
+
+	// The provider no longer knows USD, so the refresh fails.
+	p.Table.Rates = map[string]float64{"EUR": 1}
+	snap, err := e.Snapshot(ctx, "USD", "KES")
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if snap.Rate != 130 || !snap.Stale || !snap.FetchedAt.Equal(old) {
+		t.Errorf("got %+v, want the last-known rate 130 marked stale", snap)
+	}
+
+	// Without a last-known table there is nothing to fall back to.
+	if _, err := e.Snapshot(ctx, "KES", "USD"); !errors.Is(err, ErrRateUnavailable) {
+		t.Errorf("error without a known table = %v, want ErrRateUnavailable", err)
+	}
+}
+
+func TestExchangeRatesBacksOffAfterFailedRefresh(t *testing.T) {
+	p, e := newStaticRates(time.Now(), map[string]float64{"USD": 1, "KES": 130})
+	ctx := context.Background()
+
+	if _, err := e.Snapshot(ctx, "USD", "KES"); err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	age(e, "USD", time.Now().Add(-2*time.Hour))
+
+	// The refresh fails and the stale table is served.
+	p.Table.Rates = map[string]float64{"EUR": 1}
+	if snap, err := e.Snapshot(ctx, "USD", "KES"); err != nil || !snap.Stale {
+		t.Fatalf("got %+v, %v; want a stale snapshot", snap, err)
+	}
+
+	// Within the backoff the provider is not asked again, even once it recovers.
+	p.Table.Rates = map[string]float64{"USD": 1, "KES": 128}
+	snap, err := e.Snapshot(ctx, "USD", "KES")
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if snap.Rate != 130 || !snap.Stale {
+		t.Errorf("got %+v, want the stale rate 130 during the backoff", snap)
+	}
+
+	// Once the backoff has passed the refresh is tried again.
+	e.mu.Lock()
+	e.failedAt["USD"] = time.Now().Add(-e.RetryBackoff)
+	e.mu.Unlock()
+	snap, err = e.Snapshot(ctx, "USD", "KES")
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if snap.Rate != 128 || snap.Stale {
+		t.Errorf("got %+v, want a fresh snapshot at 128 after the backoff", snap)
+	}
+}
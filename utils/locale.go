@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// DefaultLocale is used when a recipient has no preferred language, or one
+// without templates.
+const DefaultLocale = "en"
+
+// Locale holds the formatting conventions of a language.
+type Locale struct {
+	Tag            string
+	Decimal        string
+	Group          string
+	Months         [12]string
+	Weekdays       [7]string // Sunday first
+	DateLayout     string    // Go layout with "January" standing in for the month name
+	TimeLayout     string
+	CurrencyBefore bool // "KES 1,200.00" rather than "1 200,00 KES"
+	// One reports whether n takes the singular form.
+	One func(n int) bool
+}
+
+// Locales are the languages notifications can be rendered in.
+var Locales = map[string]Locale{
+	"en": {
+		Tag:            "en",
+		Decimal:        ".",
+		Group:          ",",
+		Months:         [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		Weekdays:       [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		DateLayout:     "2 January",
+		TimeLayout:     "3:04 PM",
+		CurrencyBefore: true,
+		One:            func(n int) bool { return n == 1 },
+	},
+	"sw": {
+		Tag:            "sw",
+		Decimal:        ".",
+		Group:          ",",
+		Months:         [12]string{"Januari", "Februari", "Machi", "Aprili", "Mei", "Juni", "Julai", "Agosti", "Septemba", "Oktoba", "Novemba", "Desemba"},
+		Weekdays:       [7]string{"Jumapili", "Jumatatu", "Jumanne", "Jumatano", "Alhamisi", "Ijumaa", "Jumamosi"},
+		DateLayout:     "2 January",
+		TimeLayout:     "15:04",
+		CurrencyBefore: true,
+		One:            func(n int) bool { return n == 1 },
+	},
+	"fr": {
+		Tag:            "fr",
+		Decimal:        ",",
+		Group:          " ",
+		Months:         [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		Weekdays:       [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		DateLayout:     "2 January",
+		TimeLayout:     "15:04",
+		CurrencyBefore: false,
+		One:            func(n int) bool { return n == 0 || n == 1 },
+	},
+}
+
+// NormalizeLocale maps a language tag such as "sw-KE" or "FR_fr" to a
+// supported locale, falling back to DefaultLocale.
+func NormalizeLocale(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i > 0 {
+		tag = tag[:i]
+	}
+	if _, ok := Locales[tag]; ok {
+		return tag
+	}
+	return DefaultLocale
+}
+
+// LocaleFor returns the conventions of a language tag.
+func LocaleFor(tag string) Locale {
+	return Locales[NormalizeLocale(tag)]
+}
+
+// FormatNumber formats v with the locale's separators and the given decimals.
+func (l Locale) FormatNumber(v float64, decimals int) string {
+	neg := v < 0
+	s := fmt.Sprintf("%.*f", decimals, math.Abs(v))
+	intPart, frac, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteRune(r)
+	}
+	if frac != "" {
+		b.WriteString(l.Decimal)
+		b.WriteString(frac)
+	}
+	if neg {
+		return "-" + b.String()
+	}
+	return b.String()
+}
+
+// FormatMoney formats an amount with its ISO currency code.
+func (l Locale) FormatMoney(amount float64, currency string) string {
+	currency = strings.ToUpper(currency)
+	n := l.FormatNumber(amount, 2)
+	if currency == "" {
+		return n
+	}
+	if l.CurrencyBefore {
+		return currency + " " + n
+	}
+	return n + " " + currency
+}
+
+// FormatDate formats the day and month of t, e.g. "2 January" or "2 Januari".
+func (l Locale) FormatDate(t time.Time) string {
+	return strings.Replace(t.Format(l.DateLayout), t.Month().String(), l.Months[t.Month()-1], 1)
+}
+
+// FormatTime formats the clock time of t.
+func (l Locale) FormatTime(t time.Time) string {
+	return t.Format(l.TimeLayout)
+}
+
+// FormatDateTime formats t as date and time, e.g. "2 January, 3:04 PM".
+func (l Locale) FormatDateTime(t time.Time) string {
+	return l.FormatDate(t) + ", " + l.FormatTime(t)
+}
+
+// Plural picks the singular or plural form for n.
+func (l Locale) Plural(n int, one, other string) string {
+	if l.One != nil && l.One(n) {
+		return one
+	}
+	return other
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"bloomify/config"
+)
+
+// MessagingGateway sends SMS and WhatsApp messages through an HTTP gateway.
+// It posts {"channel", "to", "from", "message"} as JSON with a bearer API key
+// and expects a 2xx response, optionally carrying {"id": "..."}.
+type MessagingGateway struct {
+	Endpoint       string
+	APIKey         string
+	SMSSender      string
+	WhatsAppSender string
+	Client         *http.Client
+}
+
+// NewMessagingGatewayFromConfig returns the configured gateway, or nil when
+// MESSAGING_API_URL is unset.
+func NewMessagingGatewayFromConfig() *MessagingGateway {
+	if config.AppConfig.MessagingAPIURL == "" {
+		return nil
+	}
+	return &MessagingGateway{
+		Endpoint:       config.AppConfig.MessagingAPIURL,
+		APIKey:         config.AppConfig.MessagingAPIKey,
+		SMSSender:      config.AppConfig.SMSSenderID,
+		WhatsAppSender: config.AppConfig.WhatsAppSender,
+		Client:         &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send delivers one message on channel "sms" or "whatsapp" and returns the
+// gateway's message ID, if any.
+func (g *MessagingGateway) Send(ctx context.Context, channel, to, message string) (string, error) {
+	from := g.SMSSender
+	if channel == "whatsapp" {
+		from = g.WhatsAppSender
+	}
+	payload, err := json.Marshal(map[string]string{
+		"channel": channel,
+		"to":      to,
+		"from":    from,
+		"message": message,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s message: %w", channel, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to build %s request: %w", channel, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+g.APIKey)
+	}
+
+	client := g.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s gateway request failed: %w", channel, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("%s gateway returned %d: %s", channel, resp.StatusCode, bytes.TrimSpace(body))
+	}
+	var result struct {
+		ID string `json:"id"`
+	}
+	_ = json.Unmarshal(body, &result)
+	return result.ID, nil
+}
+
+var (
+	messagingGateway     *MessagingGateway
+	messagingGatewayOnce sync.Once
+)
+
+// GetMessagingGateway returns the gateway configured at startup, or nil.
+func GetMessagingGateway() *MessagingGateway {
+	messagingGatewayOnce.Do(func() {
+		messagingGateway = NewMessagingGatewayFromConfig()
+	})
+	return messagingGateway
+}
@@ -0,0 +1,530 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// Notification template types.
+const (
+	TemplateBookingConfirmed = "booking_confirmed"
+	TemplatePaymentRequired  = "payment_required"
+	TemplateNewBooking       = "new_booking"
+	TemplateScheduleUpdate   = "schedule_update"
+	TemplateOTP              = "otp"
+	TemplateBookingReminder  = "booking_reminder"
+	TemplateSafetyReminder   = "safety_reminder"
+	TemplateProviderNoShow   = "provider_no_show"
+	TemplateMissedBooking    = "missed_booking"
+	TemplateCustomerNoShow   = "customer_no_show"
+	TemplateNoShowRefund     = "no_show_refund"
+	TemplateProviderOnTheWay = "provider_on_the_way"
+	TemplateChatMessage      = "chat_message"
+
+	TemplateAdjustmentRequested = "adjustment_requested"
+	TemplateAdjustmentDeclined  = "adjustment_declined"
+	TemplateAdjustmentPaid      = "adjustment_paid"
+	TemplateTipReceived         = "tip_received"
+	TemplateBalanceDeclined     = "balance_declined"
+	TemplateBalanceFailed       = "balance_failed"
+	TemplateBalanceUnpaid       = "balance_unpaid"
+	TemplateBalancePaid         = "balance_paid"
+)
+
+// TemplateParams are the values a notification template can use. Templates are
+// Go text/template with the functions money, date, time, datetime, plural,
+// weekdays and join, all formatting for the template's locale.
+type TemplateParams struct {
+	ProviderName  string    `json:"providerName,omitempty"`
+	CustomerName  string    `json:"customerName,omitempty"`
+	When          time.Time `json:"when,omitzero"`
+	Until         time.Time `json:"until,omitzero"`
+	Amount        float64   `json:"amount,omitempty"`
+	Currency      string    `json:"currency,omitempty"`
+	PaymentMethod string    `json:"paymentMethod,omitempty"` // "cash" or "card"
+	Count         int       `json:"count,omitempty"`
+	Units         string    `json:"units,omitempty"`
+	Days          []string  `json:"days,omitempty"` // weekday abbreviations, "Mon" to "Sun"
+	StartDate     string    `json:"startDate,omitempty"`
+	Code          string    `json:"code,omitempty"`
+	Minutes       int       `json:"minutes,omitempty"`
+	Mode          string    `json:"mode,omitempty"` // "in_home" or "in_store"
+	Sender        string    `json:"sender,omitempty"`
+	Message       string    `json:"message,omitempty"`
+}
+
+// NotificationTemplate is the title and body of one notification in one language.
+type NotificationTemplate struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// NotificationTemplates maps a template type to its text per locale. Every
+// type has a DefaultLocale entry. The table can be extended or overridden
+// from config/notificationTemplates.json without a rebuild.
+var NotificationTemplates = map[string]map[string]NotificationTemplate{
+	TemplateBookingConfirmed: {
+		"en": {
+			Title: "Booking Confirmed!",
+			Body: `Your appointment with {{.ProviderName}} on {{datetime .When}} has been confirmed.` +
+				`{{if eq .PaymentMethod "cash"}} Please have {{money .Amount .Currency}} in cash on arrival.` +
+				`{{else}} We've successfully processed your payment of {{money .Amount .Currency}}.{{end}}`,
+		},
+		"sw": {
+			Title: "Miadi Imethibitishwa!",
+			Body: `Miadi yako na {{.ProviderName}} tarehe {{datetime .When}} imethibitishwa.` +
+				`{{if eq .PaymentMethod "cash"}} Tafadhali kuwa na {{money .Amount .Currency}} taslimu atakapofika.` +
+				`{{else}} Malipo yako ya {{money .Amount .Currency}} yamepokelewa.{{end}}`,
+		},
+		"fr": {
+			Title: "Réservation confirmée !",
+			Body: `Votre rendez-vous avec {{.ProviderName}} le {{datetime .When}} est confirmé.` +
+				`{{if eq .PaymentMethod "cash"}} Merci de prévoir {{money .Amount .Currency}} en espèces à l'arrivée.` +
+				`{{else}} Votre paiement de {{money .Amount .Currency}} a bien été reçu.{{end}}`,
+		},
+	},
+	TemplatePaymentRequired: {
+		"en": {
+			Title: "Payment Needed for Your Booking",
+			Body:  `Your booking with {{.ProviderName}} is confirmed but we couldn't process your payment. Please update your payment method to secure your appointment on {{datetime .When}}.`,
+		},
+		"sw": {
+			Title: "Malipo Yanahitajika kwa Miadi Yako",
+			Body:  `Miadi yako na {{.ProviderName}} imethibitishwa lakini hatukuweza kupokea malipo yako. Tafadhali sasisha njia yako ya malipo ili kulinda miadi yako ya {{datetime .When}}.`,
+		},
+		"fr": {
+			Title: "Paiement requis pour votre réservation",
+			Body:  `Votre réservation avec {{.ProviderName}} est confirmée mais nous n'avons pas pu traiter votre paiement. Veuillez mettre à jour votre moyen de paiement pour garantir votre rendez-vous du {{datetime .When}}.`,
+		},
+	},
+	TemplateNewBooking: {
+		"en": {
+			Title: "New Booking Received",
+			Body:  `{{.CustomerName}} booked your service for {{date .When}} at {{time .When}} - {{time .Until}}. {{.Count}} {{.Units}} remaining in this schedule slot.`,
+		},
+		"sw": {
+			Title: "Umepokea Miadi Mpya",
+			Body:  `{{.CustomerName}} amehifadhi huduma yako tarehe {{date .When}} saa {{time .When}} - {{time .Until}}. Zimebaki {{.Count}} {{.Units}} katika nafasi hii.`,
+		},
+		"fr": {
+			Title: "Nouvelle réservation",
+			Body:  `{{.CustomerName}} a réservé votre service le {{date .When}} de {{time .When}} à {{time .Until}}. Il reste {{.Count}} {{.Units}} sur ce créneau.`,
+		},
+	},
+	TemplateScheduleUpdate: {
+		"en": {
+			Title: "You’ve updated your work schedule 🗓️",
+			Body:  `Your service is now scheduled across {{.Count}} {{plural .Count "week" "weeks"}}. Active days include {{join (weekdays .Days) ", "}} — starting {{.StartDate}}. We’ll remind you as your days approach!`,
+		},
+		"sw": {
+			Title: "Umesasisha ratiba yako ya kazi 🗓️",
+			Body:  `Huduma yako sasa imepangwa kwa {{plural .Count "wiki" "wiki"}} {{.Count}}. Siku za kazi ni {{join (weekdays .Days) ", "}} — kuanzia {{.StartDate}}. Tutakukumbusha siku zako zinapokaribia!`,
+		},
+		"fr": {
+			Title: "Vous avez mis à jour votre planning 🗓️",
+			Body:  `Votre service est désormais planifié sur {{.Count}} {{plural .Count "semaine" "semaines"}}. Jours actifs : {{join (weekdays .Days) ", "}} — à partir du {{.StartDate}}. Nous vous le rappellerons à l'approche de vos journées !`,
+		},
+	},
+	TemplateBookingReminder: {
+		"en": {
+			Title: "Upcoming booking",
+			Body:  `{{if .CustomerName}}Your booking with {{.CustomerName}}{{else}}Your appointment with {{.ProviderName}}{{end}} is in {{.Count}} {{plural .Count "hour" "hours"}}, on {{datetime .When}}.`,
+		},
+		"sw": {
+			Title: "Miadi inakaribia",
+			Body:  `{{if .CustomerName}}Miadi yako na {{.CustomerName}}{{else}}Miadi yako na {{.ProviderName}}{{end}} ni baada ya {{plural .Count "saa" "saa"}} {{.Count}}, tarehe {{datetime .When}}.`,
+		},
+		"fr": {
+			Title: "Réservation à venir",
+			Body:  `{{if .CustomerName}}Votre réservation avec {{.CustomerName}}{{else}}Votre rendez-vous avec {{.ProviderName}}{{end}} a lieu dans {{.Count}} {{plural .Count "heure" "heures"}}, le {{datetime .When}}.`,
+		},
+	},
+	TemplateSafetyReminder: {
+		"en": {
+			Title: "Your booking starts soon",
+			Body: `Your booking with {{.ProviderName}} starts in {{.Minutes}} {{plural .Minutes "minute" "minutes"}}, at {{time .When}}.` +
+				`{{if eq .Mode "in_home"}} Check the provider's profile before letting them in, and share your booking with someone you trust.{{end}}`,
+		},
+		"sw": {
+			Title: "Miadi yako inaanza hivi karibuni",
+			Body: `Miadi yako na {{.ProviderName}} inaanza baada ya {{plural .Minutes "dakika" "dakika"}} {{.Minutes}}, saa {{time .When}}.` +
+				`{{if eq .Mode "in_home"}} Hakikisha wasifu wa mtoa huduma kabla ya kumruhusu kuingia, na mjulishe mtu unayemwamini kuhusu miadi yako.{{end}}`,
+		},
+		"fr": {
+			Title: "Votre réservation commence bientôt",
+			Body: `Votre réservation avec {{.ProviderName}} commence dans {{.Minutes}} {{plural .Minutes "minute" "minutes"}}, à {{time .When}}.` +
+				`{{if eq .Mode "in_home"}} Vérifiez le profil du prestataire avant de le laisser entrer et partagez votre réservation avec une personne de confiance.{{end}}`,
+		},
+	},
+	TemplateProviderNoShow: {
+		"en": {
+			Title: "Your provider hasn't arrived",
+			Body: `{{.ProviderName}} hasn't checked in for your booking at {{time .When}}. Choose a refund or book another provider in the app.` +
+				`{{if .Count}} If you don't choose within {{.Count}} {{plural .Count "hour" "hours"}}, we'll refund you automatically.{{end}}`,
+		},
+		"sw": {
+			Title: "Mtoa huduma wako hajafika",
+			Body: `{{.ProviderName}} hajathibitisha kufika kwa miadi yako ya saa {{time .When}}. Chagua kurejeshewa pesa au kumchagua mtoa huduma mwingine kwenye programu.` +
+				`{{if .Count}} Usipochagua ndani ya {{plural .Count "saa" "saa"}} {{.Count}}, tutakurejeshea pesa moja kwa moja.{{end}}`,
+		},
+		"fr": {
+			Title: "Votre prestataire n'est pas arrivé",
+			Body: `{{.ProviderName}} ne s'est pas présenté pour votre réservation de {{time .When}}. Choisissez un remboursement ou un autre prestataire dans l'application.` +
+				`{{if .Count}} Sans choix de votre part sous {{.Count}} {{plural .Count "heure" "heures"}}, vous serez remboursé automatiquement.{{end}}`,
+		},
+	},
+	TemplateMissedBooking: {
+		"en": {
+			Title: "You missed a booking",
+			Body:  `You didn't check in for the booking with {{.CustomerName}} on {{datetime .When}}. It was recorded as a no-show, which lowers your ranking.`,
+		},
+		"sw": {
+			Title: "Umekosa miadi",
+			Body:  `Hukuthibitisha kufika kwa miadi na {{.CustomerName}} tarehe {{datetime .When}}. Imerekodiwa kama kutofika, jambo linaloshusha nafasi yako.`,
+		},
+		"fr": {
+			Title: "Vous avez manqué une réservation",
+			Body:  `Vous n'avez pas signalé votre arrivée pour la réservation avec {{.CustomerName}} le {{datetime .When}}. Cette absence est enregistrée et fait baisser votre classement.`,
+		},
+	},
+	TemplateCustomerNoShow: {
+		"en": {
+			Title: "You missed your booking",
+			Body:  `{{.ProviderName}} arrived for your booking at {{time .When}} but couldn't find you. The booking was closed as a no-show and is not refunded.`,
+		},
+		"sw": {
+			Title: "Umekosa miadi yako",
+			Body:  `{{.ProviderName}} alifika kwa miadi yako ya saa {{time .When}} lakini hakukupata. Miadi imefungwa kama kutofika na pesa hazitarejeshwa.`,
+		},
+		"fr": {
+			Title: "Vous avez manqué votre réservation",
+			Body:  `{{.ProviderName}} est arrivé pour votre réservation de {{time .When}} mais ne vous a pas trouvé. La réservation est close pour absence et n'est pas remboursée.`,
+		},
+	},
+	TemplateNoShowRefund: {
+		"en": {
+			Title: "Refund issued",
+			Body: `{{if .Amount}}We've refunded {{money .Amount .Currency}} for your missed booking with {{.ProviderName}}.` +
+				`{{else}}Your missed booking with {{.ProviderName}} has been closed; you won't be charged for it.{{end}}`,
+		},
+		"sw": {
+			Title: "Pesa zimerejeshwa",
+			Body: `{{if .Amount}}Tumekurejeshea {{money .Amount .Currency}} kwa miadi uliyokosa na {{.ProviderName}}.` +
+				`{{else}}Miadi uliyokosa na {{.ProviderName}} imefungwa; hutatozwa chochote.{{end}}`,
+		},
+		"fr": {
+			Title: "Remboursement effectué",
+			Body: `{{if .Amount}}Nous vous avons remboursé {{money .Amount .Currency}} pour votre réservation manquée avec {{.ProviderName}}.` +
+				`{{else}}Votre réservation manquée avec {{.ProviderName}} est close ; elle ne vous sera pas facturée.{{end}}`,
+		},
+	},
+	TemplateProviderOnTheWay: {
+		"en": {
+			Title: "Your provider is on the way",
+			Body:  `{{.ProviderName}} is on the way{{if .Minutes}} and should arrive in about {{.Minutes}} {{plural .Minutes "minute" "minutes"}}{{end}}. Follow their location live in the app.`,
+		},
+		"sw": {
+			Title: "Mtoa huduma wako yuko njiani",
+			Body:  `{{.ProviderName}} yuko njiani{{if .Minutes}} na anatarajiwa kufika baada ya {{plural .Minutes "dakika" "dakika"}} {{.Minutes}}{{end}}. Fuatilia mahali alipo moja kwa moja kwenye programu.`,
+		},
+		"fr": {
+			Title: "Votre prestataire est en route",
+			Body:  `{{.ProviderName}} est en route{{if .Minutes}} et devrait arriver dans environ {{.Minutes}} {{plural .Minutes "minute" "minutes"}}{{end}}. Suivez sa position en direct dans l'application.`,
+		},
+	},
+	TemplateChatMessage: {
+		"en": {
+			Title: "New message from {{.Sender}}",
+			Body:  `{{if .Message}}{{.Message}}{{else}}Sent you a photo.{{end}}`,
+		},
+		"sw": {
+			Title: "Ujumbe mpya kutoka kwa {{.Sender}}",
+			Body:  `{{if .Message}}{{.Message}}{{else}}Amekutumia picha.{{end}}`,
+		},
+		"fr": {
+			Title: "Nouveau message de {{.Sender}}",
+			Body:  `{{if .Message}}{{.Message}}{{else}}Vous a envoyé une photo.{{end}}`,
+		},
+	},
+	TemplateAdjustmentRequested: {
+		"en": {
+			Title: "Approve extra charge",
+			Body:  `{{.ProviderName}} requests {{if .Count}}{{.Count}} more {{.Units}} ({{money .Amount .Currency}}){{else}}an extra {{money .Amount .Currency}}{{end}}: {{.Message}}`,
+		},
+		"sw": {
+			Title: "Idhinisha malipo ya ziada",
+			Body:  `{{.ProviderName}} anaomba {{if .Count}}{{.Units}} {{.Count}} zaidi ({{money .Amount .Currency}}){{else}}{{money .Amount .Currency}} za ziada{{end}}: {{.Message}}`,
+		},
+		"fr": {
+			Title: "Approuver un supplément",
+			Body:  `{{.ProviderName}} demande {{if .Count}}{{.Count}} {{.Units}} de plus ({{money .Amount .Currency}}){{else}}un supplément de {{money .Amount .Currency}}{{end}} : {{.Message}}`,
+		},
+	},
+	TemplateAdjustmentDeclined: {
+		"en": {
+			Title: "Extra charge declined",
+			Body:  `{{.CustomerName}} declined your {{money .Amount .Currency}} request for the booking on {{date .When}}.`,
+		},
+		"sw": {
+			Title: "Malipo ya ziada yamekataliwa",
+			Body:  `{{.CustomerName}} amekataa ombi lako la {{money .Amount .Currency}} kwa miadi ya tarehe {{date .When}}.`,
+		},
+		"fr": {
+			Title: "Supplément refusé",
+			Body:  `{{.CustomerName}} a refusé votre demande de {{money .Amount .Currency}} pour la réservation du {{date .When}}.`,
+		},
+	},
+	TemplateAdjustmentPaid: {
+		"en": {
+			Title: "Extra charge approved",
+			Body:  `{{.CustomerName}} approved {{money .Amount .Currency}} for the booking on {{date .When}}.`,
+		},
+		"sw": {
+			Title: "Malipo ya ziada yameidhinishwa",
+			Body:  `{{.CustomerName}} ameidhinisha {{money .Amount .Currency}} kwa miadi ya tarehe {{date .When}}.`,
+		},
+		"fr": {
+			Title: "Supplément approuvé",
+			Body:  `{{.CustomerName}} a approuvé {{money .Amount .Currency}} pour la réservation du {{date .When}}.`,
+		},
+	},
+	TemplateTipReceived: {
+		"en": {
+			Title: "You received a tip",
+			Body:  `{{.CustomerName}} tipped {{money .Amount .Currency}} for the booking on {{date .When}}.`,
+		},
+		"sw": {
+			Title: "Umepokea bakshishi",
+			Body:  `{{.CustomerName}} amekupa bakshishi ya {{money .Amount .Currency}} kwa miadi ya tarehe {{date .When}}.`,
+		},
+		"fr": {
+			Title: "Vous avez reçu un pourboire",
+			Body:  `{{.CustomerName}} vous a laissé un pourboire de {{money .Amount .Currency}} pour la réservation du {{date .When}}.`,
+		},
+	},
+	TemplateBalanceDeclined: {
+		"en": {
+			Title: "Balance payment declined",
+			Body:  `We couldn't charge the {{money .Amount .Currency}} balance for your booking on {{date .When}}. We'll try again shortly; check your card details.`,
+		},
+		"sw": {
+			Title: "Malipo ya salio yamekataliwa",
+			Body:  `Hatukuweza kutoza salio la {{money .Amount .Currency}} kwa miadi yako ya tarehe {{date .When}}. Tutajaribu tena hivi karibuni; hakikisha maelezo ya kadi yako.`,
+		},
+		"fr": {
+			Title: "Paiement du solde refusé",
+			Body:  `Nous n'avons pas pu prélever le solde de {{money .Amount .Currency}} pour votre réservation du {{date .When}}. Nous réessaierons sous peu ; vérifiez les informations de votre carte.`,
+		},
+	},
+	TemplateBalanceFailed: {
+		"en": {
+			Title: "Balance payment failed",
+			Body:  `We couldn't charge the {{money .Amount .Currency}} balance for your booking on {{date .When}}. Please pay in the app to keep it.`,
+		},
+		"sw": {
+			Title: "Malipo ya salio yameshindikana",
+			Body:  `Hatukuweza kutoza salio la {{money .Amount .Currency}} kwa miadi yako ya tarehe {{date .When}}. Tafadhali lipa kwenye programu ili kuihifadhi.`,
+		},
+		"fr": {
+			Title: "Échec du paiement du solde",
+			Body:  `Nous n'avons pas pu prélever le solde de {{money .Amount .Currency}} pour votre réservation du {{date .When}}. Veuillez payer dans l'application pour la conserver.`,
+		},
+	},
+	TemplateBalanceUnpaid: {
+		"en": {
+			Title: "Balance not collected",
+			Body:  `The balance for the booking with {{.CustomerName}} on {{date .When}} could not be collected. The customer has been asked to pay.`,
+		},
+		"sw": {
+			Title: "Salio halijakusanywa",
+			Body:  `Salio la miadi na {{.CustomerName}} tarehe {{date .When}} halikuweza kukusanywa. Mteja ameombwa alipe.`,
+		},
+		"fr": {
+			Title: "Solde non encaissé",
+			Body:  `Le solde de la réservation avec {{.CustomerName}} du {{date .When}} n'a pas pu être encaissé. Le client a été invité à payer.`,
+		},
+	},
+	TemplateBalancePaid: {
+		"en": {
+			Title: "Balance paid",
+			Body:  `The {{money .Amount .Currency}} balance for your booking on {{date .When}} has been paid.`,
+		},
+		"sw": {
+			Title: "Salio limelipwa",
+			Body:  `Salio la {{money .Amount .Currency}} kwa miadi yako ya tarehe {{date .When}} limelipwa.`,
+		},
+		"fr": {
+			Title: "Solde payé",
+			Body:  `Le solde de {{money .Amount .Currency}} pour votre réservation du {{date .When}} a été payé.`,
+		},
+	},
+	TemplateOTP: {
+		"en": {
+			Title: "Bloomify verification code",
+			Body:  `Your Bloomify OTP is: {{.Code}}. It expires in {{.Minutes}} {{plural .Minutes "minute" "minutes"}}.`,
+		},
+		"sw": {
+			Title: "Nambari ya uthibitisho ya Bloomify",
+			Body:  `Nambari yako ya Bloomify ni: {{.Code}}. Itaisha baada ya {{plural .Minutes "dakika" "dakika"}} {{.Minutes}}.`,
+		},
+		"fr": {
+			Title: "Code de vérification Bloomify",
+			Body:  `Votre code Bloomify est : {{.Code}}. Il expire dans {{.Minutes}} {{plural .Minutes "minute" "minutes"}}.`,
+		},
+	},
+}
+
+var (
+	templateMu    sync.RWMutex
+	templateCache = map[string]*template.Template{}
+)
+
+var weekdayIndex = map[string]int{"Sun": 0, "Mon": 1, "Tue": 2, "Wed": 3, "Thu": 4, "Fri": 5, "Sat": 6}
+
+func templateFuncs(l Locale) template.FuncMap {
+	return template.FuncMap{
+		"money":    l.FormatMoney,
+		"date":     l.FormatDate,
+		"time":     l.FormatTime,
+		"datetime": l.FormatDateTime,
+		"plural":   l.Plural,
+		"join":     strings.Join,
+		"weekdays": func(days []string) []string {
+			names := make([]string, 0, len(days))
+			for _, d := range days {
+				if i, ok := weekdayIndex[d]; ok {
+					names = append(names, l.Weekdays[i])
+				} else {
+					names = append(names, d)
+				}
+			}
+			return names
+		},
+	}
+}
+
+// RenderNotification renders a template type in the recipient's language,
+// falling back to DefaultLocale when the language has no translation.
+func RenderNotification(kind, locale string, p TemplateParams) (string, string, error) {
+	templateMu.RLock()
+	byLocale, ok := NotificationTemplates[kind]
+	templateMu.RUnlock()
+	if !ok {
+		return "", "", fmt.Errorf("unknown notification template %q", kind)
+	}
+	locale = NormalizeLocale(locale)
+	if _, ok := byLocale[locale]; !ok {
+		locale = DefaultLocale
+	}
+
+	title, err := renderTemplateText(kind, locale, "title")
+	if err != nil {
+		return "", "", err
+	}
+	body, err := renderTemplateText(kind, locale, "body")
+	if err != nil {
+		return "", "", err
+	}
+
+	var t, b strings.Builder
+	if err := title.Execute(&t, p); err != nil {
+		return "", "", fmt.Errorf("failed to render %s title: %w", kind, err)
+	}
+	if err := body.Execute(&b, p); err != nil {
+		return "", "", fmt.Errorf("failed to render %s body: %w", kind, err)
+	}
+	return t.String(), b.String(), nil
+}
+
+// renderTemplateText returns the parsed title or body of a template, parsing
+// it on first use.
+func renderTemplateText(kind, locale, part string) (*template.Template, error) {
+	key := kind + "/" + locale + "/" + part
+
+	templateMu.RLock()
+	tmpl, ok := templateCache[key]
+	tpl := NotificationTemplates[kind][locale]
+	templateMu.RUnlock()
+	if ok {
+		return tmpl, nil
+	}
+
+	text := tpl.Body
+	if part == "title" {
+		text = tpl.Title
+	}
+	tmpl, err := template.New(key).Funcs(templateFuncs(Locales[locale])).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification template %s: %w", key, err)
+	}
+
+	templateMu.Lock()
+	templateCache[key] = tmpl
+	templateMu.Unlock()
+	return tmpl, nil
+}
+
+// TemplateLocales lists the template types and the locales each is translated to.
+func TemplateLocales() map[string][]string {
+	templateMu.RLock()
+	defer templateMu.RUnlock()
+
+	out := make(map[string][]string, len(NotificationTemplates))
+	for kind, byLocale := range NotificationTemplates {
+		locales := make([]string, 0, len(byLocale))
+		for l := range byLocale {
+			locales = append(locales, l)
+		}
+		slices.Sort(locales)
+		out[kind] = locales
+	}
+	return out
+}
+
+// LoadNotificationTemplates merges templates from a JSON file shaped like
+// NotificationTemplates over the built-in ones. Every template is checked to
+// parse before any is replaced.
+func LoadNotificationTemplates(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read notification templates file: %w", err)
+	}
+	loaded := map[string]map[string]NotificationTemplate{}
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return fmt.Errorf("failed to parse notification templates JSON: %w", err)
+	}
+	for kind, byLocale := range loaded {
+		for locale, tpl := range byLocale {
+			if _, ok := Locales[locale]; !ok {
+				return fmt.Errorf("template %s: unsupported locale %q", kind, locale)
+			}
+			funcs := templateFuncs(Locales[locale])
+			for _, text := range []string{tpl.Title, tpl.Body} {
+				if _, err := template.New(kind).Funcs(funcs).Parse(text); err != nil {
+					return fmt.Errorf("template %s/%s: %w", kind, locale, err)
+				}
+			}
+		}
+	}
+
+	templateMu.Lock()
+	defer templateMu.Unlock()
+	for kind, byLocale := range loaded {
+		if NotificationTemplates[kind] == nil {
+			NotificationTemplates[kind] = map[string]NotificationTemplate{}
+		}
+		for locale, tpl := range byLocale {
+			NotificationTemplates[kind][locale] = tpl
+		}
+	}
+	templateCache = map[string]*template.Template{}
+	log.Println("Successfully loaded notification templates")
+	return nil
+}
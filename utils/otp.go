@@ -26,20 +26,24 @@ func generateSecureOTP(length int) (string, error) {
 	return otp, nil
 }
 
-// SendWhatsAppMessage sends a WhatsApp message to the given phone number.
-// Replace the body of this function with your actual integration with WhatsApp's API.
+// SendWhatsAppMessage sends a WhatsApp message to the given phone number
+// through the messaging gateway. Without a configured gateway the message is
+// only logged, which keeps development setups working.
 func SendWhatsAppMessage(phoneNumber, message string) error {
-	// For example, you could use an HTTP client to call your WhatsApp API endpoint:
-	// resp, err := http.Post("https://api.yourwhatsappprovider.com/send", "application/json", payloadReader)
-	// Handle response and errors accordingly.
-	// For now, we log the outgoing message.
-	GetLogger().Sugar().Infof("Sending WhatsApp message to %s: %s", phoneNumber, message)
-	return nil
+	gateway := GetMessagingGateway()
+	if gateway == nil {
+		GetLogger().Sugar().Infof("Sending WhatsApp message to %s: %s", phoneNumber, message)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	_, err := gateway.Send(ctx, "whatsapp", phoneNumber, message)
+	return err
 }
 
 // InitiateDeviceOTP generates an OTP, stores it in Redis with a 5-minute TTL,
-// sends it via WhatsApp, and also stores the OTP in the Test Redis with a key based on sessionID.
-func InitiateDeviceOTP(userID, deviceID, phoneNumber string) error {
+// sends it via WhatsApp in the given language, and also stores the OTP in the Test Redis with a key based on sessionID.
+func InitiateDeviceOTP(userID, deviceID, phoneNumber, locale string) error {
 	// Generate a secure 6-character OTP.
 	otp, err := generateSecureOTP(6)
 	if err != nil {
@@ -61,7 +65,11 @@ func InitiateDeviceOTP(userID, deviceID, phoneNumber string) error {
 	}
 
 	// Compose the message to send.
-	message := fmt.Sprintf("Your Bloomify OTP is: %s. It expires in 5 minutes.", otp)
+	_, message, err := RenderNotification(TemplateOTP, locale, TemplateParams{Code: otp, Minutes: int(ttl.Minutes())})
+	if err != nil {
+		GetLogger().Error("Failed to render OTP message", zap.Error(err))
+		return fmt.Errorf("failed to initiate device OTP")
+	}
 	// Send the OTP via WhatsApp.
 	if err := SendWhatsAppMessage(phoneNumber, message); err != nil {
 		GetLogger().Error("Failed to send OTP via WhatsApp", zap.Error(err))
@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// PDF fonts available to PDFDocument. They are PDF standard fonts, so nothing is embedded.
+const (
+	PDFFontRegular = "F1" // Helvetica
+	PDFFontBold    = "F2" // Helvetica-Bold
+	PDFFontMono    = "F3" // Courier, every glyph is 0.6em wide
+)
+
+// A4 page size in points.
+const (
+	PDFPageWidth  = 595.0
+	PDFPageHeight = 842.0
+)
+
+// PDFDocument is a minimal PDF 1.4 writer for text documents such as receipts.
+// Coordinates are in points from the top-left corner of the page.
+type PDFDocument struct {
+	pages []*bytes.Buffer
+}
+
+// NewPDFDocument returns a document with one empty A4 page.
+func NewPDFDocument() *PDFDocument {
+	d := &PDFDocument{}
+	d.AddPage()
+	return d
+}
+
+// AddPage starts a new page; later drawing goes to it.
+func (d *PDFDocument) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+func (d *PDFDocument) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// Text draws s with its baseline at (x, y).
+func (d *PDFDocument) Text(x, y float64, font string, size float64, s string) {
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, PDFPageHeight-y, pdfEscape(s))
+}
+
+// MonoTextRight draws s in the monospace font so that it ends at x.
+func (d *PDFDocument) MonoTextRight(x, y, size float64, s string) {
+	width := float64(len([]rune(s))) * size * 0.6
+	d.Text(x-width, y, PDFFontMono, size, s)
+}
+
+// SetColor sets the fill color used for text and rectangles; components are 0-1.
+func (d *PDFDocument) SetColor(r, g, b float64) {
+	fmt.Fprintf(d.page(), "%.3f %.3f %.3f rg\n", r, g, b)
+}
+
+// Rect fills a rectangle whose top-left corner is (x, y).
+func (d *PDFDocument) Rect(x, y, w, h float64) {
+	fmt.Fprintf(d.page(), "%.2f %.2f %.2f %.2f re f\n", x, PDFPageHeight-y-h, w, h)
+}
+
+// Line draws a straight line.
+func (d *PDFDocument) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(d.page(), "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, PDFPageHeight-y1, x2, PDFPageHeight-y2)
+}
+
+// Bytes serializes the document.
+func (d *PDFDocument) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// 1: catalog, 2: page tree, 3-5: fonts, then a page and a content stream per page.
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	for _, base := range []string{"Helvetica", "Helvetica-Bold", "Courier"} {
+		obj(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", base))
+	}
+	for i, content := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R /F3 5 0 R >> >> /Contents %d 0 R >>",
+			PDFPageWidth, PDFPageHeight, 7+2*i))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// pdfEscape encodes s as a WinAnsi PDF string literal body. Characters outside
+// Latin-1 are replaced with close ASCII equivalents or '?'.
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '–', '—':
+			b.WriteByte('-')
+		case '‘', '’':
+			b.WriteByte('\'')
+		case '“', '”':
+			b.WriteByte('"')
+		case '•':
+			b.WriteString("\\225")
+		default:
+			switch {
+			case r < 32:
+				b.WriteByte(' ')
+			case r < 128:
+				b.WriteRune(r)
+			case r < 256:
+				fmt.Fprintf(&b, "\\%03o", r)
+			default:
+				b.WriteByte('?')
+			}
+		}
+	}
+	return b.String()
+}